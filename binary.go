@@ -0,0 +1,117 @@
+package bowyer_watson
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+)
+
+// The binary triangulation format is a little-endian stream of a 4-byte
+// magic number, a 4-byte version, a 4-byte triangle count, then for each
+// triangle the coordinates A.X, A.Y, B.X, B.Y, C.X, C.Y as float64s. Version
+// 2 follows each triangle with its circumcenter X, Y and circumradius.
+const (
+	binaryMagic         = "BWTR"
+	binaryVersion       = 1
+	binaryVersionCached = 2
+)
+
+// WriteTriangles writes triangles to w in a compact binary format, which may
+// be read back with ReadTriangles.
+func WriteTriangles(w io.Writer, triangles []Triangle) error {
+	return writeTriangles(w, triangles, binaryVersion)
+}
+
+// WriteTrianglesCached is like WriteTriangles but also writes each
+// triangle's circumcircle, as calculated by CalcCircumCircle, for readers
+// that want it. ReadTriangles skips it.
+func WriteTrianglesCached(w io.Writer, triangles []Triangle) error {
+	return writeTriangles(w, triangles, binaryVersionCached)
+}
+
+func writeTriangles(w io.Writer, triangles []Triangle, version uint32) error {
+	if uint64(len(triangles)) > math.MaxUint32 {
+		return fmt.Errorf("bowyer_watson: %d triangles exceed the binary format limit", len(triangles))
+	}
+
+	bw := bufio.NewWriter(w)
+	var buf [9 * 8]byte
+	copy(buf[:4], binaryMagic)
+	binary.LittleEndian.PutUint32(buf[4:], version)
+	binary.LittleEndian.PutUint32(buf[8:], uint32(len(triangles)))
+	bw.Write(buf[:12])
+
+	n := 6
+	if version == binaryVersionCached {
+		n = 9
+	}
+	for _, t := range triangles {
+		var c Circle
+		if version == binaryVersionCached {
+			c, _ = t.CalcCircumCircle()
+		}
+		vs := [9]float64{t.A.X, t.A.Y, t.B.X, t.B.Y, t.C.X, t.C.Y, c.Center.X, c.Center.Y, c.Radius}
+		for i, f := range vs[:n] {
+			binary.LittleEndian.PutUint64(buf[8*i:], math.Float64bits(f))
+		}
+		bw.Write(buf[:8*n])
+	}
+	return bw.Flush()
+}
+
+// ReadTriangles reads triangles written by WriteTriangles or
+// WriteTrianglesCached from r.
+func ReadTriangles(r io.Reader) ([]Triangle, error) {
+	br := bufio.NewReader(r)
+	var buf [9 * 8]byte
+	if _, err := io.ReadFull(br, buf[:12]); err != nil {
+		return nil, binaryReadError(err)
+	}
+	if string(buf[:4]) != binaryMagic {
+		return nil, errors.New("bowyer_watson: not a binary triangulation")
+	}
+	version := binary.LittleEndian.Uint32(buf[4:])
+	n := 6
+	switch version {
+	case binaryVersion:
+	case binaryVersionCached:
+		n = 9
+	default:
+		return nil, fmt.Errorf("bowyer_watson: unsupported binary triangulation version %d", version)
+	}
+	count := binary.LittleEndian.Uint32(buf[8:])
+
+	// Don't trust count for the initial allocation, the input may be
+	// truncated or corrupt.
+	triangles := make([]Triangle, 0, minInt(int(count), 1<<16))
+	for i := uint32(0); i < count; i++ {
+		if _, err := io.ReadFull(br, buf[:8*n]); err != nil {
+			return nil, binaryReadError(err)
+		}
+		var vs [9]float64
+		for j := range vs[:n] {
+			vs[j] = math.Float64frombits(binary.LittleEndian.Uint64(buf[8*j:]))
+		}
+		triangles = append(triangles, Triangle{A: Point{vs[0], vs[1]}, B: Point{vs[2], vs[3]}, C: Point{vs[4], vs[5]}})
+	}
+	return triangles, nil
+}
+
+// binaryReadError converts io.EOF within a binary triangulation to
+// io.ErrUnexpectedEOF.
+func binaryReadError(err error) error {
+	if err == io.EOF {
+		return io.ErrUnexpectedEOF
+	}
+	return err
+}
+
+func minInt(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
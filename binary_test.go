@@ -0,0 +1,123 @@
+package bowyer_watson
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"testing"
+)
+
+func TestTrianglesBinary(t *testing.T) {
+	u := SweepHullTriangulation(benchmarkPoints(100))
+
+	for _, tt := range []struct {
+		name  string
+		write func(io.Writer, []Triangle) error
+		size  int
+	}{
+		{"plain", WriteTriangles, 12 + 48*len(u)},
+		{"cached", WriteTrianglesCached, 12 + 72*len(u)},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := tt.write(&buf, u); err != nil {
+				t.Fatal(err)
+			}
+			if got := buf.Len(); got != tt.size {
+				t.Errorf("size: got %v, want %v", got, tt.size)
+			}
+
+			got, err := ReadTriangles(&buf)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(got) != len(u) {
+				t.Fatalf("#triangles: got %v, want %v", len(got), len(u))
+			}
+			for i := range u {
+				if got[i] != u[i] {
+					t.Errorf("triangle %d: got %+v, want %+v", i, got[i], u[i])
+				}
+			}
+		})
+	}
+}
+
+func TestReadTrianglesErrors(t *testing.T) {
+	var buf bytes.Buffer
+	tri := []Triangle{{A: Point{0, 0}, B: Point{1, 0}, C: Point{0, 1}}}
+	if err := WriteTriangles(&buf, tri); err != nil {
+		t.Fatal(err)
+	}
+	valid := buf.Bytes()
+
+	badVersion := append([]byte(nil), valid...)
+	badVersion[4] = 9
+
+	tests := []struct {
+		name string
+		data []byte
+		want string
+	}{
+		{"empty", nil, io.ErrUnexpectedEOF.Error()},
+		{"magic", []byte("JSON\x01\x00\x00\x00\x00\x00\x00\x00"), "bowyer_watson: not a binary triangulation"},
+		{"version", badVersion, "bowyer_watson: unsupported binary triangulation version 9"},
+		{"truncated", valid[:len(valid)-1], io.ErrUnexpectedEOF.Error()},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ReadTriangles(bytes.NewReader(tt.data))
+			if err == nil || err.Error() != tt.want {
+				t.Errorf("got %v, want %v", err, tt.want)
+			}
+		})
+	}
+
+	empty := []byte("BWTR\x01\x00\x00\x00\x00\x00\x00\x00")
+	if got, err := ReadTriangles(bytes.NewReader(empty)); err != nil || len(got) != 0 {
+		t.Errorf("got %v, %v, want no triangles", got, err)
+	}
+}
+
+func BenchmarkWriteTriangles(b *testing.B) {
+	triangles := SweepHullTriangulation(benchmarkPoints(1e5))
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		WriteTriangles(io.Discard, triangles)
+	}
+}
+
+func BenchmarkWriteTrianglesJSON(b *testing.B) {
+	triangles := SweepHullTriangulation(benchmarkPoints(1e5))
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		json.NewEncoder(io.Discard).Encode(triangles)
+	}
+}
+
+func BenchmarkReadTriangles(b *testing.B) {
+	var buf bytes.Buffer
+	WriteTriangles(&buf, SweepHullTriangulation(benchmarkPoints(1e5)))
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		ReadTriangles(bytes.NewReader(buf.Bytes()))
+	}
+}
+
+func BenchmarkReadTrianglesCached(b *testing.B) {
+	var buf bytes.Buffer
+	WriteTrianglesCached(&buf, SweepHullTriangulation(benchmarkPoints(1e5)))
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		ReadTriangles(bytes.NewReader(buf.Bytes()))
+	}
+}
+
+func BenchmarkReadTrianglesJSON(b *testing.B) {
+	data, _ := json.Marshal(SweepHullTriangulation(benchmarkPoints(1e5)))
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		var ts []Triangle
+		json.Unmarshal(data, &ts)
+	}
+}
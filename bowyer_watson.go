@@ -4,88 +4,1026 @@
 package bowyer_watson
 
 import (
+	"context"
+	"errors"
+	"fmt"
 	"math"
+	"math/rand"
 	"sort"
 )
 
 // Point represents a basic x,y coordinate.
 type Point struct {
-	X, Y float64
+	X float64 `json:"x"`
+	Y float64 `json:"y"`
 }
 
+// Tolerance is the maximum per-coordinate difference at which the package
+// considers two points equal when matching vertices and edges. The default
+// of zero requires exact equality.
+var Tolerance float64
+
+// PointEqual reports whether a and b differ by no more than eps in each
+// coordinate.
+func PointEqual(a, b Point, eps float64) bool {
+	return a == b || math.Abs(a.X-b.X) <= eps && math.Abs(a.Y-b.Y) <= eps
+}
+
+// pointsByX sorts points by X, breaking ties by Y.
 type pointsByX []Point
 
 func (s pointsByX) Len() int           { return len(s) }
 func (s pointsByX) Swap(i, j int)      { s[i], s[j] = s[j], s[i] }
-func (s pointsByX) Less(i, j int) bool { return s[i].X < s[j].X }
+func (s pointsByX) Less(i, j int) bool { return s[i].X < s[j].X || s[i].X == s[j].X && s[i].Y < s[j].Y }
 
-// Triangle contains three points that form a triangle.
+// Triangle contains three points that form a triangle. It holds nothing
+// else, so two triangles with the same vertices in the same order are equal
+// and a Triangle may be used as a map key.
 type Triangle struct {
-	A, B, C         Point
+	A, B, C Point
+}
+
+// ErrDegenerateTriangle is returned by CalcCircumCircle when a triangle has
+// no circumcircle.
+var ErrDegenerateTriangle = errors.New("bowyer_watson: degenerate triangle")
+
+// CalcCircumCircle calculates and returns t's circumcircle. If t's vertices
+// are collinear, or so nearly collinear that the circumcircle cannot be
+// calculated reliably, it returns ErrDegenerateTriangle and a circle with an
+// infinite radius centered on t's centroid. Collinearity is decided exactly
+// from the sign of t's area. A triangle is nearly collinear if the
+// floating-point evaluation of its area is within its rounding error of
+// zero, which depends on how large its coordinates are compared with its
+// area, or if the circumcircle overflows.
+//
+// Nothing is cached in t. To test many points against one circumcircle,
+// calculate it once and compare their distances from its center.
+func (t *Triangle) CalcCircumCircle() (Circle, error) {
+	c, err := calcCircumcircle(t)
+	return Circle{Center: c.center, Radius: c.radius}, err
+}
+
+// circumcircle is a circumcircle as the algorithms keep it, with the squared
+// radius that they compare distances against.
+type circumcircle struct {
 	center          Point
 	radius, radius2 float64
 }
 
-// CalcCircumCircle calculates t's circumcircle and caches the results in t.
-// It must be called before using CircumcircleContains.
-func (t *Triangle) CalcCircumCircle() {
-	ab := sqr(t.A.X) + sqr(t.A.Y)
-	cd := sqr(t.B.X) + sqr(t.B.Y)
-	ef := sqr(t.C.X) + sqr(t.C.Y)
+// cachedTriangle is a triangle together with its circumcircle, calculated
+// once when the triangle is created.
+type cachedTriangle struct {
+	Triangle
+	circumcircle
+}
+
+func newCachedTriangle(t Triangle) cachedTriangle {
+	c, _ := calcCircumcircle(&t)
+	return cachedTriangle{t, c}
+}
+
+// calcCircumcircle implements CalcCircumCircle.
+func calcCircumcircle(t *Triangle) (circumcircle, error) {
+	if IsCollinear(t.A, t.B, t.C) {
+		return degenerateCircle(t), ErrDegenerateTriangle
+	}
+
+	// Working relative to A keeps the squares and products the size of the
+	// triangle rather than of its coordinates, so they neither overflow
+	// nor lose the triangle's shape to rounding when it is far from the
+	// origin.
+	bx, by := t.B.X-t.A.X, t.B.Y-t.A.Y
+	cx, cy := t.C.X-t.A.X, t.C.Y-t.A.Y
+
+	// The denominator is twice the signed area. If it is within its
+	// rounding error of zero even its sign is unreliable, and dividing by
+	// it gives a center that is huge, infinite or on the wrong side, so the
+	// triangle is treated as degenerate instead.
+	//
+	// The products are converted to float64 so that they are not fused
+	// with the sums, which would change the result on some architectures.
+	left, right := float64(bx*cy), float64(by*cx)
+	d := left - right
+	if math.Abs(d) <= circumErrBound*(math.Abs(left)+math.Abs(right)) {
+		return degenerateCircle(t), ErrDegenerateTriangle
+	}
+	// Dividing the squared lengths by d before multiplying keeps the
+	// products the size of the triangle, not its cube.
+	b2 := (float64(bx*bx) + float64(by*by)) / d
+	c2 := (float64(cx*cx) + float64(cy*cy)) / d
+	ux := (float64(cy*b2) - float64(by*c2)) / 2
+	uy := (float64(bx*c2) - float64(cx*b2)) / 2
+
+	var c circumcircle
+	c.center = Point{t.A.X + ux, t.A.Y + uy}
+	c.radius2 = float64(ux*ux) + float64(uy*uy)
+	c.radius = math.Sqrt(c.radius2)
+
+	// Extreme coordinates can still overflow.
+	if math.IsNaN(c.radius2) || math.IsInf(c.radius2, 0) || math.IsInf(c.center.X, 0) || math.IsInf(c.center.Y, 0) {
+		return degenerateCircle(t), ErrDegenerateTriangle
+	}
+	return c, nil
+}
+
+// degenerateCircle returns the circumcircle of a triangle that has none. The
+// center is t's centroid, so that it remains finite.
+func degenerateCircle(t *Triangle) circumcircle {
+	return circumcircle{
+		center:  Point{(t.A.X + t.B.X + t.C.X) / 3, (t.A.Y + t.B.Y + t.C.Y) / 3},
+		radius:  math.Inf(1),
+		radius2: math.Inf(1),
+	}
+}
+
+// isDegenerate reports whether c belongs to a triangle with no circumcircle.
+func (c *circumcircle) isDegenerate() bool {
+	return math.IsInf(c.radius2, 1)
+}
 
-	t.center.X = (ab*(t.C.Y-t.B.Y) + cd*(t.A.Y-t.C.Y) + ef*(t.B.Y-t.A.Y)) / (t.A.X*(t.C.Y-t.B.Y) + t.B.X*(t.A.Y-t.C.Y) + t.C.X*(t.B.Y-t.A.Y)) / 2
-	t.center.Y = (ab*(t.C.X-t.B.X) + cd*(t.A.X-t.C.X) + ef*(t.B.X-t.A.X)) / (t.A.Y*(t.C.X-t.B.X) + t.B.Y*(t.A.X-t.C.X) + t.C.Y*(t.B.X-t.A.X)) / 2
-	t.radius2 = sqr(t.A.X-t.center.X) + sqr(t.A.Y-t.center.Y)
-	t.radius = math.Sqrt(t.radius2)
+// contains reports whether p is within c, including on it. A degenerate
+// circle contains no points.
+func (c *circumcircle) contains(p Point) bool {
+	if c.isDegenerate() {
+		return false
+	}
+	return sqr(p.X-c.center.X)+sqr(p.Y-c.center.Y) <= c.radius2
 }
 
-// HasVertex determine if p is one of t's vertices.
+// IsDegenerate reports whether t has no circumcircle, because its vertices
+// are collinear or so nearly so that CalcCircumCircle fails.
+func (t *Triangle) IsDegenerate() bool {
+	_, err := calcCircumcircle(t)
+	return err != nil
+}
+
+// IsCollinear reports whether a, b and c lie on a single line. The test is
+// exact, free of rounding error.
+func IsCollinear(a, b, c Point) bool {
+	return orient(a, b, c) == 0
+}
+
+// SignedArea returns the area of t, positive if its vertices are in
+// counter-clockwise order and negative if they are clockwise. The sign is
+// exact, so it is zero only if the vertices are collinear.
+func (t *Triangle) SignedArea() float64 {
+	return orient(t.A, t.B, t.C) / 2
+}
+
+// NormalizeOrientation puts the vertices of every clockwise triangle in
+// counter-clockwise order by swapping B and C, and returns triangles, which
+// is modified in place. Degenerate triangles are left alone.
+// DelaunayTriangulation, DivideAndConquer and SweepHullTriangulation already
+// return counter-clockwise triangles; this is for triangles from other
+// sources.
+func NormalizeOrientation(triangles []Triangle) []Triangle {
+	for i := range triangles {
+		t := &triangles[i]
+		if t.SignedArea() < 0 {
+			t.B, t.C = t.C, t.B
+		}
+	}
+	return triangles
+}
+
+// SortTriangles puts triangles in a canonical order, in place. The vertices
+// of each triangle are rotated, preserving their orientation, so that A is
+// the first in (X, Y) order, and then the triangles are sorted by A, B and C
+// in (X, Y) order. Two slices holding the same triangles, with the same
+// orientations, are equal after sorting.
+func SortTriangles(triangles []Triangle) {
+	for i := range triangles {
+		t := &triangles[i]
+		if lexLess(t.B, t.A) && !lexLess(t.C, t.B) {
+			t.A, t.B, t.C = t.B, t.C, t.A
+		} else if lexLess(t.C, t.A) {
+			t.A, t.B, t.C = t.C, t.A, t.B
+		}
+	}
+	sort.Slice(triangles, func(i, j int) bool {
+		a, b := &triangles[i], &triangles[j]
+		if a.A != b.A {
+			return lexLess(a.A, b.A)
+		}
+		if a.B != b.B {
+			return lexLess(a.B, b.B)
+		}
+		return lexLess(a.C, b.C)
+	})
+}
+
+// HasVertex determine if p is one of t's vertices, within Tolerance.
 func (t *Triangle) HasVertex(p Point) bool {
-	return t.A == p || t.B == p || t.C == p
+	return PointEqual(t.A, p, Tolerance) || PointEqual(t.B, p, Tolerance) || PointEqual(t.C, p, Tolerance)
 }
 
 // CircumcircleContains determines if p is contained within the circumcircle
 // of t. A circumcircle is the circle whose circumference contains all 3
-// vertices of a triangle.
+// vertices of a triangle. Points on the circle, including t's vertices, are
+// contained; see CircumcircleContainsStrict. A degenerate triangle contains
+// no points.
+//
+// DelaunayTriangulation uses neither test: by default it uses
+// EuclideanInCircle, which is exact and never finds a point on the circle.
 func (t *Triangle) CircumcircleContains(p Point) bool {
-	dist2 := sqr(p.X-t.center.X) + sqr(p.Y-t.center.Y)
-	return dist2 <= t.radius2
+	c, _ := calcCircumcircle(t)
+	return c.contains(p)
+}
+
+// CircumcircleContainsStrict is like CircumcircleContains, but points on the
+// circle, including t's vertices, are not contained. Both calculate the
+// circumcircle and compare distances to its center, so points within
+// rounding error of the circle may be classified either way.
+func (t *Triangle) CircumcircleContainsStrict(p Point) bool {
+	c, _ := calcCircumcircle(t)
+	if c.isDegenerate() {
+		return false
+	}
+	return sqr(p.X-c.center.X)+sqr(p.Y-c.center.Y) < c.radius2
+}
+
+// InCircumcircle reports whether p lies strictly inside the circle through
+// a, b and c, which may be in either order. It evaluates the in-circle
+// determinant exactly, so unlike CircumcircleContains it is never misled by
+// rounding and needs no circumcircle: a point exactly on the circle is
+// outside. If a, b and c are collinear there is no circle and it returns
+// false.
+func InCircumcircle(a, b, c, p Point) bool {
+	o := orient(a, b, c)
+	if o == 0 {
+		return false
+	}
+	if o < 0 {
+		b, c = c, b
+	}
+	return inCircle(a, b, c, p) > 0
+}
+
+// Circle is a circle in the plane.
+type Circle struct {
+	Center Point   `json:"center"`
+	Radius float64 `json:"radius"`
+}
+
+// Circumcircles returns the circumcircle of each element of triangles, in
+// the same order, as calculated by CalcCircumCircle. A degenerate triangle
+// has an infinite radius, centered on its centroid.
+func Circumcircles(triangles []Triangle) []Circle {
+	circles := make([]Circle, len(triangles))
+	for i := range triangles {
+		circles[i], _ = triangles[i].CalcCircumCircle()
+	}
+	return circles
 }
 
 // Edge is a line segment.
 type Edge struct {
-	A, B Point
+	A Point `json:"a"`
+	B Point `json:"b"`
 }
 
-// isEqual returns true if e2 is an equivalent edge to e1.
+// isEqual returns true if e2 is an equivalent edge to e1, within Tolerance.
 func (e1 Edge) isEqual(e2 Edge) bool {
-	return (e1.A == e2.A && e1.B == e2.B || e1.A == e2.B && e1.B == e2.A)
+	return (PointEqual(e1.A, e2.A, Tolerance) && PointEqual(e1.B, e2.B, Tolerance) ||
+		PointEqual(e1.A, e2.B, Tolerance) && PointEqual(e1.B, e2.A, Tolerance))
+}
+
+// canonical returns e with its endpoints ordered by X, then Y, so that
+// equivalent edges compare equal with == and may be used as map keys.
+func (e Edge) canonical() Edge {
+	if e.B.X < e.A.X || e.B.X == e.A.X && e.B.Y < e.A.Y {
+		e.A, e.B = e.B, e.A
+	}
+	return e
+}
+
+// ValidatePoints checks that every element of points lies within the
+// circumcircle of super. It returns a PointError for
+// ErrPointOutsideCircumcircle identifying the first point that does not.
+// DelaunayTriangulation requires the stronger condition that every point
+// lies inside super itself.
+func ValidatePoints(points []Point, super Triangle) error {
+	c, _ := calcCircumcircle(&super)
+	for i, p := range points {
+		if !c.contains(p) {
+			return &PointError{Index: i, Point: p, Err: ErrPointOutsideCircumcircle}
+		}
+	}
+	return nil
+}
+
+// Errors returned by DelaunayTriangulation.
+var (
+	// ErrEmptyInput means there were no points to triangulate.
+	ErrEmptyInput = errors.New("bowyer_watson: no input points")
+
+	// ErrInvalidPoint means a point had a NaN or infinite coordinate. It
+	// is wrapped in a PointError naming the point.
+	ErrInvalidPoint = errors.New("bowyer_watson: point has a NaN or infinite coordinate")
+
+	// ErrPointOutsideSuper means a point was not inside the super
+	// triangle. It is wrapped in a PointError naming the point.
+	ErrPointOutsideSuper = errors.New("bowyer_watson: point is outside the super triangle")
+
+	// ErrPointOutsideCircumcircle means ValidatePoints found a point
+	// outside the circumcircle of the super triangle. It is wrapped in a
+	// PointError naming the point.
+	ErrPointOutsideCircumcircle = errors.New("bowyer_watson: point is outside the circumcircle of the super triangle")
+
+	// ErrSuperTooSmall means a point was inside the super triangle but
+	// nearer to its edges than MinSuperMargin allows, or, even with
+	// WithHullCompletion, exactly on one of them. It is wrapped in a
+	// PointError naming the point.
+	ErrSuperTooSmall = errors.New("bowyer_watson: super triangle is too small")
+
+	// ErrDegenerateSuper means the vertices of the super triangle are
+	// collinear.
+	ErrDegenerateSuper = errors.New("bowyer_watson: super triangle is degenerate")
+
+	// ErrDuplicatePoint means two input points were equal within
+	// Tolerance and RejectDuplicates was in effect. It is wrapped in a
+	// PointError naming the duplicate.
+	ErrDuplicatePoint = errors.New("bowyer_watson: duplicate point")
+
+	// ErrTooFewPoints means there were fewer than three distinct points,
+	// too few to form a triangle. DelaunayEdges accepts such input.
+	ErrTooFewPoints = errors.New("bowyer_watson: fewer than three distinct points")
+
+	// ErrCollinearInput means there were at least three distinct points
+	// but they all lie on one line, within Tolerance, so that no triangle
+	// can be formed. DelaunayEdges accepts such input.
+	ErrCollinearInput = errors.New("bowyer_watson: all points are collinear")
+
+	// ErrTooManyTriangles means the triangulation grew past the limit set
+	// with WithMaxTriangles, which happens only if the in-circle test is
+	// inconsistent. It is returned wrapped in a *LimitError.
+	ErrTooManyTriangles = errors.New("bowyer_watson: too many triangles")
+)
+
+// PointError records an error caused by one of the input points.
+type PointError struct {
+	Index int   // the index of the point in the input, or -1 if there is none
+	Point Point // the point
+	Err   error // the reason, such as ErrPointOutsideSuper
+}
+
+func (e *PointError) Error() string {
+	return fmt.Sprintf("%v: point %d %v", e.Err, e.Index, e.Point)
+}
+
+// Unwrap returns e.Err.
+func (e *PointError) Unwrap() error { return e.Err }
+
+// LimitError records the state of a triangulation stopped because it held
+// more triangles than allowed by WithMaxTriangles.
+type LimitError struct {
+	Inserted  int // the number of points inserted, including the last one
+	Total     int // the number of points to insert
+	Triangles int // the number of triangles, including ghosts
+	Max       int // the limit
+}
+
+func (e *LimitError) Error() string {
+	return fmt.Sprintf("%v: %d triangles, limit %d, after %d of %d points",
+		ErrTooManyTriangles, e.Triangles, e.Max, e.Inserted, e.Total)
+}
+
+// Unwrap returns ErrTooManyTriangles.
+func (e *LimitError) Unwrap() error { return ErrTooManyTriangles }
+
+// MinSuperMargin is the margin, as a multiple of the larger side of the
+// points' bounding box, that DelaunayTriangulation requires between every
+// point and the edges of the super triangle. Each point must lie inside
+// super with a square of half-width MinSuperMargin times that side centered
+// on it. A super triangle that merely contains the points can still yield
+// too few triangles, because the vertices of super fall inside the
+// circumcircles of triangles on the convex hull and those triangles are
+// never formed. The margin is a heuristic: it makes that rare for points
+// that are not nearly collinear along the hull, but no finite super
+// triangle rules it out. See SuperTriangleFor, and WithHullCompletion,
+// which lifts the requirement and restores the missing triangles.
+const MinSuperMargin = 1
+
+// SuperTriangleFor returns a super triangle for points that contains their
+// bounding box enlarged on every side by margin times its larger side, so
+// that it satisfies MinSuperMargin whenever margin is at least
+// MinSuperMargin. A margin below MinSuperMargin is raised to it. Larger
+// margins lose fewer triangles along the convex hull. If the points are
+// all equal, or there are none, the box is taken to have unit size.
+func SuperTriangleFor(points []Point, margin float64) Triangle {
+	b := PointsBounds(points)
+	if len(points) == 0 {
+		b = BoundingBox{}
+	}
+	size := math.Max(b.Max.X-b.Min.X, b.Max.Y-b.Min.Y)
+	if size == 0 {
+		size = 1
+	}
+	// Add a little to the margin so that rounding cannot put the
+	// enlarged box outside the result.
+	m := (math.Max(margin, MinSuperMargin) + 1e-6) * size
+	x0, y0 := b.Min.X-m, b.Min.Y-m
+	x1, y1 := b.Max.X+m, b.Max.Y+m
+
+	// The sides slope at 45 degrees through the top corners of the box.
+	w, h := x1-x0, y1-y0
+	return Triangle{
+		A: Point{x0 - h, y0},
+		B: Point{x1 + h, y0},
+		C: Point{(x0 + x1) / 2, y1 + w/2},
+	}
+}
+
+// isFinite reports whether both coordinates of p are finite.
+func isFinite(p Point) bool {
+	return !math.IsNaN(p.X) && !math.IsInf(p.X, 0) && !math.IsNaN(p.Y) && !math.IsInf(p.Y, 0)
+}
+
+// checkFinite returns a PointError for the first element of points with a
+// NaN or infinite coordinate.
+func checkFinite(points []Point) error {
+	for i, p := range points {
+		if !isFinite(p) {
+			return &PointError{Index: i, Point: p, Err: ErrInvalidPoint}
+		}
+	}
+	return nil
+}
+
+// insideTriangle reports whether p lies inside t, which must be
+// counter-clockwise, or on its boundary.
+func insideTriangle(t Triangle, p Point) bool {
+	return orient(t.A, t.B, p) >= 0 && orient(t.B, t.C, p) >= 0 && orient(t.C, t.A, p) >= 0
+}
+
+// checkInsideSuper returns a PointError for the first element of points
+// that is not strictly inside super, which must be counter-clockwise. A
+// point on the boundary of super would be joined to the edge it lies on by
+// a degenerate triangle, and the triangles around it would be discarded
+// with the vertices of super, so it is reported as ErrSuperTooSmall.
+func checkInsideSuper(points []Point, super Triangle) error {
+	for i, p := range points {
+		if !insideTriangle(super, p) {
+			return &PointError{Index: i, Point: p, Err: ErrPointOutsideSuper}
+		}
+		if orient(super.A, super.B, p) == 0 || orient(super.B, super.C, p) == 0 || orient(super.C, super.A, p) == 0 {
+			return &PointError{Index: i, Point: p, Err: ErrSuperTooSmall}
+		}
+	}
+	return nil
+}
+
+// checkSuperMargin returns a PointError for the first element of points
+// that is nearer to the edges of super, which must be counter-clockwise,
+// than MinSuperMargin allows.
+func checkSuperMargin(points []Point, super Triangle) error {
+	b := PointsBounds(points)
+	m := MinSuperMargin * math.Max(b.Max.X-b.Min.X, b.Max.Y-b.Min.Y)
+	for i, p := range points {
+		for _, q := range [...]Point{{p.X - m, p.Y - m}, {p.X + m, p.Y - m}, {p.X + m, p.Y + m}, {p.X - m, p.Y + m}} {
+			if !insideTriangle(super, q) {
+				return &PointError{Index: i, Point: p, Err: ErrSuperTooSmall}
+			}
+		}
+	}
+	return nil
+}
+
+// retireSlack is the relative margin by which a point must pass the right
+// edge of a triangle's cached circumcircle before DelaunayTriangulation
+// stops testing later points against it. The margin allows for rounding
+// error in the cache, the in-circle test itself is exact.
+const retireSlack = 1e-9
+
+// DuplicateMode selects how DelaunayTriangulation handles input points that
+// are equal, within Tolerance, to another input point.
+type DuplicateMode int
+
+const (
+	// SkipDuplicates triangulates one point of each group of equal points
+	// and ignores the rest.
+	SkipDuplicates DuplicateMode = iota
+
+	// RejectDuplicates fails with ErrDuplicatePoint.
+	RejectDuplicates
+)
+
+// An Option configures DelaunayTriangulation.
+type Option func(*options)
+
+type options struct {
+	duplicates   DuplicateMode
+	tolerance    float64 // negative for Tolerance
+	presorted    bool
+	inCircle     InCircle
+	sorted       bool
+	gridCellSize float64 // negative if no grid is used
+	progress     func(done, total int)
+	onStep       func(step int, current []Triangle, inserted Point)
+	ctx          context.Context
+	super        Triangle
+	superSet     bool
+	normalize    bool
+	completeHull bool
+	maxTriangles int // zero for the default
+	canonical    bool
+}
+
+// WithDuplicates sets how duplicate input points are handled. The default is
+// SkipDuplicates.
+func WithDuplicates(mode DuplicateMode) Option {
+	return func(o *options) { o.duplicates = mode }
+}
+
+// WithDuplicateTolerance merges input points that differ by no more than
+// eps in each coordinate, as PointEqual does, instead of those equal within
+// Tolerance. A negative eps is taken as zero, merging only equal points.
+func WithDuplicateTolerance(eps float64) Option {
+	return func(o *options) { o.tolerance = math.Max(eps, 0) }
+}
+
+// WithPresortedInput tells DelaunayTriangulation that points are already
+// sorted by X, then Y, as for insertion. The order is confirmed with one
+// pass over the points, which are sorted anyway if it is wrong, so the
+// option saves the sort but never changes the result.
+func WithPresortedInput() Option {
+	return func(o *options) { o.presorted = true }
+}
+
+// InCircle reports whether p lies strictly inside the circumcircle of t,
+// whose vertices are in counter-clockwise order. It is the predicate
+// DelaunayTriangulation uses to find the triangles a new point invalidates.
+type InCircle func(t *Triangle, p Point) bool
+
+// EuclideanInCircle is the default InCircle. Its result is exact. If p lies
+// exactly on the circumcircle the tie is broken by a symbolic perturbation
+// that depends only on the coordinates of p and t's vertices, so cocircular
+// points, such as those of a regular grid, are triangulated the same way
+// whatever order they are given in. Of four cocircular points, the one
+// last in (X, Y) order is treated as outside the circle through the other
+// three, so the triangle they form is kept: a square is split by the
+// diagonal that does not touch its top right corner.
+func EuclideanInCircle(t *Triangle, p Point) bool {
+	return inCirclePerturbed(t.A, t.B, t.C, p)
+}
+
+// WithInCircle sets the predicate used to find the triangles invalidated by
+// each new point. The default is EuclideanInCircle. Since a custom predicate
+// need not agree with the Euclidean circumcircle, triangles are then kept
+// under test until the end rather than retired once the sweep passes them.
+func WithInCircle(f InCircle) Option {
+	return func(o *options) { o.inCircle = f }
+}
+
+// WithSortedOutput puts the returned triangles in the canonical order of
+// SortTriangles. Otherwise their order depends on the order in which they
+// were completed, which can change when the input changes slightly.
+func WithSortedOutput() Option {
+	return func(o *options) { o.sorted = true }
+}
+
+// WithCanonical makes the result a function of the set of points alone,
+// however they are ordered or repeated: the points are sorted and
+// deduplicated internally even if WithPresortedInput is given, zeros of
+// either sign are taken as positive, cocircular points are resolved by the
+// symbolic perturbation of EuclideanInCircle, which depends only on the
+// points' (X, Y) order, even if WithInCircle is given, and the triangles
+// are returned in the order of SortTriangles. The output of two calls is
+// then identical, not merely equivalent, which suits caching and diffing.
+func WithCanonical() Option {
+	return func(o *options) { o.canonical = true }
+}
+
+// WithGridIndex finds the triangles invalidated by each new point with a
+// GridIndex over the bounding boxes of their circumcircles instead of
+// testing every triangle that the sweep has not yet passed. The points are
+// inserted in a pseudo-random order and the grid is refined as they are
+// added, down to cells of side cellSize, or to about one point per cell if
+// cellSize is zero or negative. For points that are spread fairly evenly
+// this scales better than the default, which it overtakes at around a
+// hundred thousand points. It has no effect with WithInCircle.
+func WithGridIndex(cellSize float64) Option {
+	return func(o *options) { o.gridCellSize = math.Max(cellSize, 0) }
+}
+
+// WithProgress calls f after each point is inserted with the number of
+// points inserted so far and the total number to insert, which excludes
+// skipped duplicates. f is called from the goroutine running the
+// triangulation and should return quickly.
+func WithProgress(f func(done, total int)) Option {
+	return func(o *options) { o.progress = f }
+}
+
+// WithStepCallback calls f after each point is inserted and the cavity it
+// leaves is filled, with the number of points inserted so far, a copy of
+// every triangle in the triangulation at that moment, including those
+// joined to the super triangle, and the point just inserted. It makes the
+// insertion steps observable, for example to animate the algorithm. The
+// points are inserted in order of increasing X, or in a random order with
+// WithGridIndex. f is called from the goroutine running the triangulation,
+// before any progress function, and owns current. Copying the triangles
+// takes time proportional to their number at every step.
+func WithStepCallback(f func(step int, current []Triangle, inserted Point)) Option {
+	return func(o *options) { o.onStep = f }
+}
+
+// WithProgressChan is like WithProgress but sends the number of points
+// inserted so far on ch. The sends do not block: an update is dropped if ch
+// is not ready to receive it, so a receiver that falls behind sees only
+// some of the counts.
+func WithProgressChan(ch chan<- int) Option {
+	return WithProgress(func(done, total int) {
+		select {
+		case ch <- done:
+		default:
+		}
+	})
+}
+
+// WithNormalization translates and scales the points to unit size around
+// the origin before triangulating them, and maps the triangles back to the
+// original points afterwards. It preserves precision for points far from
+// the origin, such as projected map coordinates, where the circumcircles
+// cached during triangulation otherwise lose accuracy and the output can
+// overlap. The translation rounds, so points that differ only in the last
+// few bits of large coordinates may be merged, and connectivity is that of
+// the rounded points. A custom InCircle sees the normalized coordinates.
+func WithNormalization() Option {
+	return func(o *options) { o.normalize = true }
+}
+
+// WithHullCompletion makes the result always cover the convex hull of the
+// points, adding the Delaunay triangles that are missing along the hull
+// because a vertex of super fell inside their circumcircles. The points need
+// only lie inside super, not with the margin given by MinSuperMargin. The
+// added triangles are computed with the Euclidean in-circle test, even if
+// WithInCircle is given. DelaunayTriangulationWithGhosts still returns the
+// ghosts of the triangulation before completion, which may overlap the
+// added triangles.
+func WithHullCompletion() Option {
+	return func(o *options) { o.completeHull = true }
+}
+
+// WithMaxTriangles stops the triangulation with a *LimitError if it ever
+// holds more than n triangles, counting those joined to the super triangle.
+// After k points are inserted a triangulation holds 2k+1 triangles, so the
+// limit is reached only if the in-circle test is inconsistent, as a custom
+// one given with WithInCircle may be, and the triangulation would otherwise
+// exhaust memory. The default, used if n is not positive, is 4 times the
+// number of distinct points plus 16.
+func WithMaxTriangles(n int) Option {
+	return func(o *options) { o.maxTriangles = n }
+}
+
+// WithContext stops the triangulation early, returning ctx.Err(), if ctx is
+// done before it is complete, like DelaunayTriangulationCtx. It replaces the
+// context given to DelaunayTriangulationCtx.
+func WithContext(ctx context.Context) Option {
+	return func(o *options) { o.ctx = ctx }
+}
+
+// WithSuperTriangle sets the super triangle used by Triangulate. Functions
+// that take a super triangle as an argument ignore it.
+func WithSuperTriangle(super Triangle) Option {
+	return func(o *options) { o.super, o.superSet = super, true }
+}
+
+// WithAutoSuper has Triangulate construct its super triangle with
+// SuperTriangleFor and AutoSuperMargin, undoing any earlier
+// WithSuperTriangle. It is the default.
+func WithAutoSuper() Option {
+	return func(o *options) { o.super, o.superSet = Triangle{}, false }
+}
+
+// AutoSuperMargin is the margin Triangulate passes to SuperTriangleFor when
+// no super triangle is given. It is large enough that triangles are lost
+// along the convex hull only where the hull is very nearly straight.
+const AutoSuperMargin = 1e6
+
+// Triangulate is like DelaunayTriangulation but takes the super triangle
+// as an option, see WithSuperTriangle. If none is given one is constructed
+// to enclose points, so callers need not choose one.
+func Triangulate(points []Point, opts ...Option) ([]Triangle, error) {
+	var o options
+	for _, opt := range opts {
+		opt(&o)
+	}
+	super := o.super
+	if !o.superSet {
+		if err := checkFinite(points); err != nil {
+			return nil, err
+		}
+		super = SuperTriangleFor(points, AutoSuperMargin)
+	}
+	interior, _, err := triangulate(context.Background(), points, super, opts, new(Triangulator))
+	return interior, err
 }
 
 // DelaunayTriangulation returns the triangles in the Delaunay triangulation
-// of points. All elements of points must lie within super. Source for
-// algorithm: paulbourke.net/papers/triangulate
-func DelaunayTriangulation(points []Point, super Triangle) []Triangle {
-	super.CalcCircumCircle()
-	ts := []Triangle{super}
-
-	pts := make([]Point, len(points))
-	copy(pts, points)
-	sort.Sort(pointsByX(pts))
-
-	var result []Triangle
-	var edges []Edge
-	for _, p := range pts {
+// of points, with their vertices in counter-clockwise order, configured by
+// opts. All elements of points must lie inside super, with the margin given
+// by MinSuperMargin; SuperTriangleFor constructs a suitable super. Duplicate
+// points are ignored unless RejectDuplicates is given. It returns an error
+// if the input cannot be triangulated, see the Err variables.
+//
+// Points are inserted in (X, Y) order, so the result, including the order
+// of the triangles, does not depend on the order of points, except in which
+// of several equal points is kept. Nor does it depend on the architecture:
+// no floating-point expression is left for the compiler to fuse.
+//
+// Source for algorithm: paulbourke.net/papers/triangulate
+func DelaunayTriangulation(points []Point, super Triangle, opts ...Option) ([]Triangle, error) {
+	interior, _, err := DelaunayTriangulationWithGhosts(points, super, opts...)
+	return interior, err
+}
+
+// DelaunayTriangulationWithGhosts is like DelaunayTriangulation but also
+// returns the ghost triangles, those with a vertex of super, which
+// DelaunayTriangulation discards. Together the interior and ghost triangles
+// tile super, so the ghosts represent the region outside the convex hull
+// of points: an interior triangle edge shared with a ghost is a hull edge,
+// and a walk that leaves the hull steps into a ghost instead of falling off
+// the mesh. The vertices of super appear in the ghosts in counter-clockwise
+// order, whatever their order in super. The ghosts are not in general
+// Delaunay with respect to the vertices of super.
+func DelaunayTriangulationWithGhosts(points []Point, super Triangle, opts ...Option) (interior, ghosts []Triangle, err error) {
+	return triangulate(context.Background(), points, super, opts, new(Triangulator))
+}
+
+// DelaunayTriangulationE is like DelaunayTriangulation but skips the points
+// it would otherwise reject, those with a NaN or infinite coordinate and
+// those not strictly inside super, and reports the indices in points of
+// every point that is not a vertex of the result, in increasing order.
+// Besides the skipped points these are the duplicates, of which the first
+// occurrence is kept unless another point merged with them within
+// Tolerance is kept instead, and any point left out of the triangles near
+// the convex hull, as can happen without WithHullCompletion. Each other
+// element of points is a vertex of some triangle.
+//
+// The other errors of DelaunayTriangulation are still returned, with the
+// index of a PointError referring to points.
+func DelaunayTriangulationE(points []Point, super Triangle, opts ...Option) (triangles []Triangle, omitted []int, err error) {
+	if orient(super.A, super.B, super.C) < 0 {
+		super.B, super.C = super.C, super.B
+	}
+	valid := make([]Point, 0, len(points))
+	index := make([]int, 0, len(points)) // the index in points of each element of valid
+	for i, p := range points {
+		if !isFinite(p) || checkInsideSuper([]Point{p}, super) != nil {
+			continue
+		}
+		valid = append(valid, p)
+		index = append(index, i)
+	}
+
+	triangles, _, err = triangulate(context.Background(), valid, super, opts, new(Triangulator))
+	if err != nil {
+		if pe, ok := err.(*PointError); ok && pe.Index >= 0 {
+			pe.Index = index[pe.Index]
+		}
+		return nil, nil, err
+	}
+
+	// Each vertex is claimed by the first point with its coordinates.
+	unclaimed := make(map[Point]bool, len(valid))
+	for _, t := range triangles {
+		unclaimed[t.A], unclaimed[t.B], unclaimed[t.C] = true, true, true
+	}
+	for i, p := range points {
+		if unclaimed[p] {
+			unclaimed[p] = false
+			continue
+		}
+		omitted = append(omitted, i)
+	}
+	return triangles, omitted, nil
+}
+
+// DelaunayTriangulationCtx is like DelaunayTriangulation but stops early and
+// returns ctx.Err() if ctx is done before the triangulation is complete.
+// The context is checked every ctxCheckInterval points.
+func DelaunayTriangulationCtx(ctx context.Context, points []Point, super Triangle, opts ...Option) ([]Triangle, error) {
+	interior, _, err := triangulate(ctx, points, super, opts, new(Triangulator))
+	return interior, err
+}
+
+// ctxCheckInterval is the number of points DelaunayTriangulationCtx
+// inserts between checks of its context.
+const ctxCheckInterval = 1024
+
+// triangulate implements DelaunayTriangulationWithGhosts, checking ctx
+// periodically. Its working storage and results reuse the buffers in buf.
+func triangulate(ctx context.Context, points []Point, super Triangle, opts []Option, buf *Triangulator) (interior, ghosts []Triangle, err error) {
+	if len(points) == 0 {
+		return nil, nil, ErrEmptyInput
+	}
+
+	o := options{gridCellSize: -1, tolerance: -1}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	if o.ctx != nil {
+		ctx = o.ctx
+	}
+	if o.canonical {
+		o.presorted, o.inCircle, o.sorted = false, nil, true
+	}
+	retire := o.inCircle == nil
+	if retire {
+		o.inCircle = EuclideanInCircle
+	}
+
+	if super.IsDegenerate() {
+		return nil, nil, ErrDegenerateSuper
+	}
+	if orient(super.A, super.B, super.C) < 0 {
+		super.B, super.C = super.C, super.B
+	}
+	if err := checkFinite(points); err != nil {
+		return nil, nil, err
+	}
+	if err := checkInsideSuper(points, super); err != nil {
+		return nil, nil, err
+	}
+	pts := append(buf.pts[:0], points...)
+	if o.canonical {
+		for i := range pts {
+			// Adding zero turns -0 into +0 and leaves other values alone.
+			pts[i].X += 0
+			pts[i].Y += 0
+		}
+	}
+	if !o.presorted || !sort.IsSorted(pointsByX(pts)) {
+		sort.Stable(pointsByX(pts))
+	}
+	tol := Tolerance
+	if o.tolerance >= 0 {
+		tol = o.tolerance
+	}
+	pts, err = dedupSorted(pts, tol, o.duplicates == RejectDuplicates)
+	if err != nil {
+		if pe, ok := err.(*PointError); ok {
+			pe.Index = duplicateIndex(points, pe.Point)
+		}
+		return nil, nil, err
+	}
+
+	if len(pts) < 3 {
+		return nil, nil, ErrTooFewPoints
+	}
+	if collinear(pts) {
+		return nil, nil, ErrCollinearInput
+	}
+	if !o.completeHull {
+		if err := checkSuperMargin(points, super); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	limit := o.maxTriangles
+	if limit <= 0 {
+		limit = 4*len(pts) + 16
+	}
+
+	var original map[Point]Point
+	normSuper := super
+	if o.normalize {
+		pts, original = normalize(pts, &normSuper)
+	}
+
+	onStep := o.onStep
+	if onStep != nil && o.normalize {
+		// Show the callback the original coordinates.
+		f := onStep
+		onStep = func(step int, current []Triangle, inserted Point) {
+			for i := range current {
+				t := &current[i]
+				t.A, t.B, t.C = original[t.A], original[t.B], original[t.C]
+			}
+			f(step, current, original[inserted])
+		}
+	}
+
+	// Like retirement, the grid relies on the Euclidean circumcircle.
+	var result []cachedTriangle
+	if o.gridCellSize >= 0 && retire {
+		result, err = insertGridded(ctx, pts, normSuper, o.gridCellSize, limit, onStep, o.progress, buf)
+	} else {
+		result, err = insertSweep(ctx, pts, normSuper, o.inCircle, retire, limit, onStep, o.progress, buf)
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	if o.normalize {
+		denormalize(result, original)
+	}
+
+	//Move any triangles using the Points of the super to ghosts, and remove
+	//any degenerate triangles created by rounding error
+	ghosts = buf.ghosts[:0]
+	for i := 0; i < len(result); {
+		t := &result[i]
+		if !t.isDegenerate() {
+			if !t.HasVertex(super.A) && !t.HasVertex(super.B) && !t.HasVertex(super.C) {
+				i++
+				continue
+			}
+			ghosts = append(ghosts, t.Triangle)
+		}
+		n := len(result) - 1
+		result[i] = result[n]
+		result = result[:n]
+	}
+	interior = buf.interior[:0]
+	for _, t := range result {
+		interior = append(interior, t.Triangle)
+	}
+	if o.completeHull {
+		interior = completeHull(interior, ghosts, super)
+	}
+
+	buf.pts, buf.result, buf.interior, buf.ghosts = pts, result, interior, ghosts
+
+	if o.sorted {
+		SortTriangles(interior)
+		SortTriangles(ghosts)
+	}
+	return interior, ghosts, nil
+}
+
+// duplicateIndex returns the index in points of p, which sortDedup found to
+// duplicate another point: its second occurrence if it occurs more than
+// once, and otherwise its only one, since it is equal to another point only
+// within Tolerance.
+func duplicateIndex(points []Point, p Point) int {
+	index := -1
+	for i, q := range points {
+		if q == p {
+			if index >= 0 {
+				return i
+			}
+			index = i
+		}
+	}
+	return index
+}
+
+// sortDedup sorts pts in place by X, then Y, and removes the points equal
+// within Tolerance to the point before them, or returns a PointError for
+// ErrDuplicatePoint, with Index -1, if reject is set.
+func sortDedup(pts []Point, reject bool) ([]Point, error) {
+	// The sort is stable so that even points that compare equal, such as
+	// zeros of different sign, keep their input order.
+	sort.Stable(pointsByX(pts))
+	return dedupSorted(pts, Tolerance, reject)
+}
+
+// dedupSorted is like sortDedup but takes pts already sorted and removes
+// the points equal within tol to the point before them.
+func dedupSorted(pts []Point, tol float64, reject bool) ([]Point, error) {
+	// Sorting makes equal points adjacent. Inserting the same point twice
+	// would create zero-area triangles.
+	n := 0
+	for k, p := range pts {
+		if k > 0 && PointEqual(p, pts[k-1], tol) {
+			if reject {
+				return nil, &PointError{Index: -1, Point: p, Err: ErrDuplicatePoint}
+			}
+			continue
+		}
+		pts[n] = p
+		n++
+	}
+	return pts[:n], nil
+}
+
+// insertSweep inserts pts, which must be sorted by X, into the
+// triangulation consisting of super and returns all the resulting
+// triangles. If retire is true, triangles whose circumcircles lie wholly
+// to the left of the current point are set aside, since no later point
+// can invalidate them. It fails with a *LimitError if there are ever more
+// than limit triangles. If onStep or progress is not nil it is called after
+// each point.
+func insertSweep(ctx context.Context, pts []Point, super Triangle, inCircle InCircle, retire bool, limit int, onStep func(int, []Triangle, Point), progress func(done, total int), buf *Triangulator) ([]cachedTriangle, error) {
+	ts := append(buf.ts[:0], newCachedTriangle(super))
+	result := buf.result[:0]
+	edges := buf.edges[:0]
+	for k, p := range pts {
+		if k%ctxCheckInterval == 0 {
+			if err := ctx.Err(); err != nil {
+				return nil, err
+			}
+		}
 		edges = edges[:0]
 
 		for i := 0; i < len(ts); {
 			t := &ts[i]
-			if p.X > t.center.X+t.radius {
+			if retire && p.X-t.center.X > t.radius+float64(retireSlack*(t.radius+math.Abs(t.center.X))) {
 				result = append(result, *t)
 				n := len(ts) - 1
 				ts[i] = ts[n]
 				ts = ts[:n]
-			} else if t.CircumcircleContains(p) {
+			} else if !t.isDegenerate() && inCircle(&t.Triangle, p) {
 				edges = append(edges,
 					Edge{t.A, t.B},
 					Edge{t.A, t.C},
@@ -99,42 +1037,213 @@ func DelaunayTriangulation(points []Point, super Triangle) []Triangle {
 			}
 		}
 
-		for j := 0; j < len(edges); j++ {
-			for i := j + 1; i < len(edges); {
-				if !edges[j].isEqual(edges[i]) {
-					i++
-					continue
+		for _, e := range holeBoundary(edges) {
+			ts = append(ts, newTriangle(e, p))
+		}
+		if n := len(ts) + len(result); n > limit {
+			return nil, &LimitError{Inserted: k + 1, Total: len(pts), Triangles: n, Max: limit}
+		}
+		if onStep != nil {
+			current := make([]Triangle, 0, len(result)+len(ts))
+			for _, t := range result {
+				current = append(current, t.Triangle)
+			}
+			for _, t := range ts {
+				current = append(current, t.Triangle)
+			}
+			onStep(k+1, current, p)
+		}
+		if progress != nil {
+			progress(k+1, len(pts))
+		}
+	}
+	buf.ts, buf.edges = ts, edges
+	return append(result, ts...), nil
+}
+
+// insertGridded is like insertSweep but finds the triangles invalidated by
+// each point with a GridIndex of their circumcircles' bounding boxes, whose
+// cells shrink as points are added until they reach side cellSize, or
+// until there is about one point per cell if cellSize is zero.
+func insertGridded(ctx context.Context, pts []Point, super Triangle, cellSize float64, limit int, onStep func(int, []Triangle, Point), progress func(done, total int), buf *Triangulator) ([]cachedTriangle, error) {
+	bounds := PointsBounds(pts)
+	area := (bounds.Max.X - bounds.Min.X) * (bounds.Max.Y - bounds.Min.Y)
+
+	// Inserting in random order keeps the hull of the points inserted so
+	// far small, and with it the number of triangles joined to the super
+	// triangle, whose circumcircles are too large for the grid.
+	r := rand.New(rand.NewSource(1))
+	r.Shuffle(len(pts), func(i, j int) { pts[i], pts[j] = pts[j], pts[i] })
+
+	// Triangles keep their index in ts while they are in the grid. The
+	// slots of removed triangles are reused.
+	ts := append(buf.ts[:0], newCachedTriangle(super))
+	dead := []bool{false}
+	var free, candidates []int
+	var g *GridIndex
+	edges := buf.edges[:0]
+	for k, p := range pts {
+		if k%ctxCheckInterval == 0 {
+			if err := ctx.Err(); err != nil {
+				return nil, err
+			}
+		}
+
+		// The triangles are large while there are few points, so the
+		// grid starts coarse and is rebuilt with smaller cells each time
+		// the number of points doubles, until the cells reach cellSize.
+		if k&(k-1) == 0 {
+			size := math.Max(cellSize, math.Sqrt(area/float64(4*k+1)))
+			if g == nil || size < g.cellSize {
+				g = NewGridIndex(bounds, size)
+				for i := range ts {
+					if !dead[i] && !ts[i].isDegenerate() {
+						g.Insert(i, circleBounds(&ts[i], bounds))
+					}
+				}
+			}
+		}
+		edges = edges[:0]
+
+		candidates = g.Query(p, candidates[:0])
+		for _, i := range candidates {
+			t := &ts[i]
+			if inCirclePerturbed(t.A, t.B, t.C, p) {
+				edges = append(edges,
+					Edge{t.A, t.B},
+					Edge{t.A, t.C},
+					Edge{t.B, t.C},
+				)
+				g.Remove(i, circleBounds(t, bounds))
+				dead[i] = true
+				free = append(free, i)
+			}
+		}
+
+		for _, e := range holeBoundary(edges) {
+			t := newTriangle(e, p)
+			i := len(ts)
+			if n := len(free); n > 0 {
+				i, free = free[n-1], free[:n-1]
+				ts[i], dead[i] = t, false
+			} else {
+				ts, dead = append(ts, t), append(dead, false)
+			}
+			// Degenerate triangles are never bad, so they are left out of
+			// the grid.
+			if !t.isDegenerate() {
+				g.Insert(i, circleBounds(&ts[i], bounds))
+			}
+		}
+		if n := len(ts) - len(free); n > limit {
+			return nil, &LimitError{Inserted: k + 1, Total: len(pts), Triangles: n, Max: limit}
+		}
+		if onStep != nil {
+			current := make([]Triangle, 0, len(ts)-len(free))
+			for i := range ts {
+				if !dead[i] {
+					current = append(current, ts[i].Triangle)
 				}
-				n := len(edges) - 1
-				edges[i] = edges[n]
-				edges = edges[:n]
 			}
+			onStep(k+1, current, p)
 		}
+		if progress != nil {
+			progress(k+1, len(pts))
+		}
+	}
+	buf.edges = edges
 
-		for _, e := range edges {
-			t := Triangle{A: e.A, B: e.B, C: p}
-			t.CalcCircumCircle()
-			ts = append(ts, t)
+	result := buf.result[:0]
+	for i := range ts {
+		if !dead[i] {
+			result = append(result, ts[i])
 		}
 	}
+	buf.ts = ts
+	return result, nil
+}
 
-	result = append(result, ts...)
+// circleBounds returns a bounding box of the part of t's circumcircle that
+// lies in bounds, enlarged to allow for rounding error in the computed
+// center and radius. The circles of triangles on the hull can be huge, but
+// only a thin sliver of them overlaps the points.
+func circleBounds(t *cachedTriangle, bounds BoundingBox) BoundingBox {
+	c := t.center
+	r := t.radius + float64(retireSlack*(t.radius+math.Max(math.Abs(c.X), math.Abs(c.Y))))
+	x0, x1 := math.Max(bounds.Min.X, c.X-r), math.Min(bounds.Max.X, c.X+r)
+	y0, y1 := math.Max(bounds.Min.Y, c.Y-r), math.Min(bounds.Max.Y, c.Y+r)
 
-	//Remove any triangles using the Points of the super
-	for i := 0; i < len(result); {
-		t := result[i]
-		if !t.HasVertex(super.A) && !t.HasVertex(super.B) && !t.HasVertex(super.C) {
+	// halfChord returns the half width of the circle at distance d from
+	// its center, where d is the distance from c to the nearest point of
+	// the interval [lo, hi].
+	halfChord := func(v, lo, hi float64) float64 {
+		d := math.Max(0, math.Max(lo-v, v-hi))
+		return math.Sqrt(math.Max(0, (r-d)*(r+d)))
+	}
+	hx, hy := halfChord(c.Y, y0, y1), halfChord(c.X, x0, x1)
+	return BoundingBox{
+		Min: Point{math.Max(x0, c.X-hx), math.Max(y0, c.Y-hy)},
+		Max: Point{math.Min(x1, c.X+hx), math.Min(y1, c.Y+hy)},
+	}
+}
+
+// holeBoundary removes from edges, the edges of the triangles invalidated by
+// a new point, both copies of every edge shared by two of them, which lie
+// inside the hole. The remaining edges bound the hole.
+func holeBoundary(edges []Edge) []Edge {
+	for j := 0; j < len(edges); {
+		i := j + 1
+		for i < len(edges) && !edges[j].isEqual(edges[i]) {
 			i++
+		}
+		if i == len(edges) {
+			j++
 			continue
 		}
-		n := len(result) - 1
-		result[i] = result[n]
-		result = result[:n]
+		n := len(edges) - 1
+		edges[i] = edges[n]
+		edges = edges[:n]
+		n--
+		edges[j] = edges[n]
+		edges = edges[:n]
+	}
+	return edges
+}
+
+// newTriangle returns the counter-clockwise triangle joining e to p, with
+// its circumcircle calculated. It is degenerate only when p lies on an edge
+// of the super triangle; it must still be kept so that the mesh has no gap,
+// but it is never bad and is removed at the end.
+func newTriangle(e Edge, p Point) cachedTriangle {
+	t := Triangle{A: e.A, B: e.B, C: p}
+	if orient(t.A, t.B, t.C) < 0 {
+		t.A, t.B = t.B, t.A
 	}
+	return newCachedTriangle(t)
+}
 
-	return result
+// collinear reports whether pts, which must be sorted, has at least three
+// elements and they all lie within Tolerance of the line through its first
+// element and the element farthest from it.
+func collinear(pts []Point) bool {
+	if len(pts) < 3 {
+		return false
+	}
+	a, b, max := pts[0], pts[0], 0.0
+	for _, p := range pts[1:] {
+		if d := sqr(p.X-a.X) + sqr(p.Y-a.Y); d > max {
+			b, max = p, d
+		}
+	}
+	length := math.Sqrt(max)
+	for _, p := range pts {
+		if math.Abs(cross(a, b, p)) > Tolerance*length {
+			return false
+		}
+	}
+	return true
 }
 
 func sqr(x float64) float64 {
-	return x * x
+	return float64(x * x)
 }
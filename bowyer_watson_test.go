@@ -1,12 +1,24 @@
 package bowyer_watson
 
 import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"flag"
 	"fmt"
 	"math"
+	"math/big"
 	"math/rand"
+	"os"
+	"reflect"
+	"strconv"
+	"strings"
 	"testing"
 )
 
+var update = flag.Bool("update", false, "rewrite the test vectors in testdata")
+
 const pc int = 6
 
 func getRandomPointInCircle(radius float64) (float64, float64) {
@@ -35,7 +47,10 @@ func TestDelaunayTriangulation1(t *testing.T) {
 		C: Point{-50, -50},
 	}
 
-	u := DelaunayTriangulation(points, super)
+	u, err := DelaunayTriangulation(points, super)
+	if err != nil {
+		t.Fatal(err)
+	}
 
 	t.Log(u)
 
@@ -57,9 +72,1715 @@ func TestDelaunayTriangulation2(t *testing.T) {
 		C: Point{-50, -50},
 	}
 
-	u := DelaunayTriangulation(points, super)
+	u, err := DelaunayTriangulation(points, super)
+	if err != nil {
+		t.Fatal(err)
+	}
 
 	if got, want := len(u), 2; got != want {
 		t.Errorf("#triangles: got %v, want %v", got, want)
 	}
 }
+
+// tenth is a variable so that arithmetic on it is not done with exact
+// constant precision.
+var tenth = 0.1
+
+func TestPointEqual(t *testing.T) {
+	a, b := Point{tenth + 2*tenth, 1}, Point{0.3, 1}
+	if a == b {
+		t.Fatalf("%v == %v, test requires rounding error", a, b)
+	}
+	if PointEqual(a, b, 0) {
+		t.Errorf("PointEqual(%v, %v, 0): got true, want false", a, b)
+	}
+	if !PointEqual(a, b, 1e-12) {
+		t.Errorf("PointEqual(%v, %v, 1e-12): got false, want true", a, b)
+	}
+	if PointEqual(a, Point{0.3, 1.1}, 1e-12) {
+		t.Errorf("PointEqual(%v, %v, 1e-12): got true, want false", a, Point{0.3, 1.1})
+	}
+}
+
+func TestTolerance(t *testing.T) {
+	defer func(tol float64) { Tolerance = tol }(Tolerance)
+
+	tri := Triangle{A: Point{tenth + 2*tenth, 0}, B: Point{1, 0}, C: Point{0, 1}}
+	p := Point{0.3, 0}
+	e1, e2 := Edge{tri.A, tri.B}, Edge{Point{1, 0}, p}
+
+	Tolerance = 0
+	if tri.HasVertex(p) {
+		t.Errorf("HasVertex with zero tolerance: got true, want false")
+	}
+	if e1.isEqual(e2) {
+		t.Errorf("isEqual with zero tolerance: got true, want false")
+	}
+
+	Tolerance = 1e-9
+	if !tri.HasVertex(p) {
+		t.Errorf("HasVertex with tolerance: got false, want true")
+	}
+	if !e1.isEqual(e2) {
+		t.Errorf("isEqual with tolerance: got false, want true")
+	}
+}
+
+func TestValidatePoints(t *testing.T) {
+	super := Triangle{
+		A: Point{0, 50},
+		B: Point{50, -50},
+		C: Point{-50, -50},
+	}
+
+	points := []Point{{0, 0}, {10, 10}, {-30, -45}}
+	if err := ValidatePoints(points, super); err != nil {
+		t.Errorf("got %v, want nil", err)
+	}
+
+	points = append(points, Point{100, 0}, Point{0, -100})
+	var pe *PointError
+	if err := ValidatePoints(points, super); !errors.As(err, &pe) || pe.Err != ErrPointOutsideCircumcircle || pe.Index != 3 || pe.Point != points[3] {
+		t.Errorf("got error %v, want %v for point 3", err, ErrPointOutsideCircumcircle)
+	}
+}
+
+func TestDelaunayTriangulationCCW(t *testing.T) {
+	points := make([]Point, 200)
+	for i := range points {
+		x, y := getRandomPointInCircle(5)
+		points[i] = Point{x, y}
+	}
+
+	super := Triangle{
+		A: Point{0, 50},
+		B: Point{50, -50},
+		C: Point{-50, -50},
+	}
+
+	dt, err := DelaunayTriangulation(points, super)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, tri := range dt {
+		if area := tri.SignedArea(); area <= 0 {
+			t.Errorf("triangle %v: got area %v, want > 0", tri, area)
+		}
+	}
+}
+
+func TestDelaunayTriangulationWithGhosts(t *testing.T) {
+	points := make([]Point, 100)
+	for i := range points {
+		x, y := getRandomPointInCircle(5)
+		points[i] = Point{x, y}
+	}
+	// Clockwise, to check that the ghosts are counter-clockwise anyway.
+	super := Triangle{
+		A: Point{0, 50},
+		B: Point{-50, -50},
+		C: Point{50, -50},
+	}
+
+	interior, ghosts, err := DelaunayTriangulationWithGhosts(points, super)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want, err := DelaunayTriangulation(points, super)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(interior) != len(want) {
+		t.Errorf("#interior: got %v, want %v", len(interior), len(want))
+	}
+
+	// With the vertices of super there are n+3 points, all inside or on
+	// the hull, which is super itself.
+	if got, want := len(interior)+len(ghosts), 2*(len(points)+3)-2-3; got != want {
+		t.Errorf("#triangles: got %v, want %v", got, want)
+	}
+	area := 0.0
+	edges := map[Edge]bool{}
+	for _, tri := range append(append([]Triangle(nil), interior...), ghosts...) {
+		area += tri.SignedArea()
+		for _, e := range [3]Edge{{tri.A, tri.B}, {tri.B, tri.C}, {tri.C, tri.A}} {
+			edges[e] = true
+		}
+	}
+	if got, want := area, math.Abs(super.SignedArea()); math.Abs(got-want) > 1e-9*want {
+		t.Errorf("area: got %v, want %v", got, want)
+	}
+	for _, tri := range ghosts {
+		if !tri.HasVertex(super.A) && !tri.HasVertex(super.B) && !tri.HasVertex(super.C) {
+			t.Errorf("ghost %v has no super vertex", tri)
+		}
+	}
+	// Every interior edge is matched by a reversed edge of some triangle,
+	// unless it is on the boundary of super.
+	for e := range edges {
+		if !edges[Edge{e.B, e.A}] {
+			for _, v := range [2]Point{e.A, e.B} {
+				if !super.HasVertex(v) {
+					t.Errorf("edge %v is unmatched", e)
+				}
+			}
+		}
+	}
+}
+
+func TestDelaunayTriangulationCtx(t *testing.T) {
+	points := make([]Point, 3*ctxCheckInterval)
+	for i := range points {
+		x, y := getRandomPointInCircle(5)
+		points[i] = Point{x, y}
+	}
+	super := Triangle{
+		A: Point{0, 50},
+		B: Point{50, -50},
+		C: Point{-50, -50},
+	}
+
+	got, err := DelaunayTriangulationCtx(context.Background(), points, super)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want, err := DelaunayTriangulation(points, super)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != len(want) {
+		t.Errorf("#triangles: got %v, want %v", len(got), len(want))
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	got, err = DelaunayTriangulationCtx(ctx, points, super)
+	if err != context.Canceled {
+		t.Errorf("got error %v, want %v", err, context.Canceled)
+	}
+	if got != nil {
+		t.Errorf("got %d triangles, want none", len(got))
+	}
+}
+
+// cancelAfter is a context that is canceled once Err has been called n
+// times, so that cancellation happens at a known point in a computation.
+type cancelAfter struct {
+	context.Context
+	n int
+}
+
+func (c *cancelAfter) Err() error {
+	if c.n--; c.n < 0 {
+		return context.Canceled
+	}
+	return nil
+}
+
+func TestDelaunayTriangulationCtxMidRun(t *testing.T) {
+	points := make([]Point, 3*ctxCheckInterval)
+	for i := range points {
+		x, y := getRandomPointInCircle(5)
+		points[i] = Point{x, y}
+	}
+	super := SuperTriangleFor(points, 10)
+	for _, opts := range [][]Option{nil, {WithGridIndex(0)}} {
+		for n := 1; n <= 2; n++ {
+			ctx := &cancelAfter{Context: context.Background(), n: n}
+			got, err := DelaunayTriangulationCtx(ctx, points, super, opts...)
+			if err != context.Canceled {
+				t.Errorf("%d checks, %d options: got error %v, want %v", n, len(opts), err, context.Canceled)
+			}
+			if got != nil {
+				t.Errorf("%d checks, %d options: got %d triangles, want none", n, len(opts), len(got))
+			}
+			if ctx.n >= 0 {
+				t.Errorf("%d checks, %d options: context was not checked again after %d checks", n, len(opts), n)
+			}
+		}
+	}
+}
+
+func TestWithProgress(t *testing.T) {
+	points := []Point{{0, 0}, {1, 0}, {0, 1}, {1, 1}, {0, 0}, {0.5, 0.3}}
+	super := SuperTriangleFor(points, 10)
+	for _, opts := range [][]Option{nil, {WithGridIndex(0)}} {
+		var calls [][2]int
+		opts = append(opts, WithProgress(func(done, total int) {
+			calls = append(calls, [2]int{done, total})
+		}))
+		if _, err := DelaunayTriangulation(points, super, opts...); err != nil {
+			t.Fatal(err)
+		}
+		// The duplicate is not counted.
+		want := [][2]int{{1, 5}, {2, 5}, {3, 5}, {4, 5}, {5, 5}}
+		if !reflect.DeepEqual(calls, want) {
+			t.Errorf("%d options: got calls %v, want %v", len(opts), calls, want)
+		}
+	}
+
+	ch := make(chan int, len(points))
+	if _, err := DelaunayTriangulation(points, super, WithProgressChan(ch)); err != nil {
+		t.Fatal(err)
+	}
+	close(ch)
+	var got []int
+	for done := range ch {
+		got = append(got, done)
+	}
+	if want := []int{1, 2, 3, 4, 5}; !reflect.DeepEqual(got, want) {
+		t.Errorf("channel: got %v, want %v", got, want)
+	}
+
+	// An unbuffered channel with no receiver must not block.
+	if _, err := DelaunayTriangulation(points, super, WithProgressChan(make(chan int))); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestWithStepCallback(t *testing.T) {
+	points := make([]Point, 50)
+	for i := range points {
+		x, y := getRandomPointInCircle(5)
+		points[i] = Point{x, y}
+	}
+	super := SuperTriangleFor(points, 10)
+	input := map[Point]bool{}
+	for _, p := range points {
+		input[p] = true
+	}
+
+	for _, opts := range [][]Option{nil, {WithGridIndex(0)}, {WithNormalization()}} {
+		var last []Triangle
+		inserted := map[Point]bool{}
+		steps := 0
+		opts = append(opts, WithStepCallback(func(step int, current []Triangle, p Point) {
+			steps++
+			if step != steps {
+				t.Errorf("%d options: got step %d, want %d", len(opts), step, steps)
+			}
+			if want := 2*step + 1; len(current) != want {
+				t.Errorf("%d options: step %d: got %d triangles, want %d", len(opts), step, len(current), want)
+			}
+			if !input[p] || inserted[p] {
+				t.Errorf("%d options: step %d: unexpected point %v", len(opts), step, p)
+			}
+			inserted[p] = true
+			last = append(last[:0], current...)
+			// The callback owns current.
+			for i := range current {
+				current[i] = Triangle{}
+			}
+		}))
+		got, err := DelaunayTriangulation(points, super, opts...)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if steps != len(points) {
+			t.Errorf("%d options: got %d steps, want %d", len(opts), steps, len(points))
+		}
+
+		// The last step shows the final triangulation, with its ghosts.
+		want := map[[3]Point]bool{}
+		for _, tri := range got {
+			want[sortedVertices(tri)] = true
+		}
+		n := 0
+		for _, tri := range last {
+			if tri.HasVertex(super.A) || tri.HasVertex(super.B) || tri.HasVertex(super.C) {
+				continue
+			}
+			n++
+			if !want[sortedVertices(tri)] {
+				t.Errorf("%d options: unexpected triangle %v in last step", len(opts), tri)
+			}
+		}
+		if n != len(got) {
+			t.Errorf("%d options: last step has %d interior triangles, want %d", len(opts), n, len(got))
+		}
+	}
+}
+
+func TestTriangulate(t *testing.T) {
+	points := make([]Point, 200)
+	for i := range points {
+		x, y := getRandomPointInCircle(5)
+		points[i] = Point{x + 1000, y - 1000}
+	}
+	super := SuperTriangleFor(points, AutoSuperMargin)
+	want, err := DelaunayTriangulation(points, super, WithSortedOutput())
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := Triangulate(points, WithSortedOutput())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("auto super: got %d triangles, want %d", len(got), len(want))
+	}
+	auto := got
+
+	small := SuperTriangleFor(points, 1)
+	want, err = DelaunayTriangulation(points, small, WithSortedOutput())
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err = Triangulate(points, WithSuperTriangle(small), WithSortedOutput())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("WithSuperTriangle: got %d triangles, want %d", len(got), len(want))
+	}
+	got, err = Triangulate(points, WithSuperTriangle(small), WithAutoSuper(), WithSortedOutput())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(got, auto) {
+		t.Errorf("WithAutoSuper: got %d triangles, want %d", len(got), len(auto))
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := Triangulate(points, WithContext(ctx)); err != context.Canceled {
+		t.Errorf("WithContext: got error %v, want %v", err, context.Canceled)
+	}
+	if _, err := DelaunayTriangulation(points, super, WithContext(ctx)); err != context.Canceled {
+		t.Errorf("DelaunayTriangulation WithContext: got error %v, want %v", err, context.Canceled)
+	}
+
+	if _, err := Triangulate(nil); err != ErrEmptyInput {
+		t.Errorf("no points: got error %v, want %v", err, ErrEmptyInput)
+	}
+	if _, err := Triangulate([]Point{{0, 0}, {math.NaN(), 1}, {1, 0}}); !errors.Is(err, ErrInvalidPoint) {
+		t.Errorf("NaN: got error %v, want %v", err, ErrInvalidPoint)
+	}
+}
+
+func TestSortTriangles(t *testing.T) {
+	got := []Triangle{
+		{A: Point{2, 0}, B: Point{1, 1}, C: Point{0, 0}},
+		{A: Point{1, 1}, B: Point{0, 0}, C: Point{2, 0}},
+		{A: Point{0, 0}, B: Point{2, 0}, C: Point{1, 1}},
+		{A: Point{0, 1}, B: Point{0, 0}, C: Point{1, 1}},
+	}
+	SortTriangles(got)
+	want := []Triangle{
+		{A: Point{0, 0}, B: Point{1, 1}, C: Point{0, 1}},
+		{A: Point{0, 0}, B: Point{2, 0}, C: Point{1, 1}},
+		{A: Point{0, 0}, B: Point{2, 0}, C: Point{1, 1}},
+		{A: Point{0, 0}, B: Point{2, 0}, C: Point{1, 1}},
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("triangle %d: got %v, want %v", i, got[i], want[i])
+		}
+	}
+}
+
+func TestDelaunayTriangulationSorted(t *testing.T) {
+	points := make([]Point, 200)
+	for i := range points {
+		x, y := getRandomPointInCircle(5)
+		points[i] = Point{x, y}
+	}
+	super := Triangle{
+		A: Point{0, 50},
+		B: Point{50, -50},
+		C: Point{-50, -50},
+	}
+
+	u, err := DelaunayTriangulation(points, super, WithSortedOutput())
+	if err != nil {
+		t.Fatal(err)
+	}
+	var want bytes.Buffer
+	if err := WriteTriangles(&want, u); err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 3; i++ {
+		rand.Shuffle(len(points), func(i, j int) { points[i], points[j] = points[j], points[i] })
+		v, err := DelaunayTriangulation(points, super, WithSortedOutput())
+		if err != nil {
+			t.Fatal(err)
+		}
+		var got bytes.Buffer
+		if err := WriteTriangles(&got, v); err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(got.Bytes(), want.Bytes()) {
+			t.Errorf("run %d: output differs", i)
+		}
+	}
+
+	// The other algorithms find the same triangles in a different order.
+	sh, dc := SweepHullTriangulation(points), DivideAndConquer(points)
+	SortTriangles(sh)
+	SortTriangles(dc)
+	if len(sh) != len(dc) {
+		t.Fatalf("#triangles: got %v and %v", len(sh), len(dc))
+	}
+	for i := range sh {
+		if sh[i].A != dc[i].A || sh[i].B != dc[i].B || sh[i].C != dc[i].C {
+			t.Errorf("triangle %d: got %v and %v", i, sh[i], dc[i])
+		}
+	}
+}
+
+func TestNormalizeOrientation(t *testing.T) {
+	ccw := Triangle{A: Point{0, 0}, B: Point{2, 0}, C: Point{0, 1}}
+	cw := Triangle{A: Point{0, 0}, B: Point{0, 1}, C: Point{2, 0}}
+	flat := Triangle{A: Point{0, 0}, B: Point{1, 1}, C: Point{2, 2}}
+	if got, want := ccw.SignedArea(), 1.0; got != want {
+		t.Errorf("ccw: got area %v, want %v", got, want)
+	}
+	if got, want := cw.SignedArea(), -1.0; got != want {
+		t.Errorf("cw: got area %v, want %v", got, want)
+	}
+	if got := flat.SignedArea(); got != 0 {
+		t.Errorf("flat: got area %v, want 0", got)
+	}
+
+	got := NormalizeOrientation([]Triangle{ccw, cw, flat})
+	want := []Triangle{ccw, ccw, flat}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("triangle %d: got %v, want %v", i, got[i], want[i])
+		}
+	}
+}
+
+func TestDelaunayTriangulationDuplicates(t *testing.T) {
+	super := Triangle{
+		A: Point{0, 50},
+		B: Point{50, -50},
+		C: Point{-50, -50},
+	}
+	points := []Point{{-2, -2}, {2, -2}, {0, 3}, {0.5, 0}, {-1, 1}}
+	want := map[[3]Point]bool{}
+	dt, err := DelaunayTriangulation(points, super)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, tri := range dt {
+		want[sortedVertices(tri)] = true
+	}
+
+	dup := points[3]
+	for _, n := range []int{2, 10} {
+		input := append([]Point(nil), points...)
+		for i := 1; i < n; i++ {
+			input = append(input, dup)
+		}
+		rand.Shuffle(len(input), func(i, j int) { input[i], input[j] = input[j], input[i] })
+
+		got, err := DelaunayTriangulation(input, super)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(got) != len(want) {
+			t.Errorf("%d copies: #triangles: got %v, want %v", n, len(got), len(want))
+		}
+		for _, tri := range got {
+			if !want[sortedVertices(tri)] {
+				t.Errorf("%d copies: unexpected triangle %v", n, tri)
+			}
+		}
+
+		_, err = DelaunayTriangulation(input, super, WithDuplicates(RejectDuplicates))
+		var pe *PointError
+		if !errors.As(err, &pe) || pe.Err != ErrDuplicatePoint {
+			t.Errorf("%d copies: got error %v, want %v", n, err, ErrDuplicatePoint)
+		} else if input[pe.Index] != dup || pe.Point != dup {
+			t.Errorf("%d copies: got point %d %v, want %v", n, pe.Index, pe.Point, dup)
+		} else if first := duplicateIndex(input[:pe.Index], dup); first < 0 || first >= pe.Index {
+			t.Errorf("%d copies: point %d has no earlier duplicate", n, pe.Index)
+		}
+	}
+
+	// Points one ulp apart are distinct unless Tolerance says otherwise.
+	ulp := Point{math.Nextafter(dup.X, 1), dup.Y}
+	input := append(append([]Point(nil), points...), ulp)
+	if _, err := DelaunayTriangulation(input, super, WithDuplicates(RejectDuplicates)); err != nil {
+		t.Errorf("ulp: got error %v, want nil", err)
+	}
+	dt, err = DelaunayTriangulation(input, super)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, c := range Circumcircles(dt) {
+		if math.IsNaN(c.Center.X) || math.IsNaN(c.Center.Y) {
+			t.Errorf("ulp: got NaN circumcenter %v", c.Center)
+		}
+	}
+
+	defer func(tol float64) { Tolerance = tol }(Tolerance)
+	Tolerance = 1e-12
+	got, err := DelaunayTriangulation(input, super)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != len(want) {
+		t.Errorf("ulp with Tolerance: #triangles: got %v, want %v", len(got), len(want))
+	}
+	_, err = DelaunayTriangulation(input, super, WithDuplicates(RejectDuplicates))
+	var pe *PointError
+	if !errors.As(err, &pe) || pe.Err != ErrDuplicatePoint {
+		t.Errorf("ulp with Tolerance: got error %v, want %v", err, ErrDuplicatePoint)
+	} else if input[pe.Index] != pe.Point {
+		t.Errorf("ulp with Tolerance: got point %d %v, want %v", pe.Index, pe.Point, input[pe.Index])
+	}
+}
+
+func TestDelaunayTriangulationCollinear(t *testing.T) {
+	super := Triangle{
+		A: Point{0, 50},
+		B: Point{50, -50},
+		C: Point{-50, -50},
+	}
+	line := func(f func(i int) Point) []Point {
+		points := make([]Point, 10)
+		for i := range points {
+			points[i] = f(i)
+		}
+		rand.Shuffle(len(points), func(i, j int) { points[i], points[j] = points[j], points[i] })
+		return points
+	}
+
+	tests := []struct {
+		name   string
+		points []Point
+	}{
+		{"horizontal", line(func(i int) Point { return Point{float64(i) - 5, 1} })},
+		{"vertical", line(func(i int) Point { return Point{-2, float64(i) - 5} })},
+		{"diagonal", line(func(i int) Point { return Point{float64(i) - 5, 2*float64(i) - 12} })},
+		{"three", []Point{{0, 0}, {1, 1}, {2, 2}}},
+		{"duplicates", []Point{{0, 0}, {1, 1}, {1, 1}, {2, 2}, {0, 0}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := DelaunayTriangulation(tt.points, super)
+			if err != ErrCollinearInput {
+				t.Errorf("got error %v, want %v", err, ErrCollinearInput)
+			}
+			if len(got) != 0 {
+				t.Errorf("got %v, want no triangles", got)
+			}
+		})
+	}
+
+	// Rounding error puts these points slightly off the line.
+	var points []Point
+	for i := 0; i < 10; i++ {
+		points = append(points, Point{float64(i) * tenth, float64(i) * 3 * tenth})
+	}
+	defer func(tol float64) { Tolerance = tol }(Tolerance)
+	Tolerance = 1e-9
+	if _, err := DelaunayTriangulation(points, super); err != ErrCollinearInput {
+		t.Errorf("inexact: got error %v, want %v", err, ErrCollinearInput)
+	}
+}
+
+func TestDelaunayTriangulationMostlyCollinear(t *testing.T) {
+	super := Triangle{
+		A: Point{0, 50},
+		B: Point{50, -50},
+		C: Point{-50, -50},
+	}
+	var points []Point
+	for i := 0; i < 10; i++ {
+		points = append(points, Point{float64(i) - 5, 0})
+	}
+	points = append(points, Point{0.5, 3})
+
+	u, err := DelaunayTriangulation(points, super)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := len(u), 9; got != want {
+		t.Errorf("#triangles: got %v, want %v", got, want)
+	}
+	for _, tri := range u {
+		if !tri.HasVertex(Point{0.5, 3}) {
+			t.Errorf("triangle %v does not use the off-line point", tri)
+		}
+		if cross(tri.A, tri.B, tri.C) <= 0 {
+			t.Errorf("triangle %v is degenerate or clockwise", tri)
+		}
+	}
+}
+
+func TestIsCollinear(t *testing.T) {
+	tests := []struct {
+		a, b, c Point
+		want    bool
+	}{
+		{Point{0, 0}, Point{1, 1}, Point{2, 2}, true},
+		{Point{0, 0}, Point{2, 2}, Point{1, 1}, true},
+		{Point{1, 5}, Point{1, -2}, Point{1, 0}, true},
+		{Point{0, 0}, Point{0, 0}, Point{3, 4}, true},
+		{Point{0, 0}, Point{1, 0}, Point{0, 1}, false},
+		{Point{0, 0}, Point{1, 0}, Point{2, 1e-300}, false},
+	}
+	for _, tt := range tests {
+		if got := IsCollinear(tt.a, tt.b, tt.c); got != tt.want {
+			t.Errorf("IsCollinear(%v, %v, %v): got %v, want %v", tt.a, tt.b, tt.c, got, tt.want)
+		}
+	}
+}
+
+func TestCalcCircumCircleDegenerate(t *testing.T) {
+	for _, tri := range []Triangle{
+		{A: Point{0, 0}, B: Point{1, 1}, C: Point{2, 2}},
+		{A: Point{1, 1}, B: Point{1, 1}, C: Point{1, 1}},
+		{A: Point{0, 0}, B: Point{1, 0}, C: Point{2, 1e-300}},
+		// Nearly collinear, and the circumcircle overflows.
+		{A: Point{0, 0}, B: Point{1e300, 0}, C: Point{0, 1e-300}},
+	} {
+		c, err := tri.CalcCircumCircle()
+		if err != ErrDegenerateTriangle {
+			t.Errorf("%v: got error %v, want %v", tri.A, err, ErrDegenerateTriangle)
+		}
+		if !tri.IsDegenerate() {
+			t.Errorf("%v: not degenerate", tri.A)
+		}
+		if math.IsNaN(c.Center.X) || math.IsNaN(c.Center.Y) || math.IsInf(c.Center.X, 0) || math.IsInf(c.Center.Y, 0) {
+			t.Errorf("%v: got center %v, want finite", tri.A, c.Center)
+		}
+		if !math.IsInf(c.Radius, 1) {
+			t.Errorf("%v: got radius %v, want +Inf", tri.A, c.Radius)
+		}
+		if tri.CircumcircleContains(Point{1, 1}) {
+			t.Errorf("%v: contains %v", tri.A, Point{1, 1})
+		}
+	}
+
+	for _, tri := range []Triangle{
+		{A: Point{0, 0}, B: Point{1, 0}, C: Point{0, 1}},
+		// Nearly collinear, but the circumcircle is representable.
+		{A: Point{0, 0}, B: Point{1, 0}, C: Point{2, 1e-12}},
+		{A: Point{0, 0}, B: Point{1, 0}, C: Point{0.5, 1e-100}},
+		// Nearly collinear far from the origin. Relative to A the area
+		// is exact.
+		{A: Point{1e9, 1e9}, B: Point{1e9 + 1, 1e9 + 1}, C: Point{1e9 + 2, 1e9 + 2 + 1e-6}},
+	} {
+		if _, err := tri.CalcCircumCircle(); err != nil {
+			t.Errorf("%v: got error %v", tri, err)
+		}
+		if tri.IsDegenerate() {
+			t.Errorf("%v: got degenerate", tri)
+		}
+	}
+}
+
+// bigCircumcircle returns the circumcenter and circumradius of t computed
+// with big.Float at a precision high enough that the result is correctly
+// rounded to float64.
+func bigCircumcircle(t Triangle) (Point, float64) {
+	const prec = 2048
+	f := func(x float64) *big.Float { return new(big.Float).SetPrec(prec).SetFloat64(x) }
+	sub := func(a, b *big.Float) *big.Float { return new(big.Float).SetPrec(prec).Sub(a, b) }
+	mul := func(a, b *big.Float) *big.Float { return new(big.Float).SetPrec(prec).Mul(a, b) }
+	add := func(a, b *big.Float) *big.Float { return new(big.Float).SetPrec(prec).Add(a, b) }
+	quo := func(a, b *big.Float) *big.Float { return new(big.Float).SetPrec(prec).Quo(a, b) }
+
+	ax, ay := f(t.A.X), f(t.A.Y)
+	bx, by := sub(f(t.B.X), ax), sub(f(t.B.Y), ay)
+	cx, cy := sub(f(t.C.X), ax), sub(f(t.C.Y), ay)
+	d := mul(f(2), sub(mul(bx, cy), mul(by, cx)))
+	b2 := add(mul(bx, bx), mul(by, by))
+	c2 := add(mul(cx, cx), mul(cy, cy))
+	ux := quo(sub(mul(cy, b2), mul(by, c2)), d)
+	uy := quo(sub(mul(bx, c2), mul(cx, b2)), d)
+
+	x, _ := add(ax, ux).Float64()
+	y, _ := add(ay, uy).Float64()
+	r, _ := new(big.Float).SetPrec(prec).Sqrt(add(mul(ux, ux), mul(uy, uy))).Float64()
+	return Point{x, y}, r
+}
+
+func TestCalcCircumCircleFarFromOrigin(t *testing.T) {
+	shapes := [][3]Point{
+		{{0.25, -0.5}, {3.5, 1.25}, {-1, 2.75}},
+		{{0, 0}, {1, 0}, {0, 1}},
+		{{0, 0}, {10, 0.5}, {20, 0}},
+		{{-3, -3}, {2, -1}, {0.5, 4}},
+	}
+	for _, o := range []float64{0, 1e8, 1e100, 1e150} {
+		// Far from the origin the shapes are scaled up so that their
+		// vertices remain distinct.
+		scale := math.Max(1, o*1e-6)
+		for _, shape := range shapes {
+			var tri Triangle
+			for i, v := range []*Point{&tri.A, &tri.B, &tri.C} {
+				*v = Point{o + scale*shape[i].X, o + scale*shape[i].Y}
+			}
+			c, err := tri.CalcCircumCircle()
+			if err != nil {
+				t.Errorf("%v: %v", tri, err)
+				continue
+			}
+			center, radius := bigCircumcircle(tri)
+			// Adding the offset rounds the center to the precision of
+			// its coordinates.
+			tol := 1e-12*radius + 1e-15*math.Max(math.Abs(center.X), math.Abs(center.Y))
+			if d := math.Hypot(c.Center.X-center.X, c.Center.Y-center.Y); d > tol {
+				t.Errorf("%v: got center %v, want %v", tri, c.Center, center)
+			}
+			if math.Abs(c.Radius-radius) > 1e-12*radius {
+				t.Errorf("%v: got radius %v, want %v", tri, c.Radius, radius)
+			}
+		}
+	}
+}
+
+func TestCalcCircumCircleThin(t *testing.T) {
+	// Ever thinner triangles ever farther from the origin either have a
+	// finite circumcircle or are degenerate. No Inf or NaN leaks into a
+	// circle that is not reported as degenerate.
+	for _, o := range []float64{0, 1e3, 1e6, 1e9, 1e12} {
+		for _, d := range []float64{1e-2, 1e-4, 1e-6, 1e-8, 1e-10, 1e-12} {
+			tri := Triangle{A: Point{o, o}, B: Point{o + 1, o + 1}, C: Point{o + 2, o + 2 + d}}
+			c, err := tri.CalcCircumCircle()
+			if math.IsNaN(c.Center.X) || math.IsNaN(c.Center.Y) || math.IsInf(c.Center.X, 0) || math.IsInf(c.Center.Y, 0) {
+				t.Errorf("%v: got center %v, want finite", tri, c.Center)
+			}
+			switch {
+			case err != nil && !math.IsInf(c.Radius, 1):
+				t.Errorf("%v: degenerate with radius %v, want +Inf", tri, c.Radius)
+			case err == nil && (math.IsNaN(c.Radius) || math.IsInf(c.Radius, 0)):
+				t.Errorf("%v: got radius %v, want finite", tri, c.Radius)
+			}
+			if err == nil && tri.CircumcircleContains(Point{o - 1, o + 10}) != (math.Hypot(o-1-c.Center.X, o+10-c.Center.Y) <= c.Radius) {
+				t.Errorf("%v: CircumcircleContains disagrees with the circle", tri)
+			}
+		}
+	}
+}
+
+func TestDelaunayTriangulationDegenerateSuper(t *testing.T) {
+	super := Triangle{
+		A: Point{-50, -50},
+		B: Point{0, 0},
+		C: Point{50, 50},
+	}
+	points := []Point{{0, 1}, {1, 0}, {-1, 0}}
+	if _, err := DelaunayTriangulation(points, super); err != ErrDegenerateSuper {
+		t.Errorf("got error %v, want %v", err, ErrDegenerateSuper)
+	}
+}
+
+func TestDelaunayTriangulationInvalidInput(t *testing.T) {
+	super := Triangle{
+		A: Point{0, 50},
+		B: Point{50, -50},
+		C: Point{-50, -50},
+	}
+	if _, err := DelaunayTriangulation(nil, super); err != ErrEmptyInput {
+		t.Errorf("empty: got error %v, want %v", err, ErrEmptyInput)
+	}
+	points := []Point{{0, 1}, {1, 0}, {100, 100}}
+	_, err := DelaunayTriangulation(points, super)
+	if !errors.Is(err, ErrPointOutsideSuper) {
+		t.Errorf("outside: got error %v, want %v", err, ErrPointOutsideSuper)
+	}
+	var pe *PointError
+	if !errors.As(err, &pe) || pe.Index != 2 || pe.Point != points[2] {
+		t.Errorf("outside: got error %#v, want point 2 %v", err, points[2])
+	}
+}
+
+func TestDelaunayTriangulationOnSuperEdge(t *testing.T) {
+	super := Triangle{
+		A: Point{0, 50},
+		B: Point{50, -50},
+		C: Point{-50, -50},
+	}
+	// The midpoint of super.A and super.B lies exactly on their edge.
+	points := []Point{{0, 1}, {1, 0}, {25, 0}, {-1, -1}}
+	for _, opts := range [][]Option{nil, {WithHullCompletion()}} {
+		_, err := DelaunayTriangulation(points, super, opts...)
+		var pe *PointError
+		if !errors.As(err, &pe) || pe.Err != ErrSuperTooSmall || pe.Index != 2 {
+			t.Errorf("%d options: got error %v, want %v for point 2", len(opts), err, ErrSuperTooSmall)
+		}
+	}
+	if got := DelaunayTriangulationParallel(points, super, 2); got != nil {
+		t.Errorf("parallel: got %v, want nil", got)
+	}
+}
+
+func TestDelaunayTriangulationFewPoints(t *testing.T) {
+	super := SuperTriangleFor([]Point{{0, 0}, {1, 1}}, 10)
+	tests := []struct {
+		name    string
+		points  []Point
+		want    []Triangle
+		wantErr error
+	}{
+		{"zero", nil, nil, ErrEmptyInput},
+		{"one", []Point{{0, 0}}, nil, ErrTooFewPoints},
+		{"two", []Point{{0, 0}, {1, 1}}, nil, ErrTooFewPoints},
+		{"three equal", []Point{{1, 0}, {1, 0}, {1, 0}}, nil, ErrTooFewPoints},
+		{"two distinct", []Point{{1, 0}, {0, 1}, {1, 0}}, nil, ErrTooFewPoints},
+		{"three collinear", []Point{{0, 0}, {0.5, 0.5}, {1, 1}}, nil, ErrCollinearInput},
+		{"three", []Point{{1, 1}, {0, 0}, {1, 0}}, []Triangle{{A: Point{0, 0}, B: Point{1, 0}, C: Point{1, 1}}}, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := DelaunayTriangulation(tt.points, super, WithSortedOutput())
+			if err != tt.wantErr {
+				t.Errorf("got error %v, want %v", err, tt.wantErr)
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("got %v, want %v", got, tt.want)
+			}
+			for i := range got {
+				if got[i].A != tt.want[i].A || got[i].B != tt.want[i].B || got[i].C != tt.want[i].C {
+					t.Errorf("got %v, want %v", got[i], tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestDelaunayTriangulationIdentical(t *testing.T) {
+	same := make([]Point, 1000)
+	for i := range same {
+		same[i] = Point{3, 4}
+	}
+	pair := make([]Point, 1000)
+	for i := range pair {
+		pair[i] = Point{float64(i % 2), 7}
+	}
+	for name, points := range map[string][]Point{"same": same, "pair": pair} {
+		super := SuperTriangleFor(points, 10)
+		for _, opts := range [][]Option{nil, {WithGridIndex(0)}, {WithNormalization()}, {WithHullCompletion()}} {
+			got, err := DelaunayTriangulation(points, super, opts...)
+			if err != ErrTooFewPoints || got != nil {
+				t.Errorf("%s, %d options: got %v, %v, want nil, %v", name, len(opts), got, err, ErrTooFewPoints)
+			}
+		}
+		if got, err := Triangulate(points); err != ErrTooFewPoints || got != nil {
+			t.Errorf("%s: Triangulate: got %v, %v, want nil, %v", name, got, err, ErrTooFewPoints)
+		}
+	}
+}
+
+func TestDelaunayTriangulationNonFinite(t *testing.T) {
+	super := SuperTriangleFor([]Point{{0, 0}, {1, 1}}, 10)
+	for _, bad := range []Point{
+		{math.NaN(), 0},
+		{0, math.NaN()},
+		{math.Inf(1), 0},
+		{0, math.Inf(-1)},
+	} {
+		points := []Point{{0, 0}, {1, 0}, {0, 1}, bad, {1, 1}}
+		got, ghosts, err := DelaunayTriangulationWithGhosts(points, super)
+		if !errors.Is(err, ErrInvalidPoint) {
+			t.Errorf("%v: got error %v, want %v", bad, err, ErrInvalidPoint)
+		}
+		var pe *PointError
+		if !errors.As(err, &pe) || pe.Index != 3 {
+			t.Errorf("%v: got error %#v, want point 3", bad, err)
+		}
+		if got != nil || ghosts != nil {
+			t.Errorf("%v: got %v and %v, want no triangles", bad, got, ghosts)
+		}
+	}
+}
+
+func TestDelaunayTriangulationSuperTooSmall(t *testing.T) {
+	// A unit square with its center. The Delaunay triangulation is the
+	// four triangles meeting at the center.
+	points := []Point{{0, 0}, {1, 0}, {1, 1}, {0, 1}, {0.5, 0.5}}
+
+	// This super contains the points, but its vertex A is inside the
+	// circumcircle of the triangle on the right side of the square, so
+	// that triangle would be lost.
+	tight := Triangle{A: Point{1.45, 0.5}, B: Point{-2.55, 8.5}, C: Point{-2.55, -7.5}}
+	_, err := DelaunayTriangulation(points, tight)
+	if !errors.Is(err, ErrSuperTooSmall) {
+		t.Fatalf("tight: got error %v, want %v", err, ErrSuperTooSmall)
+	}
+	var pe *PointError
+	if !errors.As(err, &pe) || points[pe.Index] != pe.Point {
+		t.Errorf("tight: got error %#v, want a PointError naming an input point", err)
+	}
+
+	for _, margin := range []float64{-1, 0, MinSuperMargin, 10, 1e6} {
+		super := SuperTriangleFor(points, margin)
+		got, err := DelaunayTriangulation(points, super)
+		if err != nil {
+			t.Errorf("margin %v: %v", margin, err)
+			continue
+		}
+		if len(got) != 4 {
+			t.Errorf("margin %v: got %v, want 4 triangles", margin, got)
+		}
+	}
+}
+
+func TestSuperTriangleFor(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 100; i++ {
+		points := make([]Point, 1+r.Intn(10))
+		scale := math.Pow(10, float64(r.Intn(20)-10))
+		for j := range points {
+			points[j] = Point{(r.Float64() - 0.5) * scale, (r.Float64()*2 + 3) * scale}
+		}
+		margin := r.Float64() * 3
+		super := SuperTriangleFor(points, margin)
+		if orient(super.A, super.B, super.C) <= 0 {
+			t.Fatalf("%v: super %v is not counter-clockwise", points, super)
+		}
+		if err := checkInsideSuper(points, super); err != nil {
+			t.Fatalf("%v, margin %v: %v", points, margin, err)
+		}
+		if err := checkSuperMargin(points, super); err != nil {
+			t.Fatalf("%v, margin %v: %v", points, margin, err)
+		}
+	}
+
+	for _, points := range [][]Point{nil, {{3, 4}}, {{3, 4}, {3, 4}}} {
+		super := SuperTriangleFor(points, 1)
+		if orient(super.A, super.B, super.C) <= 0 {
+			t.Errorf("%v: super %v is not counter-clockwise", points, super)
+		}
+		if err := checkInsideSuper(points, super); err != nil {
+			t.Errorf("%v: %v", points, err)
+		}
+	}
+}
+
+// checkMesh reports an error if tris, with vertices points, is not a valid
+// Delaunay triangulation of a simply connected region: every triangle is
+// counter-clockwise, no edge is shared by more than two triangles, Euler's
+// formula holds, and no point is inside any circumcircle.
+func checkMesh(t *testing.T, points []Point, tris []Triangle) {
+	t.Helper()
+	count := map[Edge]int{}
+	vertices := map[Point]bool{}
+	for _, tri := range tris {
+		if orient(tri.A, tri.B, tri.C) <= 0 {
+			t.Errorf("triangle %v is not counter-clockwise", tri)
+		}
+		for _, e := range [3]Edge{{tri.A, tri.B}, {tri.B, tri.C}, {tri.C, tri.A}} {
+			count[e.canonical()]++
+		}
+		vertices[tri.A], vertices[tri.B], vertices[tri.C] = true, true, true
+	}
+	boundary := 0
+	for e, n := range count {
+		if n > 2 {
+			t.Errorf("edge %v is shared by %d triangles", e, n)
+		}
+		if n == 1 {
+			boundary++
+		}
+	}
+	if got, want := len(tris), 2*len(vertices)-2-boundary; got != want {
+		t.Errorf("#triangles: got %v, want %v for %d vertices and %d boundary edges", got, want, len(vertices), boundary)
+	}
+	for _, tri := range ValidateDelaunay(points, tris) {
+		t.Errorf("triangle %v: circumcircle is not empty", tri)
+	}
+}
+
+func TestDelaunayTriangulationGrid(t *testing.T) {
+	super := Triangle{
+		A: Point{-100, -100},
+		B: Point{100, -100},
+		C: Point{0, 100},
+	}
+	var points []Point
+	for i := 0; i < 20; i++ {
+		for j := 0; j < 20; j++ {
+			points = append(points, Point{float64(i) * tenth, float64(j) * tenth})
+		}
+	}
+	u, err := DelaunayTriangulation(points, super)
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkMesh(t, points, u)
+	if got, want := len(u), 2*19*19; got != want {
+		t.Errorf("#triangles: got %v, want %v", got, want)
+	}
+}
+
+func TestDelaunayTriangulationColumns(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	// Tall columns of points with equal X, some of them very close
+	// together, make thin triangles whose circumcircles reach far to the
+	// right of the points that are being inserted. None of them may be
+	// retired too early.
+	var points []Point
+	for _, x := range []float64{0, 1e-6, 1, 3, 3 + 1e-9} {
+		for i := 0; i < 150; i++ {
+			points = append(points, Point{x, r.Float64() * 1000})
+		}
+	}
+	// The circumcircles of the thinnest triangles are millions of units
+	// across, so super must be larger still.
+	super := SuperTriangleFor(points, 1e9)
+	want, err := DelaunayTriangulation(points, super)
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkMesh(t, points, want)
+	hull := convexHull(points)
+	onHull := 0
+	for _, p := range points {
+		for i, a := range hull {
+			if b := hull[(i+1)%len(hull)]; orient(a, b, p) == 0 {
+				onHull++
+				break
+			}
+		}
+	}
+	if got, want := len(want), 2*len(points)-2-onHull; got != want {
+		t.Errorf("#triangles: got %v, want %v", got, want)
+	}
+	// WithInCircle turns off retirement.
+	sorted, err := DelaunayTriangulation(points, super, WithSortedOutput())
+	if err != nil {
+		t.Fatal(err)
+	}
+	unretired, err := DelaunayTriangulation(points, super, WithSortedOutput(), WithInCircle(EuclideanInCircle))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(sorted) != len(unretired) {
+		t.Fatalf("without retirement: #triangles: got %v, want %v", len(unretired), len(sorted))
+	}
+	for i := range sorted {
+		if got, want := unretired[i], sorted[i]; got.A != want.A || got.B != want.B || got.C != want.C {
+			t.Fatalf("without retirement: triangle %d: got %v, want %v", i, got, want)
+		}
+	}
+
+	// The insertion order, and so the output, does not depend on the
+	// order of the input.
+	for i := 0; i < 3; i++ {
+		r.Shuffle(len(points), func(i, j int) { points[i], points[j] = points[j], points[i] })
+		got, err := DelaunayTriangulation(points, super)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Fatalf("shuffle %d: output differs", i)
+		}
+	}
+}
+
+func TestDelaunayTriangulationSignedZero(t *testing.T) {
+	negZero := math.Copysign(0, -1)
+	points := []Point{{negZero, 0}, {1, 0}, {0, 1}, {0, 0}}
+	got, err := DelaunayTriangulation(points, SuperTriangleFor(points, 10))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, tri := range got {
+		for _, v := range []Point{tri.A, tri.B, tri.C} {
+			if v.X == 0 && v.Y == 0 && !math.Signbit(v.X) {
+				t.Errorf("got vertex %v with positive zero X, want the first of the equal points", v)
+			}
+		}
+	}
+}
+
+func TestDelaunayTriangulationDeterministic(t *testing.T) {
+	// Columns of points with equal X, cocircular squares, duplicates and
+	// zeros of both signs all tie somewhere in the sort.
+	var points []Point
+	for i := 0; i < 8; i++ {
+		for j := 0; j < 8; j++ {
+			points = append(points, Point{float64(i), float64(j)}, Point{float64(i), float64(j) + rand.Float64()})
+		}
+	}
+	points = append(points, points[:10]...)
+	points = append(points, Point{math.Copysign(0, -1), 0})
+	super := SuperTriangleFor(points, 10)
+
+	for _, opts := range [][]Option{nil, {WithGridIndex(0)}, {WithHullCompletion()}, {WithNormalization()}} {
+		var outputs [2][]byte
+		for i := range outputs {
+			got, err := DelaunayTriangulation(append([]Point(nil), points...), super, opts...)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var buf bytes.Buffer
+			if err := WriteTriangles(&buf, got); err != nil {
+				t.Fatal(err)
+			}
+			outputs[i] = buf.Bytes()
+		}
+		if !bytes.Equal(outputs[0], outputs[1]) {
+			t.Errorf("%d options: the two calls gave different output", len(opts))
+		}
+	}
+}
+
+func TestWithCanonical(t *testing.T) {
+	// A grid is full of cocircular squares, and the zeros of both signs
+	// compare equal in the sort.
+	var grid []Point
+	for i := 0; i < 8; i++ {
+		for j := 0; j < 8; j++ {
+			grid = append(grid, Point{float64(i), float64(j)})
+		}
+	}
+	grid = append(grid, grid[9], Point{math.Copysign(0, -1), 0}, Point{0, math.Copysign(0, -1)})
+	super := SuperTriangleFor(grid, 10)
+
+	r := rand.New(rand.NewSource(1))
+	var want []byte
+	for k := 0; k < 5; k++ {
+		points := append([]Point(nil), grid...)
+		r.Shuffle(len(points), func(i, j int) { points[i], points[j] = points[j], points[i] })
+		// An in-circle test that breaks ties by input order is ignored.
+		got, err := DelaunayTriangulation(points, super, WithInCircle((*Triangle).CircumcircleContains), WithPresortedInput(), WithCanonical())
+		if err != nil {
+			t.Fatal(err)
+		}
+		var buf bytes.Buffer
+		if err := WriteTriangles(&buf, got); err != nil {
+			t.Fatal(err)
+		}
+		if k == 0 {
+			want = buf.Bytes()
+			if len(got) != 2*7*7 {
+				t.Errorf("#triangles: got %v, want %v", len(got), 2*7*7)
+			}
+			for _, tri := range got {
+				for _, p := range [3]Point{tri.A, tri.B, tri.C} {
+					if math.Signbit(p.X) || math.Signbit(p.Y) {
+						t.Errorf("%v has a negative zero", tri)
+					}
+				}
+			}
+		} else if !bytes.Equal(buf.Bytes(), want) {
+			t.Errorf("shuffle %d: got different output", k)
+		}
+	}
+}
+
+func TestDelaunayTriangulationE(t *testing.T) {
+	super := Triangle{
+		A: Point{0, 50},
+		B: Point{50, -50},
+		C: Point{-50, -50},
+	}
+	points := []Point{
+		{0, 0}, {1, 0}, {0, 1}, {1, 1},
+		{1, 0},                    // 4: duplicate
+		{math.NaN(), 0},           // 5: invalid
+		{100, 100},                // 6: outside
+		{25, 0},                   // 7: on an edge of super
+		{math.Inf(1), 1},          // 8: invalid
+		{0.5, 0.5},                // 9
+		{math.Copysign(0, -1), 0}, // 10: duplicate of 0
+	}
+	triangles, omitted, err := DelaunayTriangulationE(points, super)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []int{4, 5, 6, 7, 8, 10}; !reflect.DeepEqual(omitted, want) {
+		t.Errorf("omitted: got %v, want %v", omitted, want)
+	}
+	if len(triangles) != 4 {
+		t.Errorf("#triangles: got %v, want 4", len(triangles))
+	}
+
+	// Merged within Tolerance, the point first in (X, Y) order is kept.
+	defer func(tol float64) { Tolerance = tol }(Tolerance)
+	Tolerance = 0.01
+	_, omitted, err = DelaunayTriangulationE([]Point{{1.005, 1}, {0, 0}, {1, 0}, {0, 1}, {1, 1}}, super)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []int{0}; !reflect.DeepEqual(omitted, want) {
+		t.Errorf("tolerance: omitted: got %v, want %v", omitted, want)
+	}
+	Tolerance = 0
+
+	// The index of a rejected duplicate counts the skipped points.
+	dups := []Point{points[0], points[5], points[6], points[1], points[2], points[1]}
+	_, _, err = DelaunayTriangulationE(dups, super, WithDuplicates(RejectDuplicates))
+	var pe *PointError
+	if !errors.As(err, &pe) || pe.Err != ErrDuplicatePoint || pe.Index != 5 {
+		t.Errorf("reject: got error %v, want %v for point 5", err, ErrDuplicatePoint)
+	}
+	if _, _, err := DelaunayTriangulationE(points[5:9], super); err != ErrEmptyInput {
+		t.Errorf("all skipped: got error %v, want %v", err, ErrEmptyInput)
+	}
+}
+
+func TestDelaunayTriangulationCocircular(t *testing.T) {
+	super := Triangle{
+		A: Point{-50000, -50000},
+		B: Point{50000, -50000},
+		C: Point{0, 50000},
+	}
+	// 5525² = 5⁴·13²·17² has 180 representations as a sum of two squares,
+	// so these points lie exactly on a circle.
+	var points []Point
+	const r = 5525
+	for x := -r; x <= r; x++ {
+		y := int(math.Sqrt(float64(r*r - x*x)))
+		if x*x+y*y != r*r {
+			continue
+		}
+		points = append(points, Point{float64(x), float64(y)})
+		if y != 0 {
+			points = append(points, Point{float64(x), float64(-y)})
+		}
+	}
+	if len(points) != 180 {
+		t.Fatalf("#points: got %v, want 180", len(points))
+	}
+
+	u, err := DelaunayTriangulation(points, super)
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkMesh(t, points, u)
+	if got, want := len(u), len(points)-2; got != want {
+		t.Errorf("#triangles: got %v, want %v", got, want)
+	}
+}
+
+func TestInCircumcircle(t *testing.T) {
+	a, b, c := Point{0, 0}, Point{2, 0}, Point{0, 2}
+	for _, tt := range []struct {
+		p    Point
+		want bool
+	}{
+		{Point{1, 1}, true},
+		{Point{1.9, 1.9}, true},
+		{Point{2, 2}, false}, // on the circle
+		{Point{2.1, 2}, false},
+		{a, false},
+		{Point{-0.4, 1}, true},
+		{Point{-0.5, 1}, false},
+		{Point{1e-300, 1e-300}, true},
+	} {
+		if got := InCircumcircle(a, b, c, tt.p); got != tt.want {
+			t.Errorf("%v: got %v, want %v", tt.p, got, tt.want)
+		}
+		if got := InCircumcircle(a, c, b, tt.p); got != tt.want {
+			t.Errorf("%v, clockwise: got %v, want %v", tt.p, got, tt.want)
+		}
+	}
+	if InCircumcircle(Point{0, 0}, Point{1, 1}, Point{2, 2}, Point{1, 0}) {
+		t.Error("collinear: got true")
+	}
+
+	// A triangle used without calling CalcCircumCircle first, once a
+	// silent source of wrong answers, agrees with InCircumcircle.
+	for i := 0; i < 1000; i++ {
+		var ps [4]Point
+		for j := range ps {
+			x, y := getRandomPointInCircle(10)
+			ps[j] = Point{x, y}
+		}
+		tri := Triangle{A: ps[0], B: ps[1], C: ps[2]}
+		if got, want := tri.CircumcircleContains(ps[3]), InCircumcircle(ps[0], ps[1], ps[2], ps[3]); got != want {
+			t.Errorf("%v, %v: CircumcircleContains gave %v, want %v", tri, ps[3], got, want)
+		}
+	}
+}
+
+func TestCircumcircleContainsBoundary(t *testing.T) {
+	tri := Triangle{A: Point{0, 0}, B: Point{1, 0}, C: Point{1, 1}}
+	tests := []struct {
+		p              Point
+		closed, strict bool
+	}{
+		{Point{0, 1}, true, false},
+		{tri.A, true, false},
+		{Point{0.5, 0.5}, true, true},
+		{Point{2, 2}, false, false},
+	}
+	for _, tt := range tests {
+		if got := tri.CircumcircleContains(tt.p); got != tt.closed {
+			t.Errorf("CircumcircleContains(%v): got %v, want %v", tt.p, got, tt.closed)
+		}
+		if got := tri.CircumcircleContainsStrict(tt.p); got != tt.strict {
+			t.Errorf("CircumcircleContainsStrict(%v): got %v, want %v", tt.p, got, tt.strict)
+		}
+	}
+
+	// Four cocircular points give two triangles that do not overlap.
+	points := []Point{{0, 0}, {1, 0}, {1, 1}, {0, 1}}
+	super := SuperTriangleFor(points, 10)
+	for name, opts := range map[string][]Option{
+		"default": nil,
+		"strict":  {WithInCircle((*Triangle).CircumcircleContainsStrict)},
+	} {
+		got, err := DelaunayTriangulation(points, super, opts...)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(got) != 2 {
+			t.Errorf("%s: #triangles: got %v, want 2", name, len(got))
+		}
+		checkMesh(t, points, got)
+	}
+}
+
+func TestDelaunayTriangulationSquareTieBreak(t *testing.T) {
+	// The four corners of a square are cocircular, so either diagonal
+	// gives a Delaunay triangulation. The symbolic perturbation of
+	// EuclideanInCircle always picks the one from (0, 1) to (1, 0),
+	// whatever the order of the points.
+	square := []Point{{0, 0}, {1, 0}, {1, 1}, {0, 1}}
+	want := []Triangle{
+		{A: Point{0, 0}, B: Point{1, 0}, C: Point{0, 1}},
+		{A: Point{0, 1}, B: Point{1, 0}, C: Point{1, 1}},
+	}
+	super := SuperTriangleFor(square, 10)
+	var permute func(k int)
+	permute = func(k int) {
+		if k == len(square) {
+			for _, opts := range [][]Option{{WithSortedOutput()}, {WithSortedOutput(), WithGridIndex(0)}} {
+				got, err := DelaunayTriangulation(square, super, opts...)
+				if err != nil {
+					t.Fatal(err)
+				}
+				for i := range got {
+					got[i] = Triangle{A: got[i].A, B: got[i].B, C: got[i].C}
+				}
+				if !reflect.DeepEqual(got, want) {
+					t.Errorf("%v: got %v, want %v", square, got, want)
+				}
+			}
+			return
+		}
+		for i := k; i < len(square); i++ {
+			square[k], square[i] = square[i], square[k]
+			permute(k + 1)
+			square[k], square[i] = square[i], square[k]
+		}
+	}
+	permute(0)
+}
+
+func TestCircumcircles(t *testing.T) {
+	points := []Point{{0, 0}, {4, 0}, {0, 4}, {4, 4}, {1, 2}}
+	triangles, err := DelaunayTriangulation(points, SuperTriangleFor(points, 10))
+	if err != nil {
+		t.Fatal(err)
+	}
+	triangles = append(triangles,
+		Triangle{A: Point{0, 0}, B: Point{2, 0}, C: Point{0, 2}},
+		Triangle{A: Point{0, 0}, B: Point{1, 1}, C: Point{2, 2}},
+	)
+	circles := Circumcircles(triangles)
+	if len(circles) != len(triangles) {
+		t.Fatalf("#circles: got %v, want %v", len(circles), len(triangles))
+	}
+	for i, tri := range triangles[:len(triangles)-1] {
+		c := circles[i]
+		for _, v := range [3]Point{tri.A, tri.B, tri.C} {
+			if d := math.Hypot(v.X-c.Center.X, v.Y-c.Center.Y); math.Abs(d-c.Radius) > 1e-12 {
+				t.Errorf("%v: vertex %v is %v from the center, want radius %v", tri, v, d, c.Radius)
+			}
+		}
+	}
+	if got, want := circles[len(circles)-2], (Circle{Center: Point{1, 1}, Radius: math.Sqrt2}); got != want {
+		t.Errorf("hand-built: got %v, want %v", got, want)
+	}
+	if got, want := circles[len(circles)-1], (Circle{Center: Point{1, 1}, Radius: math.Inf(1)}); got != want {
+		t.Errorf("degenerate: got %v, want %v", got, want)
+	}
+}
+
+func TestTriangleValue(t *testing.T) {
+	points := []Point{{0, 0}, {4, 0}, {0, 4}, {4, 4}, {1, 2}}
+	triangles, err := DelaunayTriangulation(points, SuperTriangleFor(points, 10))
+	if err != nil {
+		t.Fatal(err)
+	}
+	seen := map[Triangle]bool{}
+	for _, tri := range triangles {
+		seen[tri] = true
+	}
+	for _, tri := range triangles {
+		copy := Triangle{A: tri.A, B: tri.B, C: tri.C}
+		copy.CalcCircumCircle()
+		if copy != tri {
+			t.Errorf("got %+v, want %+v", copy, tri)
+		}
+		if !seen[copy] {
+			t.Errorf("%v: not found as a map key", copy)
+		}
+	}
+}
+
+func TestWithInCircle(t *testing.T) {
+	points := make([]Point, 100)
+	for i := range points {
+		x, y := getRandomPointInCircle(5)
+		points[i] = Point{x, y}
+	}
+	super := Triangle{
+		A: Point{0, 50},
+		B: Point{50, -50},
+		C: Point{-50, -50},
+	}
+	want := map[[3]Point]bool{}
+	dt, err := DelaunayTriangulation(points, super)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, tri := range dt {
+		want[sortedVertices(tri)] = true
+	}
+
+	calls := 0
+	counting := func(t *Triangle, p Point) bool {
+		calls++
+		return EuclideanInCircle(t, p)
+	}
+	for name, f := range map[string]InCircle{
+		"counting": counting,
+		"cached":   (*Triangle).CircumcircleContains,
+	} {
+		got, err := DelaunayTriangulation(points, super, WithInCircle(f))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(got) != len(want) {
+			t.Errorf("%s: #triangles: got %v, want %v", name, len(got), len(want))
+		}
+		for _, tri := range got {
+			if !want[sortedVertices(tri)] {
+				t.Errorf("%s: unexpected triangle %v", name, tri)
+			}
+		}
+	}
+	if calls == 0 {
+		t.Error("custom predicate was not called")
+	}
+
+	// A predicate that never reports a conflict leaves the super triangle
+	// intact, so every triangle touches it and none are returned.
+	never := func(*Triangle, Point) bool { return false }
+	if got, _ := DelaunayTriangulation(points, super, WithInCircle(never)); len(got) != 0 {
+		t.Errorf("never: got %d triangles, want 0", len(got))
+	}
+}
+
+func TestWithMaxTriangles(t *testing.T) {
+	points := make([]Point, 200)
+	for i := range points {
+		x, y := getRandomPointInCircle(5)
+		points[i] = Point{x, y}
+	}
+	super := SuperTriangleFor(points, 10)
+
+	// The default limit never stops a valid triangulation, but a tight one
+	// does, with either insertion loop.
+	for _, opts := range [][]Option{nil, {WithGridIndex(0)}} {
+		if _, err := DelaunayTriangulation(points, super, opts...); err != nil {
+			t.Fatalf("%d options: %v", len(opts), err)
+		}
+		_, err := DelaunayTriangulation(points, super, append(opts, WithMaxTriangles(100))...)
+		var le *LimitError
+		if !errors.As(err, &le) || !errors.Is(err, ErrTooManyTriangles) {
+			t.Fatalf("%d options: got error %v, want a *LimitError", len(opts), err)
+		}
+		if le.Max != 100 || le.Triangles <= 100 || le.Total != len(points) || le.Inserted > le.Total {
+			t.Errorf("%d options: unexpected state %+v", len(opts), le)
+		}
+	}
+
+	// A predicate that reports every third triangle as a conflict tears
+	// scattered holes whose boundaries have far more edges than the
+	// triangles removed, so the triangulation grows without bound.
+	calls := 0
+	erratic := func(*Triangle, Point) bool {
+		calls++
+		return calls%3 == 0
+	}
+	_, err := DelaunayTriangulation(points, super, WithInCircle(erratic))
+	var le *LimitError
+	if !errors.As(err, &le) {
+		t.Fatalf("got error %v, want a *LimitError", err)
+	}
+	if want := 4*len(points) + 16; le.Max != want {
+		t.Errorf("got limit %d, want %d", le.Max, want)
+	}
+	if le.Inserted >= le.Total {
+		t.Errorf("stopped after %d of %d points, want fewer", le.Inserted, le.Total)
+	}
+}
+
+// vectorsFile holds triangulations computed on a reference platform. The
+// output of DelaunayTriangulation, including the order of the triangles
+// and of their vertices, must match it exactly on every architecture.
+const vectorsFile = "testdata/vectors.txt"
+
+// A vector is an input to DelaunayTriangulation and the expected triangles,
+// as indices into points.
+type vector struct {
+	name      string
+	grid      bool
+	super     Triangle
+	points    []Point
+	triangles [][3]int
+}
+
+// vectorInputs returns the inputs of the test vectors. Their coordinates
+// are written to the file, so they need not be reproducible themselves.
+func vectorInputs() []vector {
+	r := rand.New(rand.NewSource(1))
+	var random, circle, offset, grid []Point
+	for i := 0; i < 300; i++ {
+		random = append(random, Point{float64(r.Intn(100<<10)) / 1024, float64(r.Intn(100<<10)) / 1024})
+	}
+	for i := 0; i < 64; i++ {
+		a := 2 * math.Pi * float64(i) / 64
+		circle = append(circle, Point{12.5 + 1e3*math.Cos(a), -7.25 + 1e3*math.Sin(a)})
+		offset = append(offset, Point{1e6 + 1e3*math.Cos(a), 1e6 + 1e3*math.Sin(a)})
+	}
+	circle = append(circle, Point{12.5, -7.25})
+	for i := 0; i < 10; i++ {
+		for j := 0; j < 10; j++ {
+			grid = append(grid, Point{float64(i), float64(j)})
+		}
+	}
+	var vs []vector
+	for _, in := range []struct {
+		name   string
+		points []Point
+	}{{"random", random}, {"circle", circle}, {"offset", offset}, {"grid", grid}} {
+		for _, g := range []bool{false, true} {
+			vs = append(vs, vector{name: in.name, grid: g, super: SuperTriangleFor(in.points, 10), points: in.points})
+		}
+	}
+	return vs
+}
+
+// triangulateVector returns the triangles DelaunayTriangulation finds for
+// v, as indices into v.points.
+func triangulateVector(t *testing.T, v vector) [][3]int {
+	t.Helper()
+	var opts []Option
+	if v.grid {
+		opts = append(opts, WithGridIndex(0))
+	}
+	tris, err := DelaunayTriangulation(v.points, v.super, opts...)
+	if err != nil {
+		t.Fatalf("%s: %v", v.name, err)
+	}
+	index := map[Point]int{}
+	for i, p := range v.points {
+		index[p] = i
+	}
+	result := make([][3]int, len(tris))
+	for i, tri := range tris {
+		result[i] = [3]int{index[tri.A], index[tri.B], index[tri.C]}
+	}
+	return result
+}
+
+func writeVectors(vs []vector) error {
+	f, err := os.Create(vectorsFile)
+	if err != nil {
+		return err
+	}
+	w := bufio.NewWriter(f)
+	g := func(x float64) string { return strconv.FormatFloat(x, 'g', -1, 64) }
+	for _, v := range vs {
+		fmt.Fprintf(w, "case %s %v\n", v.name, v.grid)
+		s := v.super
+		fmt.Fprintf(w, "super %s %s %s %s %s %s\n", g(s.A.X), g(s.A.Y), g(s.B.X), g(s.B.Y), g(s.C.X), g(s.C.Y))
+		fmt.Fprintf(w, "points %d\n", len(v.points))
+		for _, p := range v.points {
+			fmt.Fprintf(w, "%s %s\n", g(p.X), g(p.Y))
+		}
+		fmt.Fprintf(w, "triangles %d\n", len(v.triangles))
+		for _, tri := range v.triangles {
+			fmt.Fprintf(w, "%d %d %d\n", tri[0], tri[1], tri[2])
+		}
+	}
+	if err := w.Flush(); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+func readVectors() ([]vector, error) {
+	data, err := os.ReadFile(vectorsFile)
+	if err != nil {
+		return nil, err
+	}
+	fields := strings.Fields(string(data))
+	next := func() string {
+		if len(fields) == 0 {
+			return ""
+		}
+		s := fields[0]
+		fields = fields[1:]
+		return s
+	}
+	var firstErr error
+	num := func() float64 {
+		x, err := strconv.ParseFloat(next(), 64)
+		if err != nil && firstErr == nil {
+			firstErr = err
+		}
+		return x
+	}
+	var vs []vector
+	for len(fields) > 0 && firstErr == nil {
+		var v vector
+		if next() != "case" {
+			return nil, fmt.Errorf("%s: missing case", vectorsFile)
+		}
+		v.name, v.grid = next(), next() == "true"
+		next()
+		v.super = Triangle{A: Point{num(), num()}, B: Point{num(), num()}, C: Point{num(), num()}}
+		next()
+		v.points = make([]Point, int(num()))
+		for i := range v.points {
+			v.points[i] = Point{num(), num()}
+		}
+		next()
+		v.triangles = make([][3]int, int(num()))
+		for i := range v.triangles {
+			v.triangles[i] = [3]int{int(num()), int(num()), int(num())}
+		}
+		vs = append(vs, v)
+	}
+	return vs, firstErr
+}
+
+func TestDelaunayTriangulationVectors(t *testing.T) {
+	if *update {
+		vs := vectorInputs()
+		for i := range vs {
+			vs[i].triangles = triangulateVector(t, vs[i])
+		}
+		if err := writeVectors(vs); err != nil {
+			t.Fatal(err)
+		}
+	}
+	vs, err := readVectors()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(vs) == 0 {
+		t.Fatalf("%s: no vectors", vectorsFile)
+	}
+	for _, v := range vs {
+		if got := triangulateVector(t, v); !reflect.DeepEqual(got, v.triangles) {
+			t.Errorf("%s, grid %v: got triangles %v, want %v", v.name, v.grid, got, v.triangles)
+		}
+	}
+}
@@ -0,0 +1,124 @@
+// Package bwtesting provides helpers for testing code that produces
+// triangulations with package bowyer_watson.
+package bwtesting
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"testing"
+
+	bw "github.com/ChrisHines/bowyer-watson"
+)
+
+// AssertDelaunay reports an error through t for each element of triangles
+// whose circumcircle strictly contains an element of points, as found by
+// bowyer_watson.ValidateDelaunay. It checks only the empty circumcircle
+// property; bowyer_watson.Validate also checks that the triangles cover the
+// convex hull without overlapping. It takes time proportional to
+// len(triangles) * len(points).
+func AssertDelaunay(t testing.TB, points []bw.Point, triangles []bw.Triangle) {
+	t.Helper()
+	for _, tri := range bw.ValidateDelaunay(points, triangles) {
+		t.Errorf("triangle (%v, %v, %v) is not Delaunay: its circumcircle contains a point", tri.A, tri.B, tri.C)
+	}
+}
+
+// Check returns an error if triangles are not the Delaunay triangulation of
+// points. Besides the checks made by bowyer_watson.Validate, it confirms
+// that the triangles' total area equals the area of the points' convex hull
+// and that the numbers of vertices, edges and triangles satisfy Euler's
+// formula V - E + F = 1. It takes time proportional to
+// len(triangles) * len(points).
+func Check(points []bw.Point, triangles []bw.Triangle) error {
+	if err := bw.Validate(points, triangles); err != nil {
+		return err
+	}
+
+	area, hull := Area(triangles), HullArea(points)
+	if math.Abs(area-hull) > 1e-9*hull {
+		return fmt.Errorf("bwtesting: triangles have area %v, convex hull has area %v", area, hull)
+	}
+
+	vertices := map[bw.Point]bool{}
+	for _, t := range triangles {
+		vertices[t.A], vertices[t.B], vertices[t.C] = true, true, true
+	}
+	v, e, f := len(vertices), CountEdges(triangles), len(triangles)
+	if f > 0 && v-e+f != 1 {
+		return fmt.Errorf("bwtesting: %d vertices, %d edges and %d triangles violate Euler's formula", v, e, f)
+	}
+	return nil
+}
+
+// Area returns the total area of triangles, counting clockwise triangles
+// as negative.
+func Area(triangles []bw.Triangle) float64 {
+	area := 0.0
+	for _, t := range triangles {
+		area += cross(t.A, t.B, t.C) / 2
+	}
+	return area
+}
+
+// HullArea returns the area of the convex hull of points, which is zero if
+// there are fewer than three of them or they are collinear.
+func HullArea(points []bw.Point) float64 {
+	hull := convexHull(points)
+	area := 0.0
+	for i := 2; i < len(hull); i++ {
+		area += cross(hull[0], hull[i-1], hull[i]) / 2
+	}
+	return area
+}
+
+// CountEdges returns the number of distinct edges of triangles, counting an
+// edge shared by two triangles once regardless of its direction.
+func CountEdges(triangles []bw.Triangle) int {
+	edges := map[[2]bw.Point]bool{}
+	for _, t := range triangles {
+		for _, e := range [3][2]bw.Point{{t.A, t.B}, {t.B, t.C}, {t.C, t.A}} {
+			if less(e[1], e[0]) {
+				e[0], e[1] = e[1], e[0]
+			}
+			edges[e] = true
+		}
+	}
+	return len(edges)
+}
+
+// convexHull returns the vertices of the convex hull of points in
+// counter-clockwise order, by Andrew's monotone chain algorithm. It is
+// independent of the package under test.
+func convexHull(points []bw.Point) []bw.Point {
+	ps := append([]bw.Point(nil), points...)
+	if len(ps) < 3 {
+		return ps
+	}
+	sort.Slice(ps, func(i, j int) bool { return less(ps[i], ps[j]) })
+	var hull []bw.Point
+	for pass := 0; pass < 2; pass++ {
+		start := len(hull)
+		for _, p := range ps {
+			for len(hull) >= start+2 && cross(hull[len(hull)-2], hull[len(hull)-1], p) <= 0 {
+				hull = hull[:len(hull)-1]
+			}
+			hull = append(hull, p)
+		}
+		// The last point of each chain is the first of the other.
+		hull = hull[:len(hull)-1]
+		for i, j := 0, len(ps)-1; i < j; i, j = i+1, j-1 {
+			ps[i], ps[j] = ps[j], ps[i]
+		}
+	}
+	return hull
+}
+
+func less(a, b bw.Point) bool {
+	return a.X < b.X || a.X == b.X && a.Y < b.Y
+}
+
+// cross returns the z component of the cross product of b-a and c-a.
+func cross(a, b, c bw.Point) float64 {
+	return (b.X-a.X)*(c.Y-a.Y) - (b.Y-a.Y)*(c.X-a.X)
+}
@@ -0,0 +1,140 @@
+package bwtesting
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"testing"
+
+	bw "github.com/ChrisHines/bowyer-watson"
+)
+
+// recorder is a testing.TB that records the errors reported to it.
+type recorder struct {
+	testing.TB
+	errors []string
+}
+
+func (r *recorder) Helper() {}
+
+func (r *recorder) Errorf(format string, args ...interface{}) {
+	r.errors = append(r.errors, fmt.Sprintf(format, args...))
+}
+
+func TestAssertDelaunay(t *testing.T) {
+	points := []bw.Point{{X: 0, Y: 0}, {X: 4, Y: 0}, {X: 4, Y: 4}, {X: 0, Y: 4}, {X: 1, Y: 2}}
+	super := bw.SuperTriangleFor(points, 10)
+	triangles, err := bw.DelaunayTriangulation(points, super)
+	if err != nil {
+		t.Fatal(err)
+	}
+	AssertDelaunay(t, points, triangles)
+
+	// Splitting the square along the other diagonal puts (1, 2) inside
+	// the circumcircle of the triangle that does not contain it.
+	bad := []bw.Triangle{
+		{A: bw.Point{X: 0, Y: 0}, B: bw.Point{X: 4, Y: 0}, C: bw.Point{X: 4, Y: 4}},
+		{A: bw.Point{X: 0, Y: 0}, B: bw.Point{X: 4, Y: 4}, C: bw.Point{X: 0, Y: 4}},
+	}
+	r := &recorder{TB: t}
+	AssertDelaunay(r, points, bad)
+	if len(r.errors) != 2 {
+		t.Errorf("got %d errors %q, want 2", len(r.errors), r.errors)
+	}
+}
+
+func TestCheck(t *testing.T) {
+	square := []bw.Point{{X: 0, Y: 0}, {X: 4, Y: 0}, {X: 4, Y: 4}, {X: 0, Y: 4}, {X: 1, Y: 2}}
+	if got := HullArea(square); got != 16 {
+		t.Errorf("HullArea: got %v, want 16", got)
+	}
+	triangles, err := bw.Triangulate(square)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := Check(square, triangles); err != nil {
+		t.Error(err)
+	}
+	if got := CountEdges(triangles); got != 8 {
+		t.Errorf("CountEdges: got %v, want 8", got)
+	}
+	if got := Area(triangles); got != 16 {
+		t.Errorf("Area: got %v, want 16", got)
+	}
+	if err := Check(square, triangles[1:]); err == nil {
+		t.Error("missing triangle: got no error")
+	}
+}
+
+// decodePoints reads points from data as pairs of little-endian int16
+// coordinates. Small integers make collinear and cocircular points, and
+// duplicates, common. At most 64 points are read to keep Check fast.
+func decodePoints(data []byte) []bw.Point {
+	var points []bw.Point
+	for len(data) >= 4 && len(points) < 64 {
+		x := int16(binary.LittleEndian.Uint16(data))
+		y := int16(binary.LittleEndian.Uint16(data[2:]))
+		points = append(points, bw.Point{X: float64(x), Y: float64(y)})
+		data = data[4:]
+	}
+	return points
+}
+
+// encodePoints is the inverse of decodePoints for integer coordinates.
+func encodePoints(points []bw.Point) []byte {
+	var data []byte
+	for _, p := range points {
+		data = binary.LittleEndian.AppendUint16(data, uint16(int16(p.X)))
+		data = binary.LittleEndian.AppendUint16(data, uint16(int16(p.Y)))
+	}
+	return data
+}
+
+func FuzzDelaunay(f *testing.F) {
+	var grid, ring, line, dups []bw.Point
+	for i := 0; i < 6; i++ {
+		for j := 0; j < 6; j++ {
+			grid = append(grid, bw.Point{X: float64(i), Y: float64(j)})
+		}
+	}
+	// The twelve integer points at distance 5 from the origin.
+	for _, p := range [][2]float64{{5, 0}, {4, 3}, {3, 4}, {0, 5}} {
+		for _, s := range [][2]float64{{1, 1}, {-1, 1}, {-1, -1}, {1, -1}} {
+			ring = append(ring, bw.Point{X: s[0] * p[0], Y: s[1] * p[1]})
+		}
+	}
+	ring = append(ring, bw.Point{})
+	for i := 0; i < 10; i++ {
+		line = append(line, bw.Point{X: float64(3 * i), Y: float64(i)})
+	}
+	for i := 0; i < 4; i++ {
+		dups = append(dups, bw.Point{X: 1, Y: 1}, bw.Point{X: float64(i), Y: 7}, bw.Point{X: 1, Y: 1})
+	}
+	for _, points := range [][]bw.Point{
+		grid,
+		ring,
+		line,
+		append(line, bw.Point{X: 0, Y: 1}),
+		dups,
+		{{X: -32768, Y: -32768}, {X: 32767, Y: 32767}, {X: -32768, Y: 32767}, {X: 0, Y: 1}},
+	} {
+		f.Add(encodePoints(points))
+	}
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		points := decodePoints(data)
+		triangles, err := bw.Triangulate(points, bw.WithHullCompletion())
+		if errors.Is(err, bw.ErrEmptyInput) || errors.Is(err, bw.ErrTooFewPoints) || errors.Is(err, bw.ErrCollinearInput) {
+			if HullArea(points) != 0 {
+				t.Fatalf("%v: got %v for points with a hull of nonzero area", points, err)
+			}
+			return
+		}
+		if err != nil {
+			t.Fatalf("%v: %v", points, err)
+		}
+		if err := Check(points, triangles); err != nil {
+			t.Fatalf("%v: %v", points, err)
+		}
+	})
+}
@@ -0,0 +1,129 @@
+package bowyer_watson
+
+// ClipToRect returns the triangles that overlap the axis aligned rectangle
+// with corners min and max. Triangles lying entirely outside the rectangle
+// are discarded; triangles that straddle its boundary are returned whole.
+// The result is a new slice; triangles is not modified.
+func ClipToRect(triangles []Triangle, min, max Point) []Triangle {
+	var result []Triangle
+	for _, t := range triangles {
+		if triangleOverlapsRect(&t, min, max) {
+			result = append(result, t)
+		}
+	}
+	return result
+}
+
+// ClipToConvexPolygon returns the parts of triangles inside the convex
+// polygon, whose vertices may be in either order. Triangles lying entirely
+// inside are returned unchanged and those entirely outside are discarded.
+// Triangles that straddle the boundary are clipped with the
+// Sutherland-Hodgman algorithm and the clipped polygon is replaced by a fan
+// of triangles from its first vertex, with the orientation of the original.
+// Fan triangles of zero area are dropped. ClipToConvexPolygon returns nil if
+// polygon has zero area.
+func ClipToConvexPolygon(triangles []Triangle, polygon []Point) []Triangle {
+	area := polygonArea(polygon)
+	if area == 0 {
+		return nil
+	}
+	clip := polygon
+	if area < 0 {
+		clip = make([]Point, len(polygon))
+		for i, p := range polygon {
+			clip[len(clip)-1-i] = p
+		}
+	}
+
+	var result []Triangle
+	for _, t := range triangles {
+		if insideConvex(t.A, clip) && insideConvex(t.B, clip) && insideConvex(t.C, clip) {
+			result = append(result, t)
+			continue
+		}
+		poly := clipPolygon([]Point{t.A, t.B, t.C}, clip)
+		for i := 2; i < len(poly); i++ {
+			if orient(poly[0], poly[i-1], poly[i]) != 0 {
+				result = append(result, Triangle{A: poly[0], B: poly[i-1], C: poly[i]})
+			}
+		}
+	}
+	return result
+}
+
+// insideConvex reports whether p lies inside or on the boundary of the
+// convex polygon clip, whose vertices must be counter-clockwise. It agrees
+// with clipPolygon on which points are kept.
+func insideConvex(p Point, clip []Point) bool {
+	for i, a := range clip {
+		if cross(a, clip[(i+1)%len(clip)], p) < 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// triangleOverlapsRect reports whether t and the rectangle [min, max]
+// intersect, using the separating axis theorem. The candidate axes are the
+// rectangle's two axes and the normals of t's three edges.
+func triangleOverlapsRect(t *Triangle, min, max Point) bool {
+	if t.A.X < min.X && t.B.X < min.X && t.C.X < min.X ||
+		t.A.X > max.X && t.B.X > max.X && t.C.X > max.X ||
+		t.A.Y < min.Y && t.B.Y < min.Y && t.C.Y < min.Y ||
+		t.A.Y > max.Y && t.B.Y > max.Y && t.C.Y > max.Y {
+		return false
+	}
+
+	corners := [4]Point{min, {max.X, min.Y}, max, {min.X, max.Y}}
+	vs := [3]Point{t.A, t.B, t.C}
+	for i := range vs {
+		a, b, c := vs[i], vs[(i+1)%3], vs[(i+2)%3]
+		// The sign of cross(b-a, c-a) tells us which side of edge ab is
+		// inside t. The edge separates if every corner is on the other side.
+		side := cross(a, b, c)
+		separated := true
+		for _, p := range corners {
+			if cross(a, b, p)*side >= 0 {
+				separated = false
+				break
+			}
+		}
+		if separated {
+			return false
+		}
+	}
+	return true
+}
+
+// cross returns the z component of the cross product of b-a and c-a.
+func cross(a, b, c Point) float64 {
+	return (b.X-a.X)*(c.Y-a.Y) - (b.Y-a.Y)*(c.X-a.X)
+}
+
+// clipPolygon returns the part of the polygon poly inside the convex polygon
+// clip, whose vertices must be counter-clockwise. It uses the
+// Sutherland-Hodgman algorithm, clipping poly against each edge of clip in
+// turn.
+func clipPolygon(poly, clip []Point) []Point {
+	out := poly
+	for i := range clip {
+		a, b := clip[i], clip[(i+1)%len(clip)]
+		in := out
+		out = nil
+		for j, p := range in {
+			q := in[(j+1)%len(in)]
+			dp, dq := cross(a, b, p), cross(a, b, q)
+			if dp >= 0 {
+				out = append(out, p)
+			}
+			if dp >= 0 != (dq >= 0) {
+				s := dp / (dp - dq)
+				out = append(out, Point{p.X + s*(q.X-p.X), p.Y + s*(q.Y-p.Y)})
+			}
+		}
+		if len(out) == 0 {
+			break
+		}
+	}
+	return out
+}
@@ -0,0 +1,174 @@
+package bowyer_watson
+
+import (
+	"math"
+	"reflect"
+	"testing"
+)
+
+func TestClipToRect(t *testing.T) {
+	points := make([]Point, 200)
+	for i := range points {
+		x, y := getRandomPointInCircle(10)
+		points[i] = Point{x, y}
+	}
+
+	super := Triangle{
+		A: Point{0, 100},
+		B: Point{100, -100},
+		C: Point{-100, -100},
+	}
+
+	u, err := DelaunayTriangulation(points, super)
+	if err != nil {
+		t.Fatal(err)
+	}
+	orig := append([]Triangle(nil), u...)
+	min, max := Point{-3, -2}, Point{4, 5}
+	clipped := ClipToRect(u, min, max)
+	if !reflect.DeepEqual(u, orig) {
+		t.Error("input triangles modified")
+	}
+
+	if len(clipped) == 0 || len(clipped) >= len(u) {
+		t.Fatalf("#triangles: got %v of %v", len(clipped), len(u))
+	}
+
+	for _, tri := range clipped {
+		if outsideRect(tri, min, max) {
+			t.Errorf("triangle %v lies outside [%v, %v]", tri, min, max)
+		}
+	}
+
+	kept := 0
+	for _, tri := range u {
+		if hasVertexInRect(tri, min, max) {
+			kept++
+		}
+	}
+	if kept > len(clipped) {
+		t.Errorf("dropped triangles with a vertex inside the rectangle: got %v, want at least %v", len(clipped), kept)
+	}
+}
+
+func TestClipToRectCorner(t *testing.T) {
+	// The bounding box of this triangle overlaps the rectangle, but its
+	// hypotenuse passes outside the corner at (1, 1).
+	tri := Triangle{A: Point{1.5, 2}, B: Point{3, 2}, C: Point{3, 0.5}}
+	if got := ClipToRect([]Triangle{tri}, Point{0, 0}, Point{1.5, 1.5}); len(got) != 0 {
+		t.Errorf("got %v, want no triangles", got)
+	}
+	if got := ClipToRect([]Triangle{tri}, Point{0, 0}, Point{2.5, 1.5}); len(got) != 1 {
+		t.Errorf("got %v, want 1 triangle", got)
+	}
+}
+
+func TestClipToConvexPolygon(t *testing.T) {
+	points := make([]Point, 200)
+	for i := range points {
+		x, y := getRandomPointInCircle(10)
+		points[i] = Point{x, y}
+	}
+	// The points' hull contains the hexagon, so the clipped triangles
+	// cover it exactly.
+	u, err := DelaunayTriangulation(append(points, Point{0, 10}, Point{10, 0}, Point{0, -10}, Point{-10, 0}), SuperTriangleFor(points, 10))
+	if err != nil {
+		t.Fatal(err)
+	}
+	hexagon := make([]Point, 6)
+	for i := range hexagon {
+		s, c := math.Sincos(float64(i) * math.Pi / 3)
+		hexagon[i] = Point{6 * c, 6 * s}
+	}
+	clipped := ClipToConvexPolygon(u, hexagon)
+
+	inside := map[Triangle]bool{}
+	for _, tri := range u {
+		if insideConvex(tri.A, hexagon) && insideConvex(tri.B, hexagon) && insideConvex(tri.C, hexagon) {
+			inside[tri] = true
+		}
+	}
+	area := 0.0
+	for _, tri := range clipped {
+		delete(inside, tri)
+		a := polygonArea([]Point{tri.A, tri.B, tri.C})
+		if a <= 0 {
+			t.Errorf("triangle %v has area %v", tri, a)
+		}
+		area += a
+		for _, p := range []Point{tri.A, tri.B, tri.C} {
+			if d := distanceOutside(p, hexagon); d > 1e-12 {
+				t.Errorf("vertex %v of %v lies %v outside the hexagon", p, tri, d)
+			}
+		}
+	}
+	if len(inside) != 0 {
+		t.Errorf("dropped or changed %d triangles inside the hexagon", len(inside))
+	}
+	if want := 54 * math.Sqrt(3); math.Abs(area-want) > 1e-9 {
+		t.Errorf("area: got %v, want %v", area, want)
+	}
+
+	reversed := make([]Point, len(hexagon))
+	for i, p := range hexagon {
+		reversed[len(hexagon)-1-i] = p
+	}
+	if got := ClipToConvexPolygon(u, reversed); !reflect.DeepEqual(got, clipped) {
+		t.Error("clockwise polygon gave a different result")
+	}
+
+	line := []Point{{0, 0}, {1, 1}, {2, 2}}
+	if got := ClipToConvexPolygon(u, line); got != nil {
+		t.Errorf("degenerate polygon: got %v, want nil", got)
+	}
+}
+
+func TestClipToConvexPolygonStraddling(t *testing.T) {
+	tri := Triangle{A: Point{0, 0}, B: Point{4, 0}, C: Point{0, 4}}
+	square := []Point{{1, -1}, {3, -1}, {3, 2}, {1, 2}}
+	got := ClipToConvexPolygon([]Triangle{tri}, square)
+	// The clipped part is the rectangle [1, 3] x [0, 2] less the corner
+	// cut off by the hypotenuse x + y = 4.
+	area := 0.0
+	for _, tri := range got {
+		area += polygonArea([]Point{tri.A, tri.B, tri.C})
+	}
+	if want := 3.5; math.Abs(area-want) > 1e-12 {
+		t.Errorf("area: got %v, want %v from %v", area, want, got)
+	}
+
+	if got := ClipToConvexPolygon([]Triangle{tri}, []Point{{5, 5}, {6, 5}, {6, 6}}); len(got) != 0 {
+		t.Errorf("got %v, want no triangles", got)
+	}
+	// A triangle touching the polygon only along an edge is discarded.
+	if got := ClipToConvexPolygon([]Triangle{tri}, []Point{{0, -1}, {4, -1}, {4, 0}, {0, 0}}); len(got) != 0 {
+		t.Errorf("got %v, want no triangles", got)
+	}
+}
+
+// distanceOutside returns how far p lies outside the counter-clockwise
+// convex polygon poly, or a negative value if it is inside.
+func distanceOutside(p Point, poly []Point) float64 {
+	d := math.Inf(-1)
+	for i, a := range poly {
+		b := poly[(i+1)%len(poly)]
+		d = math.Max(d, -cross(a, b, p)/math.Hypot(b.X-a.X, b.Y-a.Y))
+	}
+	return d
+}
+
+func outsideRect(t Triangle, min, max Point) bool {
+	return t.A.X < min.X && t.B.X < min.X && t.C.X < min.X ||
+		t.A.X > max.X && t.B.X > max.X && t.C.X > max.X ||
+		t.A.Y < min.Y && t.B.Y < min.Y && t.C.Y < min.Y ||
+		t.A.Y > max.Y && t.B.Y > max.Y && t.C.Y > max.Y
+}
+
+func hasVertexInRect(t Triangle, min, max Point) bool {
+	for _, p := range []Point{t.A, t.B, t.C} {
+		if p.X >= min.X && p.X <= max.X && p.Y >= min.Y && p.Y <= max.Y {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,238 @@
+package bowyer_watson
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrPolygonEdgesCross means two edges of a Polygon passed to
+// TriangulatePolygon cross each other, so that no triangulation can contain
+// both.
+var ErrPolygonEdgesCross = errors.New("bowyer_watson: polygon edges cross")
+
+// TriangulatePolygon returns a triangulation of the region bounded by poly,
+// with its holes left empty. It triangulates the vertices of every ring,
+// recovers each edge of poly that is missing from the result to form the
+// constrained Delaunay triangulation of the vertices and edges, and keeps the
+// triangles whose centroids poly contains. An edge that passes through
+// another vertex is split there. The triangles are counter-clockwise.
+//
+// It returns the errors of DelaunayTriangulation for the vertices, such as
+// ErrCollinearInput, and ErrPolygonEdgesCross if two edges cross.
+func TriangulatePolygon(poly Polygon) ([]Triangle, error) {
+	var points []Point
+	for _, ring := range poly.rings() {
+		points = append(points, ring...)
+	}
+	super := SuperTriangleFor(points, MinSuperMargin)
+	triangles, err := DelaunayTriangulation(points, super, WithHullCompletion())
+	if err != nil {
+		return nil, err
+	}
+
+	m := newConstrainedMesh(triangles)
+	for _, e := range poly.Edges() {
+		if err := m.insertConstraint(e.A, e.B); err != nil {
+			return nil, err
+		}
+	}
+
+	var result []Triangle
+	for i, t := range m.tris {
+		centroid := Point{(t.A.X + t.B.X + t.C.X) / 3, (t.A.Y + t.B.Y + t.C.Y) / 3}
+		if !m.dead[i] && poly.Contains(centroid) {
+			result = append(result, t)
+		}
+	}
+	return result, nil
+}
+
+// constrainedMesh is a triangulation into which edges can be forced.
+type constrainedMesh struct {
+	tris        []Triangle
+	dead        []bool
+	owner       map[Edge]int // the live triangle with each directed edge
+	vertices    map[Point]bool
+	constraints map[Edge]bool // canonical
+}
+
+func newConstrainedMesh(triangles []Triangle) *constrainedMesh {
+	m := &constrainedMesh{
+		owner:       make(map[Edge]int, 3*len(triangles)),
+		vertices:    map[Point]bool{},
+		constraints: map[Edge]bool{},
+	}
+	for _, t := range triangles {
+		m.add(t)
+	}
+	return m
+}
+
+// add adds t, made counter-clockwise, to m.
+func (m *constrainedMesh) add(t Triangle) {
+	if orient(t.A, t.B, t.C) < 0 {
+		t.B, t.C = t.C, t.B
+	}
+	i := len(m.tris)
+	m.tris = append(m.tris, t)
+	m.dead = append(m.dead, false)
+	for _, e := range [3]Edge{{t.A, t.B}, {t.B, t.C}, {t.C, t.A}} {
+		m.owner[e] = i
+		m.vertices[e.A] = true
+	}
+}
+
+// remove deletes the triangle at index i.
+func (m *constrainedMesh) remove(i int) {
+	m.dead[i] = true
+	t := m.tris[i]
+	for _, e := range [3]Edge{{t.A, t.B}, {t.B, t.C}, {t.C, t.A}} {
+		if m.owner[e] == i {
+			delete(m.owner, e)
+		}
+	}
+}
+
+// insertConstraint makes the segment from a to b, which must be vertices of
+// m, an edge of m. The triangles it crosses are removed, and the
+// pseudo-polygons on either side of it are retriangulated as described by
+// Anglada, "An improved incremental algorithm for constructing restricted
+// Delaunay triangulations", 1997.
+func (m *constrainedMesh) insertConstraint(a, b Point) error {
+	for _, v := range [2]Point{a, b} {
+		if !m.vertices[v] {
+			return fmt.Errorf("bowyer_watson: polygon vertex %v was merged with another", v)
+		}
+	}
+	if a == b {
+		return nil
+	}
+
+	// A vertex on the segment splits it. The one nearest a is taken, so
+	// that the choice does not depend on the order of the map.
+	split, found := Point{}, false
+	for v := range m.vertices {
+		if onOpenSegment(a, b, v) && (!found || onOpenSegment(a, split, v)) {
+			split, found = v, true
+		}
+	}
+	if found {
+		if err := m.insertConstraint(a, split); err != nil {
+			return err
+		}
+		return m.insertConstraint(split, b)
+	}
+
+	e := Edge{a, b}
+	m.constraints[e.canonical()] = true
+	if _, ok := m.owner[e]; ok {
+		return nil
+	}
+	if _, ok := m.owner[Edge{b, a}]; ok {
+		return nil
+	}
+
+	var crossed []int
+	for i, t := range m.tris {
+		if m.dead[i] {
+			continue
+		}
+		for _, f := range [3]Edge{{t.A, t.B}, {t.B, t.C}, {t.C, t.A}} {
+			if !segmentsCross(a, b, f.A, f.B) {
+				continue
+			}
+			if m.constraints[f.canonical()] {
+				return ErrPolygonEdgesCross
+			}
+			crossed = append(crossed, i)
+			break
+		}
+	}
+
+	// The crossed triangles form a polygon whose boundary runs
+	// counter-clockwise from a to b on the right of the segment and back
+	// on its left.
+	inner := map[Edge]bool{}
+	for _, i := range crossed {
+		t := m.tris[i]
+		for _, f := range [3]Edge{{t.A, t.B}, {t.B, t.C}, {t.C, t.A}} {
+			inner[f] = true
+		}
+	}
+	next := map[Point]Point{}
+	for f := range inner {
+		if !inner[Edge{f.B, f.A}] {
+			next[f.A] = f.B
+		}
+	}
+	for _, i := range crossed {
+		m.remove(i)
+	}
+	right, ok := chain(next, a, b)
+	if !ok {
+		return fmt.Errorf("bowyer_watson: cannot recover edge %v", e)
+	}
+	left, ok := chain(next, b, a)
+	if !ok {
+		return fmt.Errorf("bowyer_watson: cannot recover edge %v", e)
+	}
+	m.fill(right)
+	m.fill(left)
+	return nil
+}
+
+// chain follows next from a to b and returns the vertices visited,
+// including a and b. It returns false if b cannot be reached.
+func chain(next map[Point]Point, a, b Point) ([]Point, bool) {
+	vs := []Point{a}
+	for p := a; p != b; {
+		q, ok := next[p]
+		if !ok || len(vs) > len(next) {
+			return nil, false
+		}
+		vs = append(vs, q)
+		p = q
+	}
+	return vs, true
+}
+
+// fill triangulates the pseudo-polygon formed by the vertices of vs, which
+// lie on one side of the segment joining its first and last elements. The
+// vertex whose circle with the segment contains no other becomes the apex
+// of a Delaunay triangle, and the pseudo-polygons on either side of it are
+// filled in turn.
+func (m *constrainedMesh) fill(vs []Point) {
+	n := len(vs)
+	if n < 3 {
+		return
+	}
+	a, b := vs[0], vs[n-1]
+	c := 1
+	for i := 2; i < n-1; i++ {
+		t := Triangle{A: a, B: vs[c], C: b}
+		if orient(t.A, t.B, t.C) < 0 {
+			t.B, t.C = t.C, t.B
+		}
+		if inCircle(t.A, t.B, t.C, vs[i]) > 0 {
+			c = i
+		}
+	}
+	m.fill(vs[:c+1])
+	m.fill(vs[c:])
+	m.add(Triangle{A: a, B: vs[c], C: b})
+}
+
+// onOpenSegment reports whether v lies on the segment from a to b but is
+// neither of its endpoints.
+func onOpenSegment(a, b, v Point) bool {
+	return v != a && v != b && orient(a, b, v) == 0 &&
+		(a.X < v.X) == (v.X < b.X) && (a.Y < v.Y) == (v.Y < b.Y)
+}
+
+// segmentsCross reports whether the segments ab and cd cross at a point
+// interior to both.
+func segmentsCross(a, b, c, d Point) bool {
+	o1, o2 := orient(a, b, c), orient(a, b, d)
+	o3, o4 := orient(c, d, a), orient(c, d, b)
+	return o1 != 0 && o2 != 0 && o3 != 0 && o4 != 0 && (o1 > 0) != (o2 > 0) && (o3 > 0) != (o4 > 0)
+}
@@ -0,0 +1,83 @@
+package bowyer_watson
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func TestTriangulatePolygon(t *testing.T) {
+	// A star with long thin spikes, whose edges are mostly not Delaunay.
+	r := rand.New(rand.NewSource(1))
+	var star []Point
+	for i := 0; i < 60; i++ {
+		theta := 2 * math.Pi * float64(i) / 60
+		radius := 1 + 9*float64(i%2) + r.Float64()
+		star = append(star, Point{radius * math.Cos(theta), radius * math.Sin(theta)})
+	}
+
+	tests := []struct {
+		name string
+		poly Polygon
+	}{
+		{"square", Polygon{Outer: []Point{{0, 0}, {4, 0}, {4, 4}, {0, 4}}}},
+		{"square with hole", Polygon{
+			Outer: []Point{{0, 0}, {4, 0}, {4, 4}, {0, 4}, {0, 0}},
+			Holes: [][]Point{{{1, 1}, {1, 3}, {3, 3}, {3, 1}}},
+		}},
+		{"arrow", Polygon{Outer: []Point{{0, 0}, {5, 1}, {10, 0}, {10, 10}, {5, 1.5}, {0, 10}}}},
+		{"clockwise comb", Polygon{Outer: []Point{{0, 0}, {0, 1}, {1, 10}, {2, 1}, {3, 10}, {4, 1}, {5, 10}, {6, 1}, {6, 0}}}},
+		{"star", Polygon{Outer: star}},
+		{"vertex on edge", Polygon{
+			Outer: []Point{{0, 0}, {4, 0}, {4, 4}, {0, 4}},
+			Holes: [][]Point{{{2, 0}, {3, 1}, {1, 1}}},
+		}},
+	}
+	for _, tc := range tests {
+		triangles, err := TriangulatePolygon(tc.poly)
+		if err != nil {
+			t.Errorf("%s: %v", tc.name, err)
+			continue
+		}
+		want := math.Abs(polygonArea(tc.poly.Outer))
+		for _, h := range tc.poly.Holes {
+			want -= math.Abs(polygonArea(h))
+		}
+		got := 0.0
+		edges := map[Edge]bool{}
+		for _, tri := range triangles {
+			if tri.SignedArea() <= 0 {
+				t.Errorf("%s: %v is not counter-clockwise", tc.name, tri)
+			}
+			got += tri.SignedArea()
+			for _, e := range [3]Edge{{tri.A, tri.B}, {tri.B, tri.C}, {tri.C, tri.A}} {
+				edges[e.canonical()] = true
+			}
+		}
+		if math.Abs(got-want) > 1e-9*want {
+			t.Errorf("%s: area: got %v, want %v", tc.name, got, want)
+		}
+		if tc.name == "vertex on edge" {
+			continue
+		}
+		for _, e := range tc.poly.Edges() {
+			if !edges[e.canonical()] {
+				t.Errorf("%s: edge %v is missing", tc.name, e)
+			}
+		}
+	}
+}
+
+func TestTriangulatePolygonInvalid(t *testing.T) {
+	bowtie := Polygon{Outer: []Point{{0, 0}, {2, 2}, {2, 0}, {0, 2}}}
+	if _, err := TriangulatePolygon(bowtie); err != ErrPolygonEdgesCross {
+		t.Errorf("bowtie: got error %v, want %v", err, ErrPolygonEdgesCross)
+	}
+	line := Polygon{Outer: []Point{{0, 0}, {1, 1}, {2, 2}}}
+	if _, err := TriangulatePolygon(line); err != ErrCollinearInput {
+		t.Errorf("line: got error %v, want %v", err, ErrCollinearInput)
+	}
+	if _, err := TriangulatePolygon(Polygon{}); err != ErrEmptyInput {
+		t.Errorf("empty: got error %v, want %v", err, ErrEmptyInput)
+	}
+}
@@ -0,0 +1,99 @@
+package bowyer_watson
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// ReadPointsCSV reads points from CSV data in r, taking the X and Y
+// coordinates from the zero based columns xCol and yCol of each record.
+// Other columns are ignored, and spaces around numbers are allowed. If
+// header is true the first record is skipped.
+func ReadPointsCSV(r io.Reader, xCol, yCol int, header bool) ([]Point, error) {
+	if xCol < 0 || yCol < 0 {
+		return nil, fmt.Errorf("bowyer_watson: invalid CSV columns %d, %d", xCol, yCol)
+	}
+	return readPointsCSV(r, xCol, yCol, header, false)
+}
+
+// ReadXYCSV reads points from CSV data in r whose first two columns are
+// the X and Y coordinates, as written by WritePointsCSV. A first record
+// that is not a pair of numbers, such as "x,y", is taken to be a header and
+// skipped. Spaces around numbers are allowed and other columns are ignored.
+func ReadXYCSV(r io.Reader) ([]Point, error) {
+	return readPointsCSV(r, 0, 1, false, true)
+}
+
+// readPointsCSV implements ReadPointsCSV and ReadXYCSV. If detect is true
+// the first record is skipped if its coordinates do not parse.
+func readPointsCSV(r io.Reader, xCol, yCol int, header, detect bool) ([]Point, error) {
+	cr := csv.NewReader(r)
+	cr.FieldsPerRecord = -1
+	cr.ReuseRecord = true
+
+	var points []Point
+	for {
+		record, err := cr.Read()
+		if err == io.EOF {
+			return points, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		if header {
+			header = false
+			continue
+		}
+
+		line, _ := cr.FieldPos(0)
+		p, err := parseCSVPoint(record, line, xCol, yCol)
+		if detect {
+			detect = false
+			if err != nil {
+				continue
+			}
+		}
+		if err != nil {
+			return nil, err
+		}
+		points = append(points, p)
+	}
+}
+
+// parseCSVPoint returns the point in columns xCol and yCol of record, which
+// starts on the given line.
+func parseCSVPoint(record []string, line, xCol, yCol int) (Point, error) {
+	var p Point
+	for _, c := range [2]struct {
+		col  int
+		name string
+		dst  *float64
+	}{{xCol, "x", &p.X}, {yCol, "y", &p.Y}} {
+		if c.col >= len(record) {
+			return Point{}, fmt.Errorf("bowyer_watson: line %d: missing %s column %d", line, c.name, c.col)
+		}
+		f, err := strconv.ParseFloat(strings.TrimSpace(record[c.col]), 64)
+		if err != nil {
+			return Point{}, fmt.Errorf("bowyer_watson: line %d: invalid %s %q", line, c.name, record[c.col])
+		}
+		*c.dst = f
+	}
+	return p, nil
+}
+
+// WritePointsCSV writes points to w as CSV records of X and Y. If header is
+// true the records are preceded by the header "x,y".
+func WritePointsCSV(w io.Writer, points []Point, header bool) error {
+	cw := csv.NewWriter(w)
+	if header {
+		cw.Write([]string{"x", "y"})
+	}
+	for _, p := range points {
+		cw.Write([]string{formatFloat(p.X), formatFloat(p.Y)})
+	}
+	cw.Flush()
+	return cw.Error()
+}
@@ -0,0 +1,102 @@
+package bowyer_watson
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestPointsCSV(t *testing.T) {
+	points := []Point{{0, 0}, {1.5, -2}, {1e-300, 3.141592653589793}}
+
+	for _, header := range []bool{false, true} {
+		var buf bytes.Buffer
+		if err := WritePointsCSV(&buf, points, header); err != nil {
+			t.Fatal(err)
+		}
+		want := "0,0\n1.5,-2\n1e-300,3.141592653589793\n"
+		if header {
+			want = "x,y\n" + want
+		}
+		if got := buf.String(); got != want {
+			t.Errorf("header %v: got %q, want %q", header, got, want)
+		}
+
+		got, err := ReadPointsCSV(&buf, 0, 1, header)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(got, points) {
+			t.Errorf("header %v: got %v, want %v", header, got, points)
+		}
+	}
+}
+
+func TestReadPointsCSVColumns(t *testing.T) {
+	data := `id,name,y,x
+1,"a, b",2,1
+2,c,4,3
+`
+	got, err := ReadPointsCSV(strings.NewReader(data), 3, 2, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []Point{{1, 2}, {3, 4}}; !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
+
+func TestReadXYCSV(t *testing.T) {
+	want := []Point{{1, 2}, {-3.5, 4e10}}
+	for name, data := range map[string]string{
+		"plain":      "1,2\n-3.5,4e10\n",
+		"header":     "x,y\n1,2\n-3.5,4e10\n",
+		"whitespace": " x , y \n  1 ,\t2\n\n-3.5,  4e10  \n",
+		"extra":      "lon,lat,name\n1,2,a\n-3.5,4e10,b\n",
+		"crlf":       "x,y\r\n1,2\r\n-3.5,4e10\r\n",
+	} {
+		got, err := ReadXYCSV(strings.NewReader(data))
+		if err != nil {
+			t.Errorf("%s: %v", name, err)
+			continue
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("%s: got %v, want %v", name, got, want)
+		}
+	}
+
+	// Only the first record may be a header.
+	if _, err := ReadXYCSV(strings.NewReader("x,y\nx,y\n")); err == nil {
+		t.Errorf("two headers: got no error")
+	}
+	if _, err := ReadXYCSV(strings.NewReader("1,2\n3\n")); err == nil {
+		t.Errorf("missing column: got no error")
+	}
+	got, err := ReadXYCSV(strings.NewReader(""))
+	if err != nil || len(got) != 0 {
+		t.Errorf("empty: got %v, %v, want no points", got, err)
+	}
+}
+
+func TestReadPointsCSVErrors(t *testing.T) {
+	tests := []struct {
+		name       string
+		data       string
+		xCol, yCol int
+		want       string
+	}{
+		{"header", "x,y\n1,2\n", 0, 1, `bowyer_watson: line 1: invalid x "x"`},
+		{"missing", "1,2\n3\n", 0, 1, "bowyer_watson: line 2: missing y column 1"},
+		{"invalid", "1,2\n3,four\n", 0, 1, `bowyer_watson: line 2: invalid y "four"`},
+		{"columns", "1,2\n", -1, 1, "bowyer_watson: invalid CSV columns -1, 1"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ReadPointsCSV(strings.NewReader(tt.data), tt.xCol, tt.yCol, false)
+			if err == nil || err.Error() != tt.want {
+				t.Errorf("got %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,129 @@
+package bowyer_watson
+
+import "math"
+
+// DeduplicatePoints returns the distinct elements of points in the order of
+// their first occurrence.
+func DeduplicatePoints(points []Point) []Point {
+	unique, _ := DedupPoints(points, 0)
+	return unique
+}
+
+// DeduplicatePointsEps is like DeduplicatePoints but also drops points that
+// are equal within eps, as defined by PointEqual, to an earlier point that
+// was kept.
+func DeduplicatePointsEps(points []Point, eps float64) []Point {
+	unique, _ := DedupPoints(points, eps)
+	return unique
+}
+
+// SanitizePoints returns the points with finite coordinates, in order,
+// together with the indices in points of those that were removed because
+// a coordinate was NaN or infinite. DelaunayTriangulation rejects such
+// points with ErrInvalidPoint; this drops them instead.
+func SanitizePoints(points []Point) (finite []Point, removed []int) {
+	finite = make([]Point, 0, len(points))
+	for i, p := range points {
+		if !isFinite(p) {
+			removed = append(removed, i)
+			continue
+		}
+		finite = append(finite, p)
+	}
+	return finite, removed
+}
+
+// Representative selects the point DedupPointsRep keeps for each group of
+// merged points.
+type Representative int
+
+const (
+	// FirstPoint keeps the first point of each group.
+	FirstPoint Representative = iota
+
+	// CentroidPoint replaces each group with the mean of its points.
+	CentroidPoint
+)
+
+// DedupPoints merges points that are equal within tol, as defined by
+// PointEqual, and returns the remaining points together with a mapping from
+// each index of points to the index in unique of the point that replaced
+// it, so that attributes stored alongside points can be remapped. Each
+// group is represented by its first point, see DedupPointsRep.
+func DedupPoints(points []Point, tol float64) (unique []Point, mapping []int) {
+	return DedupPointsRep(points, tol, FirstPoint)
+}
+
+// DedupPointsRep is like DedupPoints but chooses the representative of each
+// group with rep. Points are taken in order and each joins the group of the
+// first earlier representative point it is within tol of, or else starts
+// a new group. Merging is not transitive: in a chain of points each within
+// tol of the next, a point farther than tol from the start of the chain
+// starts a new group. Groups are matched against their first point even
+// when rep is CentroidPoint, so the result does not depend on how groups
+// drift as points are added. The unique points are in the order their
+// groups were started. It takes time proportional to len(points) unless
+// many points are within a few multiples of tol of each other.
+func DedupPointsRep(points []Point, tol float64, rep Representative) (unique []Point, mapping []int) {
+	mapping = make([]int, len(points))
+	unique = make([]Point, 0, len(points))
+	if tol <= 0 {
+		index := make(map[Point]int, len(points))
+		for i, p := range points {
+			k, ok := index[p]
+			if !ok {
+				k = len(unique)
+				index[p] = k
+				unique = append(unique, p)
+			}
+			mapping[i] = k
+		}
+		return unique, mapping
+	}
+
+	// Bucket the first point of each group in a grid of tol sized cells,
+	// so that only the 3x3 block of cells around a point needs to be
+	// searched.
+	type cell struct{ x, y int64 }
+	key := func(p Point) cell {
+		return cell{int64(math.Floor(p.X / tol)), int64(math.Floor(p.Y / tol))}
+	}
+	grid := map[cell][]int{}
+	var sums []Point
+	var counts []int
+outer:
+	for i, p := range points {
+		c := key(p)
+		for dx := int64(-1); dx <= 1; dx++ {
+			for dy := int64(-1); dy <= 1; dy++ {
+				for _, k := range grid[cell{c.x + dx, c.y + dy}] {
+					if PointEqual(p, unique[k], tol) {
+						mapping[i] = k
+						if rep == CentroidPoint {
+							sums[k].X += p.X
+							sums[k].Y += p.Y
+							counts[k]++
+						}
+						continue outer
+					}
+				}
+			}
+		}
+		k := len(unique)
+		grid[c] = append(grid[c], k)
+		unique = append(unique, p)
+		mapping[i] = k
+		if rep == CentroidPoint {
+			sums = append(sums, p)
+			counts = append(counts, 1)
+		}
+	}
+	if rep == CentroidPoint {
+		for k, s := range sums {
+			if counts[k] > 1 {
+				unique[k] = Point{s.X / float64(counts[k]), s.Y / float64(counts[k])}
+			}
+		}
+	}
+	return unique, mapping
+}
@@ -0,0 +1,103 @@
+package bowyer_watson
+
+import (
+	"math"
+	"reflect"
+	"testing"
+)
+
+func TestDeduplicatePoints(t *testing.T) {
+	points := []Point{{1, 2}, {0, 0}, {1, 2}, {3, 4}, {0, 0}, {1, 2}}
+	want := []Point{{1, 2}, {0, 0}, {3, 4}}
+	if got := DeduplicatePoints(points); !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+	if got := DeduplicatePoints(nil); len(got) != 0 {
+		t.Errorf("nil: got %v, want []", got)
+	}
+}
+
+func TestDeduplicatePointsEps(t *testing.T) {
+	points := []Point{
+		{0, 0},
+		{tenth + 2*tenth, 1},
+		{0.3, 1},        // equal to the previous point within eps
+		{0.0009, 0.001}, // in a neighboring cell of the first point
+		{-0.0005, 0},    // across the origin from the first point
+		{0.002, 0},
+		{5, 5},
+	}
+	want := []Point{{0, 0}, {tenth + 2*tenth, 1}, {0.002, 0}, {5, 5}}
+	if got := DeduplicatePointsEps(points, 0.001); !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+
+	if got, want := DeduplicatePointsEps(points, 0), DeduplicatePoints(points); !reflect.DeepEqual(got, want) {
+		t.Errorf("eps 0: got %v, want %v", got, want)
+	}
+}
+
+func TestDedupPoints(t *testing.T) {
+	// A cluster of coincident points, a chain of points each within tol
+	// of the next, and a point on its own.
+	points := []Point{
+		{1, 1}, {0, 0}, {1, 1}, {0.0005, 0}, {1, 1},
+		{10, 0}, {10.0008, 0}, {10.0016, 0}, {10.0024, 0},
+		{-5, 3},
+	}
+	unique, mapping := DedupPoints(points, 0.001)
+	wantUnique := []Point{{1, 1}, {0, 0}, {10, 0}, {10.0016, 0}, {-5, 3}}
+	wantMapping := []int{0, 1, 0, 1, 0, 2, 2, 3, 3, 4}
+	if !reflect.DeepEqual(unique, wantUnique) {
+		t.Errorf("unique: got %v, want %v", unique, wantUnique)
+	}
+	if !reflect.DeepEqual(mapping, wantMapping) {
+		t.Errorf("mapping: got %v, want %v", mapping, wantMapping)
+	}
+	for i, k := range mapping {
+		if !PointEqual(points[i], unique[k], 0.001) {
+			t.Errorf("point %d %v mapped to %v", i, points[i], unique[k])
+		}
+	}
+
+	unique, mapping = DedupPointsRep(points, 0.001, CentroidPoint)
+	wantUnique = []Point{{1, 1}, {0.00025, 0}, {10.0004, 0}, {10.002, 0}, {-5, 3}}
+	if len(unique) != len(wantUnique) {
+		t.Fatalf("centroid: got %v, want %v", unique, wantUnique)
+	}
+	for k := range unique {
+		if !PointEqual(unique[k], wantUnique[k], 1e-12) {
+			t.Errorf("centroid %d: got %v, want %v", k, unique[k], wantUnique[k])
+		}
+	}
+	if !reflect.DeepEqual(mapping, wantMapping) {
+		t.Errorf("centroid mapping: got %v, want %v", mapping, wantMapping)
+	}
+
+	unique, mapping = DedupPoints(points, 0)
+	if got, want := len(unique), 8; got != want {
+		t.Errorf("tol 0: #unique: got %v, want %v", got, want)
+	}
+	for i, k := range mapping {
+		if points[i] != unique[k] {
+			t.Errorf("tol 0: point %d %v mapped to %v", i, points[i], unique[k])
+		}
+	}
+}
+
+func TestSanitizePoints(t *testing.T) {
+	nan, inf := math.NaN(), math.Inf(1)
+	points := []Point{{0, 0}, {nan, 1}, {1, 2}, {3, inf}, {-inf, nan}, {math.MaxFloat64, -math.MaxFloat64}}
+	finite, removed := SanitizePoints(points)
+	if want := []Point{{0, 0}, {1, 2}, {math.MaxFloat64, -math.MaxFloat64}}; !reflect.DeepEqual(finite, want) {
+		t.Errorf("finite: got %v, want %v", finite, want)
+	}
+	if want := []int{1, 3, 4}; !reflect.DeepEqual(removed, want) {
+		t.Errorf("removed: got %v, want %v", removed, want)
+	}
+
+	finite, removed = SanitizePoints([]Point{{1, 1}, {2, 2}})
+	if len(finite) != 2 || removed != nil {
+		t.Errorf("all finite: got %v, %v", finite, removed)
+	}
+}
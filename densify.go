@@ -0,0 +1,73 @@
+package bowyer_watson
+
+import (
+	"errors"
+	"math"
+)
+
+// ErrNotConverged means Densify stopped after its limit of refinement
+// rounds with edges longer than maxLen remaining.
+var ErrNotConverged = errors.New("bowyer_watson: densification did not converge")
+
+// densifyMaxIterations bounds the number of refinement rounds performed by
+// Densify. It is a variable so that tests can lower it.
+var densifyMaxIterations = 16
+
+// Densify triangulates points and then repeatedly splits every edge of the
+// triangulation longer than maxLen by inserting evenly spaced Steiner points
+// along it, re-triangulating after each round. It stops once no edge is
+// longer than maxLen, or after a fixed number of rounds. It returns the
+// final triangulation and the Steiner points that were added. The original
+// points are not modified and remain vertices of the result. If edges
+// longer than maxLen remain after the last round, it returns that
+// triangulation and its Steiner points with ErrNotConverged. It returns the
+// error of DelaunayTriangulation, with no triangles, if points, or the
+// points with the Steiner points added, cannot be triangulated.
+func Densify(points []Point, super Triangle, maxLen float64) (triangles []Triangle, steiner []Point, err error) {
+	all := make([]Point, len(points), len(points)*2)
+	copy(all, points)
+
+	triangles, err = DelaunayTriangulation(all, super)
+	if err != nil {
+		return nil, nil, err
+	}
+	if !(maxLen > 0) {
+		return triangles, nil, nil
+	}
+
+	for i := 0; ; i++ {
+		added := len(all)
+		seen := map[Edge]bool{}
+		for _, t := range triangles {
+			for _, e := range [3]Edge{{t.A, t.B}, {t.B, t.C}, {t.C, t.A}} {
+				e = e.canonical()
+				if seen[e] {
+					continue
+				}
+				seen[e] = true
+				all = appendSplitPoints(all, e, maxLen)
+			}
+		}
+		if len(all) == added {
+			return triangles, steiner, nil
+		}
+		if i == densifyMaxIterations {
+			return triangles, steiner, ErrNotConverged
+		}
+		steiner = append(steiner, all[added:]...)
+		if triangles, err = DelaunayTriangulation(all, super); err != nil {
+			return nil, nil, err
+		}
+	}
+}
+
+// appendSplitPoints appends the points that divide e into the fewest equal
+// segments no longer than maxLen, excluding e's endpoints.
+func appendSplitPoints(points []Point, e Edge, maxLen float64) []Point {
+	dx, dy := e.B.X-e.A.X, e.B.Y-e.A.Y
+	n := math.Ceil(math.Hypot(dx, dy) / maxLen)
+	for k := 1.0; k < n; k++ {
+		points = append(points, Point{e.A.X + dx*k/n, e.A.Y + dy*k/n})
+	}
+	return points
+}
@@ -0,0 +1,118 @@
+package bowyer_watson
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+func TestDensify(t *testing.T) {
+	// Two distant clusters produce long, skinny triangles between them.
+	var points []Point
+	for i := 0; i < 10; i++ {
+		x, y := getRandomPointInCircle(1)
+		points = append(points, Point{x - 8, y}, Point{x + 8, y + 3})
+	}
+	orig := append([]Point(nil), points...)
+
+	super := Triangle{
+		A: Point{0, 100},
+		B: Point{100, -100},
+		C: Point{-100, -100},
+	}
+
+	const maxLen = 1.5
+	u, steiner, err := Densify(points, super, maxLen)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(steiner) == 0 {
+		t.Fatalf("no Steiner points added")
+	}
+	for i := range orig {
+		if points[i] != orig[i] {
+			t.Errorf("points[%d] modified: got %v, want %v", i, points[i], orig[i])
+		}
+	}
+
+	vertices := map[Point]bool{}
+	for _, tri := range u {
+		vertices[tri.A], vertices[tri.B], vertices[tri.C] = true, true, true
+		for _, e := range []Edge{{tri.A, tri.B}, {tri.B, tri.C}, {tri.C, tri.A}} {
+			if l := math.Hypot(e.A.X-e.B.X, e.A.Y-e.B.Y); l > maxLen*(1+1e-9) {
+				t.Errorf("edge %v has length %v, want <= %v", e, l, maxLen)
+			}
+		}
+	}
+	for _, p := range orig {
+		if !vertices[p] {
+			t.Errorf("original point %v is not a vertex of the result", p)
+		}
+	}
+	if got, want := len(vertices), len(orig)+len(steiner); got != want {
+		t.Errorf("#vertices: got %v, want %v", got, want)
+	}
+}
+
+func TestDensifyNoop(t *testing.T) {
+	points := []Point{{0, 0}, {1, 0}, {0, 1}}
+	super := Triangle{
+		A: Point{0, 50},
+		B: Point{50, -50},
+		C: Point{-50, -50},
+	}
+	u, steiner, err := Densify(points, super, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(steiner) != 0 {
+		t.Errorf("got Steiner points %v, want none", steiner)
+	}
+	if len(u) != 1 {
+		t.Errorf("#triangles: got %v, want 1", len(u))
+	}
+}
+
+func TestDensifyInvalid(t *testing.T) {
+	super := Triangle{
+		A: Point{0, 50},
+		B: Point{50, -50},
+		C: Point{-50, -50},
+	}
+	points := []Point{{0, 0}, {1, 0}, {0, 1}, {100, 100}}
+	if u, steiner, err := Densify(points, super, 0.5); !errors.Is(err, ErrPointOutsideSuper) || u != nil || steiner != nil {
+		t.Errorf("got %v, %v, %v, want error %v", u, steiner, err, ErrPointOutsideSuper)
+	}
+}
+
+func TestDensifyNotConverged(t *testing.T) {
+	defer func(n int) { densifyMaxIterations = n }(densifyMaxIterations)
+	densifyMaxIterations = 1
+
+	points := []Point{{0, 0}, {10, 0}, {0, 10}}
+	super := Triangle{
+		A: Point{0, 100},
+		B: Point{100, -100},
+		C: Point{-100, -100},
+	}
+	const maxLen = 0.5
+	u, steiner, err := Densify(points, super, maxLen)
+	if err != ErrNotConverged {
+		t.Fatalf("got error %v, want %v", err, ErrNotConverged)
+	}
+	if len(u) == 0 || len(steiner) == 0 {
+		t.Fatalf("got %d triangles and %d Steiner points, want the partial result", len(u), len(steiner))
+	}
+	long := false
+	for _, tri := range u {
+		for _, e := range []Edge{{tri.A, tri.B}, {tri.B, tri.C}, {tri.C, tri.A}} {
+			if math.Hypot(e.A.X-e.B.X, e.A.Y-e.B.Y) > maxLen {
+				long = true
+			}
+		}
+	}
+	if !long {
+		t.Errorf("no edge is longer than %v", maxLen)
+	}
+}
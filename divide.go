@@ -0,0 +1,259 @@
+package bowyer_watson
+
+import "sort"
+
+// DivideAndConquer returns the triangles in the Delaunay triangulation of
+// points, computed with the Guibas-Stolfi divide and conquer algorithm.
+// Like SweepHullTriangulation it needs no super triangle and its result
+// covers the convex hull of points. Duplicate points are ignored and nil is
+// returned if any point has a NaN or infinite coordinate, there are fewer
+// than three distinct points or they are all collinear. The returned
+// triangles are counter-clockwise.
+//
+// The points are sorted by X, then Y, split in half recursively, and the
+// triangulations of the halves are merged bottom to top. Source for
+// algorithm: Guibas, L. and Stolfi, J., "Primitives for the manipulation of
+// general subdivisions and the computation of Voronoi diagrams", ACM
+// Transactions on Graphics 4(2), 1985.
+func DivideAndConquer(points []Point) []Triangle {
+	if checkFinite(points) != nil {
+		return nil
+	}
+	pts := sortedUnique(points)
+	if len(pts) < 3 {
+		return nil
+	}
+	var s subdivision
+	s.pts = pts
+	s.delaunay(0, len(pts))
+	return s.triangles()
+}
+
+// sortedUnique returns a sorted copy of points, ordered by X, then Y, with
+// duplicates removed.
+func sortedUnique(points []Point) []Point {
+	pts := make([]Point, len(points))
+	copy(pts, points)
+	sort.Slice(pts, func(i, j int) bool {
+		return pts[i].X < pts[j].X || pts[i].X == pts[j].X && pts[i].Y < pts[j].Y
+	})
+	n := 0
+	for i, p := range pts {
+		if i == 0 || p != pts[n-1] {
+			pts[n] = p
+			n++
+		}
+	}
+	return pts[:n]
+}
+
+// A quadEdge represents an undirected edge of a subdivision and its dual.
+// Its four directed edges are the edge, its dual rotated 90 degrees
+// counter-clockwise, the edge reversed, and the dual reversed.
+type quadEdge struct {
+	e       [4]directedEdge
+	deleted bool
+}
+
+// A directedEdge is one of the four directed edges of a quadEdge.
+type directedEdge struct {
+	rot  *directedEdge // the dual edge rotated 90 degrees counter-clockwise
+	next *directedEdge // the next edge counter-clockwise around the origin
+	org  int           // index of the origin point, for primal edges
+	quad *quadEdge
+	mark bool
+}
+
+func (e *directedEdge) sym() *directedEdge    { return e.rot.rot }
+func (e *directedEdge) invRot() *directedEdge { return e.rot.rot.rot }
+func (e *directedEdge) dest() int             { return e.sym().org }
+func (e *directedEdge) onext() *directedEdge  { return e.next }
+func (e *directedEdge) oprev() *directedEdge  { return e.rot.next.rot }
+func (e *directedEdge) lnext() *directedEdge  { return e.invRot().next.rot }
+func (e *directedEdge) rprev() *directedEdge  { return e.sym().next }
+
+// subdivision is a planar subdivision built from quad edges over pts, or
+// over ints if it is set, in which case the predicates are evaluated in
+// integer arithmetic.
+type subdivision struct {
+	pts   []Point
+	ints  []PointI
+	edges []*quadEdge
+}
+
+func (s *subdivision) makeEdge(org, dest int) *directedEdge {
+	q := &quadEdge{}
+	for i := range q.e {
+		q.e[i].rot = &q.e[(i+1)%4]
+		q.e[i].quad = q
+	}
+	q.e[0].next = &q.e[0]
+	q.e[1].next = &q.e[3]
+	q.e[2].next = &q.e[2]
+	q.e[3].next = &q.e[1]
+	q.e[0].org, q.e[2].org = org, dest
+	s.edges = append(s.edges, q)
+	return &q.e[0]
+}
+
+// splice joins or separates the origin rings of a and b, and the left face
+// rings of their duals.
+func splice(a, b *directedEdge) {
+	alpha, beta := a.next.rot, b.next.rot
+	a.next, b.next = b.next, a.next
+	alpha.next, beta.next = beta.next, alpha.next
+}
+
+// connect adds an edge from the destination of a to the origin of b, so that
+// a, the new edge and b share a left face.
+func (s *subdivision) connect(a, b *directedEdge) *directedEdge {
+	e := s.makeEdge(a.dest(), b.org)
+	splice(e, a.lnext())
+	splice(e.sym(), b)
+	return e
+}
+
+func (s *subdivision) deleteEdge(e *directedEdge) {
+	splice(e, e.oprev())
+	splice(e.sym(), e.sym().oprev())
+	e.quad.deleted = true
+}
+
+func (s *subdivision) ccw(a, b, c int) bool {
+	if s.ints != nil {
+		return orientI(s.ints[a], s.ints[b], s.ints[c]) > 0
+	}
+	return orient(s.pts[a], s.pts[b], s.pts[c]) > 0
+}
+
+func (s *subdivision) rightOf(p int, e *directedEdge) bool {
+	return s.ccw(p, e.dest(), e.org)
+}
+
+func (s *subdivision) leftOf(p int, e *directedEdge) bool {
+	return s.ccw(p, e.org, e.dest())
+}
+
+// inCircle reports whether d is inside the circle through a, b and c, which
+// must be counter-clockwise, breaking ties as EuclideanInCircle does.
+func (s *subdivision) inCircle(a, b, c, d int) bool {
+	if s.ints != nil {
+		return inCirclePerturbedI(s.ints[a], s.ints[b], s.ints[c], s.ints[d])
+	}
+	return inCirclePerturbed(s.pts[a], s.pts[b], s.pts[c], s.pts[d])
+}
+
+// delaunay triangulates pts[lo:hi], which must hold at least two points. It
+// returns the counter-clockwise convex hull edge out of the leftmost point
+// and the clockwise convex hull edge out of the rightmost point.
+func (s *subdivision) delaunay(lo, hi int) (ldo, rdo *directedEdge) {
+	switch hi - lo {
+	case 2:
+		a := s.makeEdge(lo, lo+1)
+		return a, a.sym()
+	case 3:
+		a := s.makeEdge(lo, lo+1)
+		b := s.makeEdge(lo+1, lo+2)
+		splice(a.sym(), b)
+		switch {
+		case s.ccw(lo, lo+1, lo+2):
+			s.connect(b, a)
+			return a, b.sym()
+		case s.ccw(lo, lo+2, lo+1):
+			c := s.connect(b, a)
+			return c.sym(), c
+		default:
+			return a, b.sym()
+		}
+	}
+
+	mid := (lo + hi) / 2
+	ldo, ldi := s.delaunay(lo, mid)
+	rdi, rdo := s.delaunay(mid, hi)
+	return s.merge(ldo, ldi, rdi, rdo)
+}
+
+// merge joins the triangulations of two point sets separated by a vertical
+// line, given the hull edges returned by delaunay for each.
+func (s *subdivision) merge(ldo, ldi, rdi, rdo *directedEdge) (*directedEdge, *directedEdge) {
+	// Find the lower common tangent of the two hulls.
+	for {
+		if s.leftOf(rdi.org, ldi) {
+			ldi = ldi.lnext()
+		} else if s.rightOf(ldi.org, rdi) {
+			rdi = rdi.rprev()
+		} else {
+			break
+		}
+	}
+
+	basel := s.connect(rdi.sym(), ldi)
+	if ldi.org == ldo.org {
+		ldo = basel.sym()
+	}
+	if rdi.org == rdo.org {
+		rdo = basel
+	}
+
+	// Zip the halves together from the bottom up, deleting edges that fail
+	// the Delaunay condition as the new cross edges are added.
+	valid := func(e *directedEdge) bool { return s.rightOf(e.dest(), basel) }
+	for {
+		lcand := basel.sym().onext()
+		if valid(lcand) {
+			for s.inCircle(basel.dest(), basel.org, lcand.dest(), lcand.onext().dest()) {
+				t := lcand.onext()
+				s.deleteEdge(lcand)
+				lcand = t
+			}
+		}
+		rcand := basel.oprev()
+		if valid(rcand) {
+			for s.inCircle(basel.dest(), basel.org, rcand.dest(), rcand.oprev().dest()) {
+				t := rcand.oprev()
+				s.deleteEdge(rcand)
+				rcand = t
+			}
+		}
+		lvalid, rvalid := valid(lcand), valid(rcand)
+		if !lvalid && !rvalid {
+			break
+		}
+		if !lvalid || rvalid && s.inCircle(lcand.dest(), lcand.org, rcand.org, rcand.dest()) {
+			basel = s.connect(rcand, basel.sym())
+		} else {
+			basel = s.connect(basel.sym(), lcand.sym())
+		}
+	}
+	return ldo, rdo
+}
+
+// triangles returns the counter-clockwise triangular faces of s.
+func (s *subdivision) triangles() []Triangle {
+	var result []Triangle
+	s.faces(func(a, b, c int) {
+		result = append(result, Triangle{A: s.pts[a], B: s.pts[b], C: s.pts[c]})
+	})
+	return result
+}
+
+// faces calls f with the indexes of the vertices of each counter-clockwise
+// triangular face of s.
+func (s *subdivision) faces(f func(a, b, c int)) {
+	for _, q := range s.edges {
+		if q.deleted {
+			continue
+		}
+		for _, e := range [2]*directedEdge{&q.e[0], &q.e[2]} {
+			if e.mark {
+				continue
+			}
+			a, b := e.lnext(), e.lnext().lnext()
+			if b.lnext() != e || !s.ccw(e.org, a.org, b.org) {
+				continue
+			}
+			e.mark, a.mark, b.mark = true, true, true
+			f(e.org, a.org, b.org)
+		}
+	}
+}
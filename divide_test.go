@@ -0,0 +1,116 @@
+package bowyer_watson
+
+import (
+	"fmt"
+	"math"
+	"testing"
+)
+
+func TestDivideAndConquer(t *testing.T) {
+	for _, n := range []int{3, 4, 5, 10, 100, 1000} {
+		t.Run(fmt.Sprint(n), func(t *testing.T) {
+			points := make([]Point, n)
+			for i := range points {
+				x, y := getRandomPointInCircle(5)
+				points[i] = Point{x, y}
+			}
+
+			u := DivideAndConquer(points)
+			checkDelaunay(t, points, u)
+
+			// Random points are in general position, so the Delaunay
+			// triangulation is unique and must match the sweep-hull result.
+			want := map[[3]Point]bool{}
+			for _, tri := range SweepHullTriangulation(points) {
+				want[sortedVertices(tri)] = true
+			}
+			if got := len(u); got != len(want) {
+				t.Errorf("#triangles: got %v, want %v", got, len(want))
+			}
+			for _, tri := range u {
+				if !want[sortedVertices(tri)] {
+					t.Errorf("unexpected triangle %v", tri)
+				}
+			}
+
+			// Every triangle found by DelaunayTriangulation must also be in
+			// the result.
+			super := Triangle{
+				A: Point{0, 50},
+				B: Point{50, -50},
+				C: Point{-50, -50},
+			}
+			found := map[[3]Point]bool{}
+			for _, tri := range u {
+				found[sortedVertices(tri)] = true
+			}
+			dt, err := DelaunayTriangulation(points, super)
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, tri := range dt {
+				if !found[sortedVertices(tri)] {
+					t.Errorf("missing triangle %v", tri)
+				}
+			}
+		})
+	}
+}
+
+func TestDivideAndConquerDegenerate(t *testing.T) {
+	grid := make([]Point, 0, 100)
+	for i := 0; i < 10; i++ {
+		for j := 0; j < 10; j++ {
+			grid = append(grid, Point{float64(i), float64(j)})
+		}
+	}
+
+	// Columns of points sharing x-coordinates, so that the halves are split
+	// within a column and each half's base case may be vertical.
+	var columns []Point
+	for i := 0; i < 7; i++ {
+		for j := 0; j < 3; j++ {
+			columns = append(columns, Point{float64(i), float64(j*j) + float64(i)/8})
+		}
+	}
+
+	tests := []struct {
+		name   string
+		points []Point
+		want   int
+	}{
+		{"empty", nil, 0},
+		{"two", []Point{{0, 0}, {1, 1}}, 0},
+		{"collinear", []Point{{0, 0}, {1, 1}, {2, 2}, {3, 3}, {4, 4}, {5, 5}, {6, 6}}, 0},
+		{"vertical", []Point{{1, 0}, {1, 3}, {1, 1}, {1, 2}, {1, 5}}, 0},
+		{"same", []Point{{1, 1}, {1, 1}, {1, 1}}, 0},
+		{"duplicates", []Point{{0, 0}, {1, 0}, {0, 1}, {1, 0}, {0, 0}, {0, 1}}, 1},
+		{"square", []Point{{0, 0}, {1, 0}, {0, 1}, {1, 1}}, 2},
+		{"collinear hull", []Point{{0, 0}, {1, 0}, {2, 0}, {3, 0}, {1.5, 1}}, 3},
+		{"collinear halves", []Point{{0, 0}, {1, 0}, {2, 0}, {0, 2}, {1, 2}, {2, 2}}, 4},
+		{"vertical halves", []Point{{0, 0}, {0, 1}, {0, 2}, {1, 0}, {1, 1}, {1, 2}, {1, 3}}, 5},
+		{"columns", columns, 2*len(columns) - 2 - 16},
+		{"grid", grid, 162},
+		{"NaN", []Point{{0, 0}, {1, 0}, {0, 1}, {1, 1}, {math.NaN(), 0.5}, {0.3, 0.7}}, 0},
+		{"infinite", []Point{{0, 0}, {1, 0}, {0, 1}, {1, math.Inf(1)}}, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			u := DivideAndConquer(tt.points)
+			if got := len(u); got != tt.want {
+				t.Errorf("#triangles: got %v, want %v", got, tt.want)
+			}
+			if len(u) > 0 {
+				checkDelaunay(t, tt.points, u)
+			}
+		})
+	}
+}
+
+func BenchmarkDivideAndConquer1e5(b *testing.B) {
+	points := benchmarkPoints(1e5)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		DivideAndConquer(points)
+	}
+}
@@ -0,0 +1,28 @@
+package bowyer_watson
+
+// DualEdges returns the edges of the graph dual to triangles: for every edge
+// shared by two triangles it returns the segment connecting their
+// circumcenters. Edges on the boundary of the triangulation belong to a
+// single triangle and have no dual edge.
+func DualEdges(triangles []Triangle) []Edge {
+	centers := make([]Point, len(triangles))
+	for i := range triangles {
+		c, _ := triangles[i].CalcCircumCircle()
+		centers[i] = c.Center
+	}
+
+	owner := map[Edge]int{}
+	var dual []Edge
+	for i, t := range triangles {
+		for _, e := range [3]Edge{{t.A, t.B}, {t.B, t.C}, {t.C, t.A}} {
+			e = e.canonical()
+			if j, ok := owner[e]; ok {
+				dual = append(dual, Edge{centers[j], centers[i]})
+				delete(owner, e)
+				continue
+			}
+			owner[e] = i
+		}
+	}
+	return dual
+}
@@ -0,0 +1,62 @@
+package bowyer_watson
+
+import "testing"
+
+func TestDualEdges(t *testing.T) {
+	tris := []Triangle{
+		{A: Point{0, 0}, B: Point{2, 0}, C: Point{1, 1}},
+		{A: Point{0, 0}, B: Point{1, 1}, C: Point{0, 3}},
+	}
+	got := DualEdges(tris)
+	want := Edge{Point{1, 0}, Point{-0.5, 1.5}}
+	if len(got) != 1 || !got[0].isEqual(want) {
+		t.Errorf("got %v, want [%v]", got, want)
+	}
+}
+
+func TestDualEdgesCount(t *testing.T) {
+	points := make([]Point, 50)
+	for i := range points {
+		x, y := getRandomPointInCircle(5)
+		points[i] = Point{x, y}
+	}
+
+	super := Triangle{
+		A: Point{0, 50},
+		B: Point{50, -50},
+		C: Point{-50, -50},
+	}
+
+	u, err := DelaunayTriangulation(points, super)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	count := map[Edge]int{}
+	for _, tri := range u {
+		for _, e := range []Edge{{tri.A, tri.B}, {tri.B, tri.C}, {tri.C, tri.A}} {
+			count[e.canonical()]++
+		}
+	}
+	interior := 0
+	for _, n := range count {
+		if n == 2 {
+			interior++
+		}
+	}
+
+	centers := map[Point]bool{}
+	for _, c := range Circumcircles(u) {
+		centers[c.Center] = true
+	}
+
+	dual := DualEdges(u)
+	if got, want := len(dual), interior; got != want {
+		t.Errorf("#dual edges: got %v, want %v", got, want)
+	}
+	for _, e := range dual {
+		if !centers[e.A] || !centers[e.B] {
+			t.Errorf("dual edge %v does not connect circumcenters", e)
+		}
+	}
+}
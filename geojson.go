@@ -0,0 +1,108 @@
+package bowyer_watson
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+type geoJSONFeatureCollection struct {
+	Type     string           `json:"type"`
+	Features []geoJSONFeature `json:"features"`
+}
+
+type geoJSONFeature struct {
+	Type       string          `json:"type"`
+	Geometry   geoJSONGeometry `json:"geometry"`
+	Properties struct{}        `json:"properties"`
+}
+
+type geoJSONGeometry struct {
+	Type        string         `json:"type"`
+	Coordinates [][][2]float64 `json:"coordinates"`
+}
+
+// geoJSONObject holds any GeoJSON object for decoding.
+type geoJSONObject struct {
+	Type       string          `json:"type"`
+	Features   []geoJSONObject `json:"features,omitempty"`
+	Geometry   *geoJSONObject  `json:"geometry,omitempty"`
+	Geometries []geoJSONObject `json:"geometries,omitempty"`
+	Coords     json.RawMessage `json:"coordinates,omitempty"`
+}
+
+// WriteGeoJSON writes triangles to w as a GeoJSON FeatureCollection with
+// one Polygon feature per triangle. As required by RFC 7946 each polygon
+// ring is closed and wound counter-clockwise.
+func WriteGeoJSON(w io.Writer, triangles []Triangle) error {
+	fc := geoJSONFeatureCollection{Type: "FeatureCollection", Features: make([]geoJSONFeature, 0, len(triangles))}
+	for _, t := range triangles {
+		a, b, c := t.A, t.B, t.C
+		if t.SignedArea() < 0 {
+			b, c = c, b
+		}
+		fc.Features = append(fc.Features, geoJSONFeature{
+			Type: "Feature",
+			Geometry: geoJSONGeometry{
+				Type:        "Polygon",
+				Coordinates: [][][2]float64{{{a.X, a.Y}, {b.X, b.Y}, {c.X, c.Y}, {a.X, a.Y}}},
+			},
+		})
+	}
+	return json.NewEncoder(w).Encode(fc)
+}
+
+// ReadPointsGeoJSON reads the Point and MultiPoint geometries from the
+// GeoJSON document in r. The document may be a FeatureCollection, a Feature,
+// a GeometryCollection or a bare geometry. Other geometry types are ignored.
+func ReadPointsGeoJSON(r io.Reader) ([]Point, error) {
+	var obj geoJSONObject
+	if err := json.NewDecoder(r).Decode(&obj); err != nil {
+		return nil, err
+	}
+	return appendGeoJSONPoints(nil, &obj)
+}
+
+func appendGeoJSONPoints(points []Point, obj *geoJSONObject) ([]Point, error) {
+	switch obj.Type {
+	case "FeatureCollection":
+		for i := range obj.Features {
+			var err error
+			if points, err = appendGeoJSONPoints(points, &obj.Features[i]); err != nil {
+				return nil, err
+			}
+		}
+	case "Feature":
+		if obj.Geometry != nil {
+			return appendGeoJSONPoints(points, obj.Geometry)
+		}
+	case "GeometryCollection":
+		for i := range obj.Geometries {
+			var err error
+			if points, err = appendGeoJSONPoints(points, &obj.Geometries[i]); err != nil {
+				return nil, err
+			}
+		}
+	case "Point":
+		var pos []float64
+		if err := json.Unmarshal(obj.Coords, &pos); err != nil {
+			return nil, fmt.Errorf("bowyer_watson: invalid Point coordinates: %v", err)
+		}
+		if len(pos) < 2 {
+			return nil, fmt.Errorf("bowyer_watson: Point has %d coordinates, want at least 2", len(pos))
+		}
+		points = append(points, Point{pos[0], pos[1]})
+	case "MultiPoint":
+		var pos [][]float64
+		if err := json.Unmarshal(obj.Coords, &pos); err != nil {
+			return nil, fmt.Errorf("bowyer_watson: invalid MultiPoint coordinates: %v", err)
+		}
+		for _, p := range pos {
+			if len(p) < 2 {
+				return nil, fmt.Errorf("bowyer_watson: MultiPoint position has %d coordinates, want at least 2", len(p))
+			}
+			points = append(points, Point{p[0], p[1]})
+		}
+	}
+	return points, nil
+}
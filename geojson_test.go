@@ -0,0 +1,112 @@
+package bowyer_watson
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestWriteGeoJSON(t *testing.T) {
+	tris := []Triangle{
+		{A: Point{0, 0}, B: Point{1, 0}, C: Point{1, 1}},    // CCW
+		{A: Point{0, 0}, B: Point{0, 1}, C: Point{1, 1}},    // CW
+		{A: Point{-2, 3}, B: Point{5, 1}, C: Point{0.5, 9}}, // CCW
+	}
+
+	var buf bytes.Buffer
+	if err := WriteGeoJSON(&buf, tris); err != nil {
+		t.Fatal(err)
+	}
+
+	var fc struct {
+		Type     string
+		Features []struct {
+			Type     string
+			Geometry struct {
+				Type        string
+				Coordinates [][][2]float64
+			}
+			Properties map[string]interface{}
+		}
+	}
+	if err := json.Unmarshal(buf.Bytes(), &fc); err != nil {
+		t.Fatal(err)
+	}
+	if fc.Type != "FeatureCollection" || len(fc.Features) != len(tris) {
+		t.Fatalf("got %s with %d features, want FeatureCollection with %d", fc.Type, len(fc.Features), len(tris))
+	}
+
+	for i, f := range fc.Features {
+		if f.Type != "Feature" || f.Geometry.Type != "Polygon" || f.Properties == nil {
+			t.Errorf("feature %d: got %+v", i, f)
+			continue
+		}
+		if len(f.Geometry.Coordinates) != 1 || len(f.Geometry.Coordinates[0]) != 4 {
+			t.Errorf("feature %d: got coordinates %v, want one ring of 4 positions", i, f.Geometry.Coordinates)
+			continue
+		}
+		ring := f.Geometry.Coordinates[0]
+		if ring[0] != ring[3] {
+			t.Errorf("feature %d: ring %v is not closed", i, ring)
+		}
+		var ps []Point
+		for _, c := range ring[:3] {
+			ps = append(ps, Point{c[0], c[1]})
+		}
+		if cross(ps[0], ps[1], ps[2]) <= 0 {
+			t.Errorf("feature %d: ring %v is not counter-clockwise", i, ring)
+		}
+		got := Triangle{A: ps[0], B: ps[1], C: ps[2]}
+		for _, p := range []Point{tris[i].A, tris[i].B, tris[i].C} {
+			if !got.HasVertex(p) {
+				t.Errorf("feature %d: ring %v missing vertex %v", i, ring, p)
+			}
+		}
+	}
+}
+
+func TestReadPointsGeoJSON(t *testing.T) {
+	want := []Point{{1, 2}, {-3.5, 4.25}, {5, 6}, {7, 8}, {9, 10}}
+
+	// Round trip the points through an encoded FeatureCollection.
+	var features []string
+	for _, p := range want[:2] {
+		features = append(features, fmt.Sprintf(`{"type":"Feature","geometry":{"type":"Point","coordinates":[%v,%v]},"properties":null}`, p.X, p.Y))
+	}
+	features = append(features,
+		`{"type":"Feature","geometry":{"type":"Polygon","coordinates":[[[0,0],[1,0],[1,1],[0,0]]]},"properties":{}}`,
+		`{"type":"Feature","geometry":{"type":"MultiPoint","coordinates":[[5,6],[7,8,100]]},"properties":{}}`,
+		`{"type":"Feature","geometry":{"type":"GeometryCollection","geometries":[{"type":"Point","coordinates":[9,10]}]},"properties":{}}`,
+	)
+	doc := `{"type":"FeatureCollection","features":[` + strings.Join(features, ",") + `]}`
+
+	got, err := ReadPointsGeoJSON(strings.NewReader(doc))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+
+	got, err = ReadPointsGeoJSON(strings.NewReader(`{"type":"Point","coordinates":[1,2]}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(got, want[:1]) {
+		t.Errorf("got %v, want %v", got, want[:1])
+	}
+
+	for _, doc := range []string{
+		`{"type":"Point","coordinates":[1]}`,
+		`{"type":"MultiPoint","coordinates":[[1,2],[3]]}`,
+		`{"type":"Point","coordinates":"x"}`,
+		`{"type":`,
+	} {
+		if _, err := ReadPointsGeoJSON(strings.NewReader(doc)); err == nil {
+			t.Errorf("%s: got nil error", doc)
+		}
+	}
+}
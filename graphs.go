@@ -0,0 +1,155 @@
+package bowyer_watson
+
+// meshEdge is an edge of a triangulation together with the vertices
+// opposite it in the one or two triangles that share it.
+type meshEdge struct {
+	Edge
+	opposite [2]Point
+	n        int // the number of elements of opposite in use
+}
+
+// meshEdges returns the distinct edges of triangles, in canonical form, in
+// the order they first occur.
+func meshEdges(triangles []Triangle) []meshEdge {
+	index := map[Edge]int{}
+	var edges []meshEdge
+	for _, t := range triangles {
+		for _, s := range [3][3]Point{{t.A, t.B, t.C}, {t.B, t.C, t.A}, {t.C, t.A, t.B}} {
+			e := Edge{s[0], s[1]}.canonical()
+			i, ok := index[e]
+			if !ok {
+				i = len(edges)
+				index[e] = i
+				edges = append(edges, meshEdge{Edge: e})
+			}
+			if me := &edges[i]; me.n < 2 {
+				me.opposite[me.n] = s[2]
+				me.n++
+			}
+		}
+	}
+	return edges
+}
+
+// DelaunayEdges returns the distinct edges of the triangles
+// DelaunayTriangulation returns for points, super and opts, with their
+// endpoints ordered by X, then Y. Unlike DelaunayTriangulation it accepts
+// inputs with too few points or only collinear points, for which the
+// Delaunay graph is a path: one point has no edges, two distinct points
+// have a single edge, and collinear points are joined in order along their
+// line. Other errors from DelaunayTriangulation are returned.
+func DelaunayEdges(points []Point, super Triangle, opts ...Option) ([]Edge, error) {
+	triangles, err := DelaunayTriangulation(points, super, opts...)
+	switch err {
+	case nil:
+	case ErrTooFewPoints, ErrCollinearInput:
+		pts, _ := sortDedup(append([]Point(nil), points...), false)
+		var edges []Edge
+		for i := 1; i < len(pts); i++ {
+			edges = append(edges, Edge{pts[i-1], pts[i]})
+		}
+		return edges, nil
+	default:
+		return nil, err
+	}
+	var edges []Edge
+	for _, me := range meshEdges(triangles) {
+		edges = append(edges, me.Edge)
+	}
+	return edges, nil
+}
+
+// GabrielGraph returns the edges of the Gabriel graph of points: the pairs
+// of points whose diametral circle, the circle with the pair as diameter,
+// contains no other point, inside or on the circle. It is a subgraph of
+// the Delaunay triangulation, computed with DelaunayTriangulation and
+// super, in which a Delaunay edge is a Gabriel edge exactly when the
+// vertices opposite it are outside its diametral circle. Triangles lost
+// along the convex hull because super is too small can cause extra edges
+// there, see MinSuperMargin. If DelaunayTriangulation rejects points, no
+// edges are returned.
+func GabrielGraph(points []Point, super Triangle) []Edge {
+	triangles, err := DelaunayTriangulation(points, super)
+	if err != nil {
+		return nil
+	}
+	var result []Edge
+	for _, me := range meshEdges(triangles) {
+		keep := true
+		for _, c := range me.opposite[:me.n] {
+			if inDiametralCircle(me.Edge, c) {
+				keep = false
+			}
+		}
+		if keep {
+			result = append(result, me.Edge)
+		}
+	}
+	return result
+}
+
+// inDiametralCircle reports whether p lies inside or on the circle with e
+// as diameter, where e subtends a right or obtuse angle.
+func inDiametralCircle(e Edge, p Point) bool {
+	return (e.A.X-p.X)*(e.B.X-p.X)+(e.A.Y-p.Y)*(e.B.Y-p.Y) <= 0
+}
+
+// RelativeNeighborhoodGraph returns the edges of the relative neighborhood
+// graph of points: the pairs of points a and b for which no other point is
+// closer to both a and b than they are to each other. It is a subgraph of
+// the Gabriel graph, so only the Gabriel edges of the Delaunay
+// triangulation, computed with DelaunayTriangulation and super, are
+// tested. If DelaunayTriangulation rejects points, no edges are returned.
+func RelativeNeighborhoodGraph(points []Point, super Triangle) []Edge {
+	triangles, err := DelaunayTriangulation(points, super)
+	if err != nil {
+		return nil
+	}
+	edges := meshEdges(triangles)
+	neighbors := map[Point][]Point{}
+	for _, me := range edges {
+		neighbors[me.A] = append(neighbors[me.A], me.B)
+		neighbors[me.B] = append(neighbors[me.B], me.A)
+	}
+
+	// A point closer to both a and b than they are to each other lies in
+	// the disk around a with b on its boundary. From every vertex inside
+	// that disk a Delaunay neighbor is closer to a, so the whole disk can
+	// be searched by walking out from a along Delaunay edges.
+	visited := map[Point]int{}
+	var queue []Point
+	var result []Edge
+	for i, me := range edges {
+		keep := true
+		for _, c := range me.opposite[:me.n] {
+			if inDiametralCircle(me.Edge, c) {
+				keep = false
+			}
+		}
+		d := dist2(me.A, me.B)
+		visited[me.A] = i + 1
+		queue = append(queue[:0], me.A)
+		for k := 0; k < len(queue) && keep; k++ {
+			for _, r := range neighbors[queue[k]] {
+				if visited[r] == i+1 || dist2(r, me.A) >= d {
+					continue
+				}
+				if dist2(r, me.B) < d {
+					keep = false
+					break
+				}
+				visited[r] = i + 1
+				queue = append(queue, r)
+			}
+		}
+		if keep {
+			result = append(result, me.Edge)
+		}
+	}
+	return result
+}
+
+// dist2 returns the squared distance between a and b.
+func dist2(a, b Point) float64 {
+	return sqr(a.X-b.X) + sqr(a.Y-b.Y)
+}
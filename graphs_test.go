@@ -0,0 +1,122 @@
+package bowyer_watson
+
+import (
+	"math/rand"
+	"testing"
+)
+
+// bruteForceGraph returns the canonical edges between pairs of points for
+// which no other point satisfies blocks.
+func bruteForceGraph(points []Point, blocks func(e Edge, p Point) bool) map[Edge]bool {
+	edges := map[Edge]bool{}
+	for i, a := range points {
+	pairs:
+		for _, b := range points[i+1:] {
+			e := Edge{a, b}
+			for _, p := range points {
+				if p != a && p != b && blocks(e, p) {
+					continue pairs
+				}
+			}
+			edges[e.canonical()] = true
+		}
+	}
+	return edges
+}
+
+func checkGraph(t *testing.T, name string, got []Edge, want map[Edge]bool) {
+	t.Helper()
+	seen := map[Edge]bool{}
+	for _, e := range got {
+		if seen[e] {
+			t.Errorf("%s: edge %v appears twice", name, e)
+		}
+		seen[e] = true
+		if !want[e] {
+			t.Errorf("%s: unexpected edge %v", name, e)
+		}
+	}
+	for e := range want {
+		if !seen[e] {
+			t.Errorf("%s: missing edge %v", name, e)
+		}
+	}
+}
+
+func TestProximityGraphs(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	random := make([]Point, 150)
+	for i := range random {
+		random[i] = Point{r.Float64() * 10, r.Float64() * 10}
+	}
+	// A lattice with gaps has many ties and long edges between points in
+	// the same row or column.
+	var sparse []Point
+	for i := 0; i < 40; i++ {
+		sparse = append(sparse, Point{float64(r.Intn(8)), float64(r.Intn(8))})
+	}
+	sparse = DeduplicatePoints(sparse)
+	var lattice []Point
+	for x := 0; x < 6; x++ {
+		for y := 0; y < 5; y++ {
+			lattice = append(lattice, Point{float64(x), float64(y)})
+		}
+	}
+
+	for _, tt := range []struct {
+		name   string
+		points []Point
+	}{
+		{"random", random},
+		{"lattice", lattice},
+		{"sparse lattice", sparse},
+		{"triangle", []Point{{0, 0}, {4, 0}, {2, 1}}},
+	} {
+		super := SuperTriangleFor(tt.points, 100)
+		checkGraph(t, tt.name+" gabriel", GabrielGraph(tt.points, super),
+			bruteForceGraph(tt.points, inDiametralCircle))
+		checkGraph(t, tt.name+" rng", RelativeNeighborhoodGraph(tt.points, super),
+			bruteForceGraph(tt.points, func(e Edge, p Point) bool {
+				d := dist2(e.A, e.B)
+				return dist2(p, e.A) < d && dist2(p, e.B) < d
+			}))
+	}
+
+	if got := GabrielGraph(nil, SuperTriangleFor(nil, 1)); got != nil {
+		t.Errorf("no points: got %v, want nil", got)
+	}
+}
+
+func TestDelaunayEdges(t *testing.T) {
+	super := SuperTriangleFor([]Point{{0, 0}, {2, 2}}, 10)
+	tests := []struct {
+		name   string
+		points []Point
+		want   []Edge
+	}{
+		{"one", []Point{{1, 1}}, nil},
+		{"two", []Point{{1, 1}, {0, 0}}, []Edge{{Point{0, 0}, Point{1, 1}}}},
+		{"two distinct", []Point{{1, 1}, {0, 0}, {1, 1}}, []Edge{{Point{0, 0}, Point{1, 1}}}},
+		{"collinear", []Point{{2, 2}, {0, 0}, {1, 1}}, []Edge{{Point{0, 0}, Point{1, 1}}, {Point{1, 1}, Point{2, 2}}}},
+		{"triangle", []Point{{0, 0}, {1, 0}, {0, 1}}, []Edge{{Point{0, 0}, Point{1, 0}}, {Point{0, 0}, Point{0, 1}}, {Point{0, 1}, Point{1, 0}}}},
+	}
+	for _, tt := range tests {
+		got, err := DelaunayEdges(tt.points, super)
+		if err != nil {
+			t.Errorf("%s: %v", tt.name, err)
+			continue
+		}
+		want := map[Edge]bool{}
+		for _, e := range tt.want {
+			want[e] = true
+		}
+		checkGraph(t, tt.name, got, want)
+	}
+
+	if _, err := DelaunayEdges(nil, super); err != ErrEmptyInput {
+		t.Errorf("no points: got error %v, want %v", err, ErrEmptyInput)
+	}
+	if _, err := DelaunayEdges([]Point{{1e6, 0}}, super); err == nil {
+		t.Errorf("outside super: got no error")
+	}
+}
@@ -0,0 +1,145 @@
+package bowyer_watson
+
+import "math"
+
+// BoundingBox is an axis-aligned rectangle.
+type BoundingBox struct {
+	Min, Max Point
+}
+
+// PointsBounds returns the smallest BoundingBox containing points. For no
+// points it returns a box with Min at +Inf and Max at -Inf, which contains
+// nothing.
+func PointsBounds(points []Point) BoundingBox {
+	b := BoundingBox{
+		Min: Point{math.Inf(1), math.Inf(1)},
+		Max: Point{math.Inf(-1), math.Inf(-1)},
+	}
+	for _, p := range points {
+		b.Min.X, b.Min.Y = math.Min(b.Min.X, p.X), math.Min(b.Min.Y, p.Y)
+		b.Max.X, b.Max.Y = math.Max(b.Max.X, p.X), math.Max(b.Max.Y, p.Y)
+	}
+	return b
+}
+
+// Contains reports whether p lies in b, including its boundary.
+func (b BoundingBox) Contains(p Point) bool {
+	return p.X >= b.Min.X && p.X <= b.Max.X && p.Y >= b.Min.Y && p.Y <= b.Max.Y
+}
+
+// gridMaxCells is the number of cells above which GridIndex stores an item
+// in a separate list rather than in every cell it overlaps.
+const gridMaxCells = 64
+
+// GridIndex is a uniform grid over a rectangle that finds the items whose
+// bounding boxes may contain a point. Items are identified by ints. An item
+// is recorded in every cell its box overlaps, except that an item whose box
+// spans more than a few dozen cells is kept in a list that every query
+// returns, so that a few large items do not make insertion expensive. Points
+// and boxes outside the grid are clamped to its edge cells, so queries are
+// correct anywhere but only fast inside.
+type GridIndex struct {
+	bounds   BoundingBox
+	cellSize float64
+	nx, ny   int
+	cells    [][]int
+	large    []int
+	largePos map[int]int // index of each item in large
+}
+
+// NewGridIndex returns an empty index over bounds with square cells of side
+// cellSize. The number of cells is limited to about a million, and the
+// cells are made larger if necessary.
+func NewGridIndex(bounds BoundingBox, cellSize float64) *GridIndex {
+	w, h := bounds.Max.X-bounds.Min.X, bounds.Max.Y-bounds.Min.Y
+	if !(w >= 0 && h >= 0) {
+		w, h = 0, 0
+	}
+	if smallest := math.Sqrt(w * h / (1 << 20)); !(cellSize >= smallest) {
+		cellSize = smallest
+	}
+	g := &GridIndex{bounds: bounds, cellSize: cellSize, nx: 1, ny: 1, largePos: map[int]int{}}
+	if cellSize > 0 {
+		g.nx = int(math.Min(w/cellSize, 1<<20)) + 1
+		g.ny = int(math.Min(h/cellSize, 1<<20)) + 1
+	}
+	g.cells = make([][]int, g.nx*g.ny)
+	return g
+}
+
+// cell returns the column and row of the cell containing p, clamped to the
+// grid.
+func (g *GridIndex) cell(p Point) (int, int) {
+	clamp := func(v float64, n int) int {
+		if !(v > 0) {
+			return 0
+		}
+		if v >= float64(n-1) {
+			return n - 1
+		}
+		return int(v)
+	}
+	if g.cellSize == 0 {
+		return 0, 0
+	}
+	return clamp((p.X-g.bounds.Min.X)/g.cellSize, g.nx), clamp((p.Y-g.bounds.Min.Y)/g.cellSize, g.ny)
+}
+
+// span calls f for each cell box overlaps and reports true, or reports
+// false without calling f if box overlaps too many cells.
+func (g *GridIndex) span(box BoundingBox, f func(c int)) bool {
+	x0, y0 := g.cell(box.Min)
+	x1, y1 := g.cell(box.Max)
+	if (x1-x0+1)*(y1-y0+1) > gridMaxCells {
+		return false
+	}
+	for y := y0; y <= y1; y++ {
+		for x := x0; x <= x1; x++ {
+			f(y*g.nx + x)
+		}
+	}
+	return true
+}
+
+// Insert adds the item id with bounding box box to the index.
+func (g *GridIndex) Insert(id int, box BoundingBox) {
+	if !g.span(box, func(c int) { g.cells[c] = append(g.cells[c], id) }) {
+		g.largePos[id] = len(g.large)
+		g.large = append(g.large, id)
+	}
+}
+
+// Remove deletes the item id, which must have been inserted with the same
+// box, from the index.
+func (g *GridIndex) Remove(id int, box BoundingBox) {
+	if g.span(box, func(c int) { g.cells[c] = removeInt(g.cells[c], id) }) {
+		return
+	}
+	i := g.largePos[id]
+	n := len(g.large) - 1
+	g.large[i] = g.large[n]
+	g.largePos[g.large[i]] = i
+	g.large = g.large[:n]
+	delete(g.largePos, id)
+}
+
+// Query appends to dst the items whose boxes overlap the cell containing p,
+// which include every item whose box contains p, and returns the extended
+// slice. Each item appears once.
+func (g *GridIndex) Query(p Point, dst []int) []int {
+	x, y := g.cell(p)
+	dst = append(dst, g.cells[y*g.nx+x]...)
+	return append(dst, g.large...)
+}
+
+// removeInt deletes the first occurrence of v from s, not preserving order.
+func removeInt(s []int, v int) []int {
+	for i, x := range s {
+		if x == v {
+			n := len(s) - 1
+			s[i] = s[n]
+			return s[:n]
+		}
+	}
+	return s
+}
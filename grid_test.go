@@ -0,0 +1,122 @@
+package bowyer_watson
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestGridIndex(t *testing.T) {
+	bounds := BoundingBox{Min: Point{0, 0}, Max: Point{10, 10}}
+	g := NewGridIndex(bounds, 1)
+	boxes := map[int]BoundingBox{
+		0: {Min: Point{0.5, 0.5}, Max: Point{1.5, 1.5}},
+		1: {Min: Point{5, 5}, Max: Point{5.5, 5.5}},
+		2: {Min: Point{-100, -100}, Max: Point{100, 100}}, // too large for cells
+		3: {Min: Point{9.5, 9.5}, Max: Point{20, 20}},     // partly outside
+	}
+	for id := 0; id < len(boxes); id++ {
+		g.Insert(id, boxes[id])
+	}
+
+	r := rand.New(rand.NewSource(1))
+	check := func(name string) {
+		t.Helper()
+		for i := 0; i < 1000; i++ {
+			p := Point{r.Float64()*30 - 5, r.Float64()*30 - 5}
+			got := map[int]bool{}
+			for _, id := range g.Query(p, nil) {
+				if got[id] {
+					t.Errorf("%s: %v: item %d returned twice", name, p, id)
+				}
+				got[id] = true
+			}
+			for id, box := range boxes {
+				if box.Contains(p) && !got[id] {
+					t.Errorf("%s: %v: item %d is missing", name, p, id)
+				}
+			}
+		}
+	}
+	check("inserted")
+
+	g.Remove(0, boxes[0])
+	g.Remove(2, boxes[2])
+	delete(boxes, 0)
+	delete(boxes, 2)
+	check("removed")
+	for _, p := range []Point{{1, 1}, {-50, -50}} {
+		if got := g.Query(p, nil); len(got) != 0 {
+			t.Errorf("%v: got %v, want none", p, got)
+		}
+	}
+}
+
+func TestPointsBounds(t *testing.T) {
+	b := PointsBounds([]Point{{1, 5}, {-2, 3}, {4, -1}})
+	if want := (BoundingBox{Min: Point{-2, -1}, Max: Point{4, 5}}); b != want {
+		t.Errorf("got %v, want %v", b, want)
+	}
+	if b := PointsBounds(nil); b.Contains(Point{0, 0}) {
+		t.Errorf("empty bounds %v contains the origin", b)
+	}
+}
+
+func TestDelaunayTriangulationGridIndex(t *testing.T) {
+	super := Triangle{
+		A: Point{-1000, -1000},
+		B: Point{1000, -1000},
+		C: Point{0, 1000},
+	}
+	var lattice []Point
+	for i := 0; i < 30; i++ {
+		for j := 0; j < 30; j++ {
+			lattice = append(lattice, Point{float64(i), float64(j)})
+		}
+	}
+	random := make([]Point, 2000)
+	for i := range random {
+		x, y := getRandomPointInCircle(5)
+		random[i] = Point{x, y}
+	}
+	for name, points := range map[string][]Point{"lattice": lattice, "random": random} {
+		want, err := DelaunayTriangulation(points, super, WithSortedOutput())
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, cell := range []float64{0, 0.1, 100} {
+			got, err := DelaunayTriangulation(points, super, WithSortedOutput(), WithGridIndex(cell))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(got) != len(want) {
+				t.Errorf("%s, cell %v: #triangles: got %v, want %v", name, cell, len(got), len(want))
+				continue
+			}
+			for i := range want {
+				if got[i].A != want[i].A || got[i].B != want[i].B || got[i].C != want[i].C {
+					t.Errorf("%s, cell %v: triangle %d: got %v, want %v", name, cell, i, got[i], want[i])
+					break
+				}
+			}
+		}
+	}
+}
+
+func benchmarkTriangulation(b *testing.B, n int, opts ...Option) {
+	points := benchmarkPoints(n)
+	super := Triangle{
+		A: Point{-1000, -1000},
+		B: Point{1000, -1000},
+		C: Point{50, 1000},
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		DelaunayTriangulation(points, super, opts...)
+	}
+}
+
+func BenchmarkDelaunayTriangulation100K(b *testing.B) { benchmarkTriangulation(b, 100000) }
+
+func BenchmarkDelaunayTriangulationGridIndex100K(b *testing.B) {
+	benchmarkTriangulation(b, 100000, WithGridIndex(0))
+}
@@ -0,0 +1,44 @@
+package bowyer_watson
+
+import (
+	"encoding/binary"
+	"hash/fnv"
+	"math"
+)
+
+// HashTriangulation returns a fingerprint of triangles that does not depend
+// on their order or on the order of the vertices within each triangle, so
+// it is unchanged when the same triangulation is computed again by another
+// method or from permuted input. Each triangle is hashed with FNV-64a over
+// the coordinates of its vertices in sorted order, and the hashes are
+// summed. Summing, unlike XOR, keeps a repeated triangle from cancelling
+// itself. Zeros of either sign hash equally, as they compare equal. Equal
+// hashes do not guarantee equal triangulations, but a change is missed
+// only with probability about 2^-64.
+func HashTriangulation(triangles []Triangle) uint64 {
+	h := fnv.New64a()
+	var buf [48]byte
+	var sum uint64
+	for _, t := range triangles {
+		vs := [3]Point{t.A, t.B, t.C}
+		// Sort the three vertices by X, then Y.
+		if lexLess(vs[1], vs[0]) {
+			vs[0], vs[1] = vs[1], vs[0]
+		}
+		if lexLess(vs[2], vs[1]) {
+			vs[1], vs[2] = vs[2], vs[1]
+		}
+		if lexLess(vs[1], vs[0]) {
+			vs[0], vs[1] = vs[1], vs[0]
+		}
+		for i, v := range vs {
+			// Adding zero turns -0 into +0.
+			binary.LittleEndian.PutUint64(buf[16*i:], math.Float64bits(v.X+0))
+			binary.LittleEndian.PutUint64(buf[16*i+8:], math.Float64bits(v.Y+0))
+		}
+		h.Reset()
+		h.Write(buf[:])
+		sum += h.Sum64()
+	}
+	return sum
+}
@@ -0,0 +1,62 @@
+package bowyer_watson
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func TestHashTriangulation(t *testing.T) {
+	points := make([]Point, 200)
+	for i := range points {
+		x, y := getRandomPointInCircle(10)
+		points[i] = Point{x, y}
+	}
+	a, err := Triangulate(points, WithHullCompletion())
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The same point set in another order, triangulated by another method.
+	shuffled := append([]Point(nil), points...)
+	rand.Shuffle(len(shuffled), func(i, j int) { shuffled[i], shuffled[j] = shuffled[j], shuffled[i] })
+	b := DivideAndConquer(shuffled)
+	if len(a) != len(b) {
+		t.Fatalf("#triangles: got %d and %d", len(a), len(b))
+	}
+	if ha, hb := HashTriangulation(a), HashTriangulation(b); ha != hb {
+		t.Errorf("got hashes %x and %x for the same triangulation", ha, hb)
+	}
+
+	// Reordering the triangles and their vertices changes nothing.
+	c := append([]Triangle(nil), a...)
+	rand.Shuffle(len(c), func(i, j int) { c[i], c[j] = c[j], c[i] })
+	for i := range c {
+		switch i % 3 {
+		case 1:
+			c[i].A, c[i].B, c[i].C = c[i].B, c[i].C, c[i].A
+		case 2:
+			c[i].B, c[i].C = c[i].C, c[i].B
+		}
+	}
+	if HashTriangulation(c) != HashTriangulation(a) {
+		t.Error("reordering changed the hash")
+	}
+
+	// Any change to the triangles changes the hash.
+	for name, d := range map[string][]Triangle{
+		"dropped":  a[1:],
+		"repeated": append(append([]Triangle(nil), a...), a[0]),
+		"moved":    append([]Triangle{{A: a[0].A, B: a[0].B, C: Point{a[0].C.X, math.Nextafter(a[0].C.Y, 100)}}}, a[1:]...),
+	} {
+		if HashTriangulation(d) == HashTriangulation(a) {
+			t.Errorf("%s: got the same hash", name)
+		}
+	}
+
+	negZero := math.Copysign(0, -1)
+	z1 := []Triangle{{A: Point{0, 0}, B: Point{1, 0}, C: Point{0, 1}}}
+	z2 := []Triangle{{A: Point{negZero, 0}, B: Point{1, negZero}, C: Point{0, 1}}}
+	if HashTriangulation(z1) != HashTriangulation(z2) {
+		t.Error("zeros of different sign hash differently")
+	}
+}
@@ -0,0 +1,70 @@
+package bowyer_watson
+
+// completeHull appends to interior, counter-clockwise triangles from
+// DelaunayTriangulation, the triangles of the Delaunay triangulation of the
+// points that are missing from it, and returns the result. The ghosts, which
+// have a vertex of super, cover the rest of super, including the pockets
+// between interior and the convex hull.
+//
+// Every vertex of a missing triangle is a vertex of a ghost, and the
+// circumcircle of a missing triangle contains no point, so it is also a
+// triangle of the Delaunay triangulation of the ghosts' vertices. That
+// triangulation tiles the pockets with the missing triangles, so they are
+// found by walking across it from the edges on the boundary of interior,
+// without crossing an edge of interior.
+func completeHull(interior, ghosts []Triangle, super Triangle) []Triangle {
+	seen := map[Point]bool{super.A: true, super.B: true, super.C: true}
+	var vs []Point
+	for _, g := range ghosts {
+		for _, v := range [3]Point{g.A, g.B, g.C} {
+			if !seen[v] {
+				seen[v] = true
+				vs = append(vs, v)
+			}
+		}
+	}
+	dt := DivideAndConquer(vs)
+	if len(interior) == 0 {
+		return append(interior, dt...)
+	}
+
+	// Edges are directed, counter-clockwise around their triangle.
+	inner := map[Edge]bool{}
+	for _, t := range interior {
+		for _, e := range [3]Edge{{t.A, t.B}, {t.B, t.C}, {t.C, t.A}} {
+			inner[e] = true
+		}
+	}
+	owner := make(map[Edge]int, 3*len(dt))
+	for i, t := range dt {
+		for _, e := range [3]Edge{{t.A, t.B}, {t.B, t.C}, {t.C, t.A}} {
+			owner[e] = i
+		}
+	}
+
+	// A pocket lies to the right of each boundary edge of interior. visit
+	// queues the triangle across e unless it is in interior.
+	var queue []int
+	added := make([]bool, len(dt))
+	visit := func(e Edge) {
+		if i, ok := owner[Edge{e.B, e.A}]; ok && !added[i] && !inner[Edge{e.B, e.A}] {
+			added[i] = true
+			queue = append(queue, i)
+		}
+	}
+	for _, t := range interior {
+		for _, e := range [3]Edge{{t.A, t.B}, {t.B, t.C}, {t.C, t.A}} {
+			visit(e)
+		}
+	}
+	for k := 0; k < len(queue); k++ {
+		t := dt[queue[k]]
+		for _, e := range [3]Edge{{t.A, t.B}, {t.B, t.C}, {t.C, t.A}} {
+			visit(e)
+		}
+	}
+	for _, i := range queue {
+		interior = append(interior, dt[i])
+	}
+	return interior
+}
@@ -0,0 +1,58 @@
+package bowyer_watson
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+func TestWithHullCompletion(t *testing.T) {
+	super := Triangle{
+		A: Point{0, 50},
+		B: Point{50, -50},
+		C: Point{-50, -50},
+	}
+	points := []Point{{0, 49}, {-0.4, 48.5}, {0.4, 48.5}}
+	for i := 0; i < 40; i++ {
+		x, y := getRandomPointInCircle(10)
+		points = append(points, Point{x, y + 20})
+	}
+
+	_, err := DelaunayTriangulation(points, super)
+	if !errors.Is(err, ErrSuperTooSmall) {
+		t.Errorf("without completion: got error %v, want %v", err, ErrSuperTooSmall)
+	}
+
+	for name, opts := range map[string][]Option{
+		"sweep":      {WithHullCompletion()},
+		"grid":       {WithHullCompletion(), WithGridIndex(0)},
+		"normalized": {WithHullCompletion(), WithNormalization()},
+	} {
+		got, err := DelaunayTriangulation(points, super, opts...)
+		if err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		checkMesh(t, points, got)
+		area := 0.0
+		for _, tri := range got {
+			area += tri.SignedArea()
+		}
+		if want := convexHullArea(points); math.Abs(area-want) > 1e-9*want {
+			t.Errorf("%s: got area %v, want convex hull area %v", name, area, want)
+		}
+		if want := DivideAndConquer(points); len(got) != len(want) {
+			t.Errorf("%s: #triangles: got %v, want %v", name, len(got), len(want))
+		}
+	}
+
+	// A flat triangle in the corner of super, whose circumcircle contains
+	// super's vertex, so that without completion there are no triangles.
+	corner := []Point{{-0.3, 49.3}, {0.3, 49.3}, {0, 49.29}}
+	got, err := DelaunayTriangulation(corner, super, WithHullCompletion())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 1 {
+		t.Errorf("corner: got %v, want one triangle", got)
+	}
+}
@@ -0,0 +1,78 @@
+package bowyer_watson
+
+// IncidentTriangles returns the indices in triangles of the triangles that
+// have vertex as a vertex, in counter-clockwise order around it. Consecutive
+// triangles share an edge. For an interior vertex the fan is closed and
+// starts at an arbitrary triangle; for a vertex on the boundary of the
+// triangulation it starts at the triangle with a boundary edge clockwise
+// of the others. The triangles may be in either orientation.
+func IncidentTriangles(triangles []Triangle, vertex Point) []int {
+	var ts []int
+	for i, t := range triangles {
+		if t.A == vertex || t.B == vertex || t.C == vertex {
+			ts = append(ts, i)
+		}
+	}
+	return fan(triangles, ts, vertex)
+}
+
+// fan orders the indices ts of triangles incident to v counter-clockwise
+// around v, as described for IncidentTriangles. If the triangles do not
+// form a single fan, as where two parts of a triangulation meet only at v,
+// each fan is ordered in turn.
+func fan(triangles []Triangle, ts []int, v Point) []int {
+	if len(ts) < 2 {
+		return ts
+	}
+	// Seen from v, each counter-clockwise triangle spans the angle from its
+	// vertex after v to its vertex before v. The next triangle around v
+	// starts where this one ends.
+	from := make(map[Point]int, len(ts))
+	to := make(map[Point]bool, len(ts))
+	ends := make([][2]Point, len(ts))
+	for j, i := range ts {
+		b, c := spanAround(triangles[i], v)
+		ends[j] = [2]Point{b, c}
+		from[b] = j
+		to[c] = true
+	}
+
+	result := make([]int, 0, len(ts))
+	used := make([]bool, len(ts))
+	walk := func(j int) {
+		for !used[j] {
+			used[j] = true
+			result = append(result, ts[j])
+			next, ok := from[ends[j][1]]
+			if !ok {
+				return
+			}
+			j = next
+		}
+	}
+	// Start open fans at their clockwise end, then take whatever is left.
+	for j := range ts {
+		if !used[j] && !to[ends[j][0]] {
+			walk(j)
+		}
+	}
+	for j := range ts {
+		walk(j)
+	}
+	return result
+}
+
+// spanAround returns the vertices of t other than p in counter-clockwise
+// order around p, where p is a vertex of t.
+func spanAround(t Triangle, p Point) (Point, Point) {
+	vs := [3]Point{t.A, t.B, t.C}
+	if orient(t.A, t.B, t.C) < 0 {
+		vs[1], vs[2] = vs[2], vs[1]
+	}
+	for i, v := range vs {
+		if v == p {
+			return vs[(i+1)%3], vs[(i+2)%3]
+		}
+	}
+	return vs[1], vs[2]
+}
@@ -0,0 +1,77 @@
+package bowyer_watson
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestIncidentTriangles(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	points := make([]Point, 200)
+	for i := range points {
+		points[i] = Point{r.Float64()*10 - 5, r.Float64()*10 - 5}
+	}
+	triangles, err := DelaunayTriangulation(points, SuperTriangleFor(points, 10))
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Flip some triangles to check that orientation does not matter.
+	for i := 0; i < len(triangles); i += 3 {
+		triangles[i].B, triangles[i].C = triangles[i].C, triangles[i].B
+	}
+	// Vertices on the boundary of the triangulation have open fans.
+	hull := map[Point]bool{}
+	for _, me := range meshEdges(triangles) {
+		if me.n == 1 {
+			hull[me.A], hull[me.B] = true, true
+		}
+	}
+
+	for _, p := range points {
+		ts := IncidentTriangles(triangles, p)
+		n := 0
+		for _, tri := range triangles {
+			if tri.HasVertex(p) {
+				n++
+			}
+		}
+		if len(ts) != n {
+			t.Fatalf("%v: got %d triangles, want %d", p, len(ts), n)
+		}
+		// Each triangle must end, counter-clockwise around p, where the
+		// next one starts.
+		for k := range ts {
+			if k == len(ts)-1 && hull[p] {
+				break
+			}
+			_, end := spanAround(triangles[ts[k]], p)
+			start, _ := spanAround(triangles[ts[(k+1)%len(ts)]], p)
+			if end != start {
+				t.Fatalf("%v: triangles %d and %d do not follow each other", p, ts[k], ts[(k+1)%len(ts)])
+			}
+		}
+	}
+
+	if got := IncidentTriangles(triangles, Point{100, 100}); got != nil {
+		t.Errorf("not a vertex: got %v, want nil", got)
+	}
+}
+
+func TestIncidentTrianglesHull(t *testing.T) {
+	// A fan of three triangles around the corner (0, 0), listed out of order.
+	triangles := []Triangle{
+		{A: Point{0, 0}, B: Point{1, 1}, C: Point{0, 2}},
+		{A: Point{0, 0}, B: Point{2, 0}, C: Point{2, 1}},
+		{A: Point{0, 0}, B: Point{2, 1}, C: Point{1, 1}},
+	}
+	got := IncidentTriangles(triangles, Point{0, 0})
+	want := []int{1, 2, 0}
+	if len(got) != len(want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+	for i := range got {
+		if got[i] != want[i] {
+			t.Fatalf("got %v, want %v", got, want)
+		}
+	}
+}
@@ -0,0 +1,500 @@
+package bowyer_watson
+
+import (
+	"math"
+	"sort"
+)
+
+// IncrementalTriangulation maintains the Delaunay triangulation of a set of
+// points that grows one point at a time. Each insertion only visits the
+// triangles near the new point: the one containing it is found by walking
+// across the mesh from the previous insertion, and the triangles whose
+// circumcircles contain it are found by searching outward from there. For
+// points that arrive in a spatially coherent order, or in random order,
+// this is much cheaper than triangulating from scratch after every
+// insertion.
+//
+// The zero value is not usable; create one with NewIncremental.
+type IncrementalTriangulation struct {
+	super Triangle
+	pts   []Point // the vertices of super, then the inserted points
+	tris  []itri
+	free  []int // indices of deleted elements of tris
+	last  int   // a live triangle near the last insertion
+	err   error // ErrDegenerateSuper, if super is degenerate
+
+	locator *landingGrid // built by BuildLocator, cleared by any change
+
+	// Working storage for insert, kept between insertions.
+	bad      []int
+	inCavity map[int]bool
+	boundary []boundaryEdge
+	byStart  map[int]int
+	added    []int
+}
+
+// A boundaryEdge is an edge of the cavity formed by an insertion, from u to
+// w, with n the triangle beyond it or -1.
+type boundaryEdge struct{ u, w, n int }
+
+// An itri is a triangle of an IncrementalTriangulation. Its vertices are
+// counter-clockwise.
+type itri struct {
+	v    [3]int // indices into pts
+	adj  [3]int // adj[i] shares the edge from v[i] to v[i+1], or -1
+	dead bool
+}
+
+// A landingGrid divides the bounding box of the inserted points into square
+// cells and records a live triangle near the center of each, from which a
+// walk to any point in the cell is short.
+type landingGrid struct {
+	min    Point
+	side   float64
+	nx, ny int
+	cells  []int // row by row
+}
+
+// NewIncremental returns an empty triangulation into which points inside
+// super can be inserted.
+func NewIncremental(super Triangle) *IncrementalTriangulation {
+	t := &IncrementalTriangulation{}
+	if super.IsDegenerate() {
+		t.err = ErrDegenerateSuper
+		return t
+	}
+	if orient(super.A, super.B, super.C) < 0 {
+		super.B, super.C = super.C, super.B
+	}
+	t.super = super
+	t.pts = []Point{super.A, super.B, super.C}
+	t.tris = []itri{{v: [3]int{0, 1, 2}, adj: [3]int{-1, -1, -1}}}
+	return t
+}
+
+// Insert adds p to the triangulation. It returns a PointError, with Index
+// -1, for ErrInvalidPoint if p has a NaN or infinite coordinate and for
+// ErrPointOutsideSuper unless p lies strictly inside the super triangle, and
+// ErrDegenerateSuper if the super triangle was degenerate. A point equal,
+// within Tolerance, to one already inserted is ignored.
+func (t *IncrementalTriangulation) Insert(p Point) error {
+	if err := t.check(p); err != nil {
+		return err
+	}
+	t.insert(p)
+	return nil
+}
+
+// InsertBatch is InsertAll with SkipDuplicates.
+func (t *IncrementalTriangulation) InsertBatch(points []Point) error {
+	return t.InsertAll(points, SkipDuplicates)
+}
+
+// InsertAll adds points to the triangulation, like calling Insert for each
+// of them but faster for a large batch: they are inserted sorted by X, so
+// that each walk to the next point is short, and the working storage is
+// reused. A point equal, within Tolerance, to a vertex or to another of
+// points is ignored under SkipDuplicates; under RejectDuplicates it is
+// reported as ErrDuplicatePoint. If any point would be rejected, InsertAll
+// returns its error as a PointError, with Index set to the point's index in
+// points, and inserts none of them, so the triangulation is never left
+// half updated. The order of points is not changed.
+func (t *IncrementalTriangulation) InsertAll(points []Point, mode DuplicateMode) error {
+	for i, p := range points {
+		if err := t.check(p); err != nil {
+			if pe, ok := err.(*PointError); ok {
+				pe.Index = i
+			}
+			return err
+		}
+	}
+	order := make([]int, len(points))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(i, j int) bool { return lexLess(points[order[i]], points[order[j]]) })
+	if mode == RejectDuplicates {
+		for k, i := range order {
+			p := points[i]
+			if k > 0 && PointEqual(p, points[order[k-1]], Tolerance) || t.hasVertex(p) {
+				return &PointError{Index: i, Point: p, Err: ErrDuplicatePoint}
+			}
+		}
+	}
+	for _, i := range order {
+		t.insert(points[i])
+	}
+	return nil
+}
+
+// check returns the error Insert reports for p, if any.
+func (t *IncrementalTriangulation) check(p Point) error {
+	if t.err != nil {
+		return t.err
+	}
+	if !isFinite(p) {
+		return &PointError{Index: -1, Point: p, Err: ErrInvalidPoint}
+	}
+	s := t.super
+	if orient(s.A, s.B, p) <= 0 || orient(s.B, s.C, p) <= 0 || orient(s.C, s.A, p) <= 0 {
+		return &PointError{Index: -1, Point: p, Err: ErrPointOutsideSuper}
+	}
+	return nil
+}
+
+// insert adds p, which check has accepted.
+func (t *IncrementalTriangulation) insert(p Point) {
+	bad := t.cavity(p)
+	if t.cavityHasVertex(p) {
+		return
+	}
+	inCavity := t.inCavity
+	t.locator = nil
+
+	// Join p to each edge on the boundary of the cavity.
+	pi := len(t.pts)
+	t.pts = append(t.pts, p)
+	boundary := t.boundary[:0]
+	for _, i := range bad {
+		tri := t.tris[i]
+		for j, n := range tri.adj {
+			if n < 0 || !inCavity[n] {
+				boundary = append(boundary, boundaryEdge{tri.v[j], tri.v[(j+1)%3], n})
+			}
+		}
+	}
+	t.boundary = boundary
+	for _, i := range bad {
+		t.tris[i].dead = true
+		t.free = append(t.free, i)
+	}
+
+	byStart := t.byStart
+	for k := range byStart {
+		delete(byStart, k)
+	}
+	added := t.added[:0]
+	for _, e := range boundary {
+		i := t.alloc(itri{v: [3]int{e.u, e.w, pi}, adj: [3]int{e.n, -1, -1}})
+		t.setNeighbor(e.n, e.w, i)
+		byStart[e.u] = i
+		added = append(added, i)
+	}
+	t.added = added
+	for _, i := range added {
+		// The edge from w to p is shared with the new triangle starting
+		// at w.
+		j := byStart[t.tris[i].v[1]]
+		t.tris[i].adj[1] = j
+		t.tris[j].adj[2] = i
+	}
+	t.last = added[0]
+}
+
+// cavity returns the triangles whose circumcircles contain p, which check
+// has accepted, in t.bad, and marks them in t.inCavity. They form a
+// connected region around the triangle containing p.
+func (t *IncrementalTriangulation) cavity(p Point) []int {
+	if t.inCavity == nil {
+		t.inCavity = map[int]bool{}
+		t.byStart = map[int]int{}
+	}
+	start := t.locate(p)
+	bad := append(t.bad[:0], start)
+	inCavity := t.inCavity
+	for k := range inCavity {
+		delete(inCavity, k)
+	}
+	inCavity[start] = true
+	for k := 0; k < len(bad); k++ {
+		for _, n := range t.tris[bad[k]].adj {
+			if n < 0 || inCavity[n] {
+				continue
+			}
+			v := t.tris[n].v
+			if inCirclePerturbed(t.pts[v[0]], t.pts[v[1]], t.pts[v[2]], p) {
+				inCavity[n] = true
+				bad = append(bad, n)
+			}
+		}
+	}
+	t.bad = bad
+	return bad
+}
+
+// cavityHasVertex reports whether p is equal, within Tolerance, to a vertex
+// of the triangles found by the last call to cavity.
+func (t *IncrementalTriangulation) cavityHasVertex(p Point) bool {
+	for _, i := range t.bad {
+		for _, v := range t.tris[i].v {
+			if PointEqual(t.pts[v], p, Tolerance) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// hasVertex reports whether p, which check has accepted, is equal, within
+// Tolerance, to a vertex of t.
+func (t *IncrementalTriangulation) hasVertex(p Point) bool {
+	t.cavity(p)
+	return t.cavityHasVertex(p)
+}
+
+// Remove deletes p from the triangulation and restores the Delaunay
+// property around it. It returns false if p is not, within Tolerance, a
+// vertex of the triangulation.
+//
+// The triangles incident to p form a star-shaped polygon, which is
+// retriangulated by repeatedly cutting off an ear whose circumcircle
+// contains no other vertex of the polygon. Such an ear is always a
+// triangle of the new Delaunay triangulation.
+func (t *IncrementalTriangulation) Remove(p Point) bool {
+	if t.err != nil {
+		return false
+	}
+	s := t.super
+	if orient(s.A, s.B, p) <= 0 || orient(s.B, s.C, p) <= 0 || orient(s.C, s.A, p) <= 0 {
+		return false
+	}
+	start := t.locate(p)
+	v := -1
+	for _, u := range t.tris[start].v {
+		if PointEqual(t.pts[u], p, Tolerance) {
+			v = u
+		}
+	}
+	if v < 0 {
+		return false
+	}
+	t.locator = nil
+
+	// Walk counter-clockwise around v, collecting the polygon formed by
+	// the far edges of its triangles and the triangles beyond them.
+	var poly, outer []int
+	for i := start; ; {
+		tri := &t.tris[i]
+		j := 0
+		for tri.v[j] != v {
+			j++
+		}
+		poly = append(poly, tri.v[(j+1)%3])
+		outer = append(outer, tri.adj[(j+1)%3])
+		tri.dead = true
+		t.free = append(t.free, i)
+		if i = tri.adj[(j+2)%3]; i == start {
+			break
+		}
+	}
+
+	// outer[k] is the triangle beyond the polygon edge from poly[k] to
+	// poly[k+1].
+	for len(poly) > 3 {
+		n := len(poly)
+		ear := -1
+		for k := 0; k < n && ear < 0; k++ {
+			a, b, c := poly[k], poly[(k+1)%n], poly[(k+2)%n]
+			if orient(t.pts[a], t.pts[b], t.pts[c]) <= 0 {
+				continue
+			}
+			ear = k
+			for _, q := range poly {
+				if q != a && q != b && q != c && inCirclePerturbed(t.pts[a], t.pts[b], t.pts[c], t.pts[q]) {
+					ear = -1
+					break
+				}
+			}
+		}
+		k1, k2 := (ear+1)%n, (ear+2)%n
+		i := t.alloc(itri{v: [3]int{poly[ear], poly[k1], poly[k2]}, adj: [3]int{outer[ear], outer[k1], -1}})
+		t.setNeighbor(outer[ear], poly[k1], i)
+		t.setNeighbor(outer[k1], poly[k2], i)
+		// The ear's third edge becomes a polygon edge.
+		outer[ear] = i
+		poly = append(poly[:k1], poly[k1+1:]...)
+		outer = append(outer[:k1], outer[k1+1:]...)
+	}
+	i := t.alloc(itri{v: [3]int{poly[0], poly[1], poly[2]}, adj: [3]int{outer[0], outer[1], outer[2]}})
+	for k := range poly {
+		t.setNeighbor(outer[k], poly[(k+1)%3], i)
+	}
+	t.last = i
+	return true
+}
+
+// setNeighbor records that triangle i lies beyond the edge of triangle n
+// that starts at vertex w. It does nothing if n is -1, for an edge of the
+// super triangle.
+func (t *IncrementalTriangulation) setNeighbor(n, w, i int) {
+	if n < 0 {
+		return
+	}
+	nt := &t.tris[n]
+	for j := range nt.v {
+		if nt.v[j] == w {
+			nt.adj[j] = i
+		}
+	}
+}
+
+// alloc stores tri in a free slot of t.tris and returns its index.
+func (t *IncrementalTriangulation) alloc(tri itri) int {
+	if n := len(t.free); n > 0 {
+		i := t.free[n-1]
+		t.free = t.free[:n-1]
+		t.tris[i] = tri
+		return i
+	}
+	t.tris = append(t.tris, tri)
+	return len(t.tris) - 1
+}
+
+// locate returns a triangle that contains p, which must be inside the super
+// triangle, by walking from t.last towards p.
+func (t *IncrementalTriangulation) locate(p Point) int {
+	return t.walk(t.last, p)
+}
+
+// walk returns a triangle that contains p, which must be inside the super
+// triangle, by walking from the live triangle i towards p. The walk always
+// terminates in a Delaunay triangulation. It only reads t.
+func (t *IncrementalTriangulation) walk(i int, p Point) int {
+	for {
+		tri := &t.tris[i]
+		next := -1
+		for j := range tri.v {
+			a, b := t.pts[tri.v[j]], t.pts[tri.v[(j+1)%3]]
+			if orient(a, b, p) < 0 {
+				next = tri.adj[j]
+				break
+			}
+		}
+		if next < 0 {
+			return i
+		}
+		i = next
+	}
+}
+
+// BuildLocator indexes the triangulation so that Locate starts each walk
+// near its point rather than at the last insertion, which makes a query
+// take expected constant time for points spread evenly over the
+// triangulation. It uses a uniform grid of landing triangles with about one
+// cell per vertex. Insert, InsertAll and Remove discard the locator when
+// they change the triangulation; call BuildLocator again afterwards.
+func (t *IncrementalTriangulation) BuildLocator() {
+	t.locator = nil
+	if t.err != nil || len(t.pts) == 3 {
+		return
+	}
+	b := PointsBounds(t.pts[3:])
+	w, h := b.Max.X-b.Min.X, b.Max.Y-b.Min.Y
+	n := float64(len(t.pts) - 3)
+	side := math.Sqrt(w * h / n)
+	if side == 0 {
+		side = math.Max(w, h) / n
+	}
+	if side == 0 {
+		side = 1
+	}
+	g := &landingGrid{min: b.Min, side: side, nx: int(w/side) + 1, ny: int(h/side) + 1}
+	g.cells = make([]int, g.nx*g.ny)
+	i := t.last
+	for y := 0; y < g.ny; y++ {
+		for k := 0; k < g.nx; k++ {
+			// Alternating directions keeps each walk to a neighbouring
+			// cell.
+			x := k
+			if y%2 == 1 {
+				x = g.nx - 1 - k
+			}
+			c := Point{
+				math.Min(b.Min.X+(float64(x)+0.5)*side, b.Max.X),
+				math.Min(b.Min.Y+(float64(y)+0.5)*side, b.Max.Y),
+			}
+			i = t.walk(i, c)
+			g.cells[y*g.nx+x] = i
+		}
+	}
+	t.locator = g
+}
+
+// Locate returns the triangle of Triangles that contains p, on its boundary
+// or inside it. It returns false if there is none, because p lies outside
+// the convex hull of the inserted points. Locate does not change t, so any
+// number of goroutines may call it at once, provided none calls a method
+// that does. Without BuildLocator each query walks from the last insertion.
+func (t *IncrementalTriangulation) Locate(p Point) (Triangle, bool) {
+	if t.err != nil || !isFinite(p) {
+		return Triangle{}, false
+	}
+	s := t.super
+	if orient(s.A, s.B, p) < 0 || orient(s.B, s.C, p) < 0 || orient(s.C, s.A, p) < 0 {
+		return Triangle{}, false
+	}
+	start := t.last
+	if g := t.locator; g != nil {
+		x := int(math.Max(0, math.Min(float64(g.nx-1), (p.X-g.min.X)/g.side)))
+		y := int(math.Max(0, math.Min(float64(g.ny-1), (p.Y-g.min.Y)/g.side)))
+		start = g.cells[y*g.nx+x]
+	}
+	i := t.walk(start, p)
+	if t.isSuperTriangle(i) {
+		// A point on the hull may have been reached from outside it.
+		if i = t.hullTriangle(i, p); i < 0 {
+			return Triangle{}, false
+		}
+	}
+	v := t.tris[i].v
+	return Triangle{A: t.pts[v[0]], B: t.pts[v[1]], C: t.pts[v[2]]}, true
+}
+
+// hullTriangle returns a triangle of Triangles that has p, which lies in
+// triangle i, on its boundary, or -1 if there is none. Triangle i must
+// have a vertex of the super triangle.
+func (t *IncrementalTriangulation) hullTriangle(i int, p Point) int {
+	tri := &t.tris[i]
+	for j, n := range tri.adj {
+		a, b := t.pts[tri.v[j]], t.pts[tri.v[(j+1)%3]]
+		if a == p && tri.v[j] >= 3 {
+			// Search the triangles around the vertex.
+			for k := n; k != i; {
+				if !t.isSuperTriangle(k) {
+					return k
+				}
+				nt := &t.tris[k]
+				m := 0
+				for nt.v[m] != tri.v[j] {
+					m++
+				}
+				k = nt.adj[m]
+			}
+			return -1
+		}
+		if n >= 0 && orient(a, b, p) == 0 && !t.isSuperTriangle(n) {
+			return n
+		}
+	}
+	return -1
+}
+
+// isSuperTriangle reports whether triangle i has a vertex of the super
+// triangle, so that it is not one of Triangles.
+func (t *IncrementalTriangulation) isSuperTriangle(i int) bool {
+	v := t.tris[i].v
+	return v[0] < 3 || v[1] < 3 || v[2] < 3
+}
+
+// Triangles returns the triangles of the Delaunay triangulation of the
+// points inserted so far, with their vertices in counter-clockwise order.
+func (t *IncrementalTriangulation) Triangles() []Triangle {
+	var result []Triangle
+	for _, tri := range t.tris {
+		if tri.dead || tri.v[0] < 3 || tri.v[1] < 3 || tri.v[2] < 3 {
+			continue
+		}
+		result = append(result, Triangle{A: t.pts[tri.v[0]], B: t.pts[tri.v[1]], C: t.pts[tri.v[2]]})
+	}
+	return result
+}
@@ -0,0 +1,417 @@
+package bowyer_watson
+
+import (
+	"errors"
+	"math"
+	"math/rand"
+	"sync"
+	"testing"
+)
+
+func TestIncrementalTriangulation(t *testing.T) {
+	super := Triangle{
+		A: Point{0, 50},
+		B: Point{50, -50},
+		C: Point{-50, -50},
+	}
+	it := NewIncremental(super)
+	var points []Point
+	for i := 0; i < 300; i++ {
+		x, y := getRandomPointInCircle(5)
+		p := Point{x, y}
+		if err := it.Insert(p); err != nil {
+			t.Fatal(err)
+		}
+		points = append(points, p)
+		if i%50 == 49 {
+			checkIncremental(t, points, super, it.Triangles())
+		}
+	}
+	checkIncremental(t, points, super, it.Triangles())
+
+	// Repeats are ignored.
+	if err := it.Insert(points[7]); err != nil {
+		t.Fatal(err)
+	}
+	checkIncremental(t, points, super, it.Triangles())
+
+	for _, p := range []Point{{100, 0}, super.A, {0, -50}} {
+		var pe *PointError
+		if err := it.Insert(p); !errors.As(err, &pe) || pe.Err != ErrPointOutsideSuper || pe.Point != p || pe.Index != -1 {
+			t.Errorf("Insert(%v): got error %v, want %v", p, err, ErrPointOutsideSuper)
+		}
+	}
+	for _, p := range []Point{{math.NaN(), 0}, {0, math.Inf(-1)}} {
+		var pe *PointError
+		if err := it.Insert(p); !errors.As(err, &pe) || pe.Err != ErrInvalidPoint || pe.Index != -1 {
+			t.Errorf("Insert(%v): got error %v, want %v", p, err, ErrInvalidPoint)
+		}
+	}
+}
+
+// checkIncremental reports an error unless tris has the same triangles as
+// DelaunayTriangulation finds for points and super.
+func checkIncremental(t *testing.T, points []Point, super Triangle, tris []Triangle) {
+	t.Helper()
+	want, err := DelaunayTriangulation(points, super)
+	if err != nil {
+		t.Fatal(err)
+	}
+	found := map[[3]Point]bool{}
+	for _, tri := range want {
+		found[sortedVertices(tri)] = true
+	}
+	if len(tris) != len(want) {
+		t.Errorf("%d points: #triangles: got %v, want %v", len(points), len(tris), len(want))
+	}
+	for _, tri := range tris {
+		if !found[sortedVertices(tri)] {
+			t.Errorf("%d points: unexpected triangle %v", len(points), tri)
+		}
+	}
+	checkMesh(t, points, tris)
+}
+
+func TestIncrementalTriangulationGrid(t *testing.T) {
+	// Cocircular points, inserted in an order that walks far each time.
+	var grid []Point
+	for i := 0; i < 20; i++ {
+		for j := 0; j < 20; j++ {
+			grid = append(grid, Point{float64(i), float64(j)})
+		}
+	}
+	rand.New(rand.NewSource(1)).Shuffle(len(grid), func(i, j int) { grid[i], grid[j] = grid[j], grid[i] })
+	it := NewIncremental(Triangle{A: Point{-1000, -1000}, B: Point{0, 1000}, C: Point{1000, -1000}})
+	for _, p := range grid {
+		if err := it.Insert(p); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := Validate(grid, it.Triangles()); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestIncrementalTriangulationRemove(t *testing.T) {
+	super := Triangle{
+		A: Point{0, 50},
+		B: Point{50, -50},
+		C: Point{-50, -50},
+	}
+	it := NewIncremental(super)
+	var points []Point
+	for i := 0; i < 200; i++ {
+		x, y := getRandomPointInCircle(5)
+		p := Point{x, y}
+		if err := it.Insert(p); err != nil {
+			t.Fatal(err)
+		}
+		points = append(points, p)
+	}
+
+	rand.Shuffle(len(points), func(i, j int) { points[i], points[j] = points[j], points[i] })
+	for len(points) > 100 {
+		p := points[len(points)-1]
+		points = points[:len(points)-1]
+		if !it.Remove(p) {
+			t.Fatalf("Remove(%v): got false, want true", p)
+		}
+		if it.Remove(p) {
+			t.Fatalf("second Remove(%v): got true, want false", p)
+		}
+		if len(points)%25 == 0 {
+			checkIncremental(t, points, super, it.Triangles())
+		}
+	}
+
+	for _, p := range []Point{{1e-3, 1e-3}, {100, 0}, super.A} {
+		if it.Remove(p) {
+			t.Errorf("Remove(%v): got true, want false", p)
+		}
+	}
+
+	// The triangulation can still grow after removals.
+	for i := 0; i < 50; i++ {
+		x, y := getRandomPointInCircle(5)
+		p := Point{x, y}
+		if err := it.Insert(p); err != nil {
+			t.Fatal(err)
+		}
+		points = append(points, p)
+	}
+	checkIncremental(t, points, super, it.Triangles())
+}
+
+func TestIncrementalTriangulationRemoveGrid(t *testing.T) {
+	var grid []Point
+	for i := 0; i < 10; i++ {
+		for j := 0; j < 10; j++ {
+			grid = append(grid, Point{float64(i), float64(j)})
+		}
+	}
+	super := Triangle{A: Point{-1000, -1000}, B: Point{1000, -1000}, C: Point{0, 1000}}
+	it := NewIncremental(super)
+	for _, p := range grid {
+		if err := it.Insert(p); err != nil {
+			t.Fatal(err)
+		}
+	}
+	r := rand.New(rand.NewSource(1))
+	r.Shuffle(len(grid), func(i, j int) { grid[i], grid[j] = grid[j], grid[i] })
+	for len(grid) > 50 {
+		if !it.Remove(grid[0]) {
+			t.Fatalf("Remove(%v): got false, want true", grid[0])
+		}
+		grid = grid[1:]
+	}
+	checkIncremental(t, grid, super, it.Triangles())
+}
+
+func TestIncrementalTriangulationInsertBatch(t *testing.T) {
+	super := Triangle{
+		A: Point{0, 50},
+		B: Point{50, -50},
+		C: Point{-50, -50},
+	}
+	it := NewIncremental(super)
+	var points []Point
+	for i := 0; i < 100; i++ {
+		x, y := getRandomPointInCircle(5)
+		p := Point{x, y}
+		if err := it.Insert(p); err != nil {
+			t.Fatal(err)
+		}
+		points = append(points, p)
+	}
+
+	batch := make([]Point, 500)
+	for i := range batch {
+		x, y := getRandomPointInCircle(5)
+		batch[i] = Point{x, y}
+	}
+	batch = append(batch, points[3])
+	first := batch[0]
+	if err := it.InsertBatch(batch); err != nil {
+		t.Fatal(err)
+	}
+	if batch[0] != first {
+		t.Error("InsertBatch reordered its argument")
+	}
+	points = append(points, batch[:500]...)
+	checkIncremental(t, points, super, it.Triangles())
+
+	// A batch with an invalid point is rejected whole.
+	bad := []Point{{1, 1}, {2, 2}, {100, 0}}
+	var pe *PointError
+	if err := it.InsertBatch(bad); !errors.As(err, &pe) || pe.Err != ErrPointOutsideSuper || pe.Index != 2 {
+		t.Errorf("got error %v, want %v for point 2", err, ErrPointOutsideSuper)
+	}
+	checkIncremental(t, points, super, it.Triangles())
+}
+
+func TestIncrementalTriangulationInsertAll(t *testing.T) {
+	super := Triangle{
+		A: Point{0, 50},
+		B: Point{50, -50},
+		C: Point{-50, -50},
+	}
+	it := NewIncremental(super)
+	var points []Point
+	for i := 0; i < 100; i++ {
+		x, y := getRandomPointInCircle(5)
+		points = append(points, Point{x, y})
+	}
+	if err := it.InsertAll(points, RejectDuplicates); err != nil {
+		t.Fatal(err)
+	}
+	checkIncremental(t, points, super, it.Triangles())
+
+	// Duplicates of a vertex or within the batch are reported, and nothing
+	// is inserted.
+	tests := []struct {
+		name  string
+		batch []Point
+		want  int
+	}{
+		{"vertex", []Point{{1, 1}, points[5], {2, 2}}, 1},
+		{"batch", []Point{{1, 1}, {2, 2}, {1, 1}}, 2},
+	}
+	for _, tc := range tests {
+		var pe *PointError
+		if err := it.InsertAll(tc.batch, RejectDuplicates); !errors.As(err, &pe) || pe.Err != ErrDuplicatePoint || pe.Index != tc.want {
+			t.Errorf("%s: got error %v, want %v for point %d", tc.name, err, ErrDuplicatePoint, tc.want)
+		}
+		checkIncremental(t, points, super, it.Triangles())
+	}
+	for _, tc := range tests {
+		if err := it.InsertAll(tc.batch, SkipDuplicates); err != nil {
+			t.Errorf("%s: skip: %v", tc.name, err)
+		}
+	}
+	points = append(points, Point{1, 1}, Point{2, 2})
+	checkIncremental(t, points, super, it.Triangles())
+
+	var pe *PointError
+	if err := it.InsertAll([]Point{{3, 3}, {100, 0}}, SkipDuplicates); !errors.As(err, &pe) || pe.Err != ErrPointOutsideSuper || pe.Index != 1 {
+		t.Errorf("got error %v, want %v for point 1", err, ErrPointOutsideSuper)
+	}
+	checkIncremental(t, points, super, it.Triangles())
+}
+
+func TestIncrementalTriangulationLocate(t *testing.T) {
+	super := Triangle{
+		A: Point{0, 50},
+		B: Point{50, -50},
+		C: Point{-50, -50},
+	}
+	it := NewIncremental(super)
+	var points []Point
+	for i := 0; i < 500; i++ {
+		x, y := getRandomPointInCircle(5)
+		points = append(points, Point{x, y})
+	}
+	if err := it.InsertAll(points, SkipDuplicates); err != nil {
+		t.Fatal(err)
+	}
+
+	check := func(name string) {
+		t.Helper()
+		triangles := map[[3]Point]bool{}
+		for _, tri := range it.Triangles() {
+			triangles[sortedVertices(tri)] = true
+		}
+		queries := append([]Point{{0, 6}, {40, 40}, {math.NaN(), 0}}, points[:50]...)
+		for i := 0; i < 200; i++ {
+			x, y := getRandomPointInCircle(5)
+			queries = append(queries, Point{x, y})
+		}
+		for _, q := range queries {
+			got, ok := it.Locate(q)
+			inside := false
+			for _, tri := range it.Triangles() {
+				if orient(tri.A, tri.B, q) >= 0 && orient(tri.B, tri.C, q) >= 0 && orient(tri.C, tri.A, q) >= 0 {
+					inside = true
+				}
+			}
+			if ok != inside {
+				t.Errorf("%s: Locate(%v): got %v, want %v", name, q, ok, inside)
+				continue
+			}
+			if !ok {
+				continue
+			}
+			if !triangles[sortedVertices(got)] {
+				t.Errorf("%s: Locate(%v): got %v, not a triangle of the triangulation", name, q, got)
+			}
+			if orient(got.A, got.B, q) < 0 || orient(got.B, got.C, q) < 0 || orient(got.C, got.A, q) < 0 {
+				t.Errorf("%s: Locate(%v): got %v, which does not contain it", name, q, got)
+			}
+		}
+	}
+	check("walk")
+	it.BuildLocator()
+	check("locator")
+
+	// Changes discard the locator.
+	it.Insert(Point{1, 1})
+	check("insert")
+	it.BuildLocator()
+	it.Remove(points[0])
+	check("remove")
+
+	// Concurrent queries are safe.
+	it.BuildLocator()
+	var wg sync.WaitGroup
+	for g := 0; g < 4; g++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for _, p := range points[1:] {
+				if _, ok := it.Locate(p); !ok {
+					t.Errorf("Locate(%v): got false, want true", p)
+				}
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+func TestIncrementalTriangulationDegenerateSuper(t *testing.T) {
+	it := NewIncremental(Triangle{A: Point{0, 0}, B: Point{1, 1}, C: Point{2, 2}})
+	if err := it.Insert(Point{1, 0}); err != ErrDegenerateSuper {
+		t.Errorf("got error %v, want %v", err, ErrDegenerateSuper)
+	}
+	if got := it.Triangles(); got != nil {
+		t.Errorf("got %v, want no triangles", got)
+	}
+}
+
+func BenchmarkIncrementalTriangulation(b *testing.B) {
+	points := make([]Point, 10000)
+	for i := range points {
+		x, y := getRandomPointInCircle(5)
+		points[i] = Point{x, y}
+	}
+	super := Triangle{
+		A: Point{0, 50},
+		B: Point{50, -50},
+		C: Point{-50, -50},
+	}
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		it := NewIncremental(super)
+		for _, p := range points {
+			it.Insert(p)
+		}
+	}
+}
+
+func BenchmarkIncrementalTriangulationInsertBatch(b *testing.B) {
+	points := make([]Point, 10000)
+	for i := range points {
+		x, y := getRandomPointInCircle(5)
+		points[i] = Point{x, y}
+	}
+	super := Triangle{
+		A: Point{0, 50},
+		B: Point{50, -50},
+		C: Point{-50, -50},
+	}
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		NewIncremental(super).InsertBatch(points)
+	}
+}
+
+func BenchmarkIncrementalTriangulationLocate(b *testing.B) {
+	super := Triangle{
+		A: Point{0, 50},
+		B: Point{50, -50},
+		C: Point{-50, -50},
+	}
+	it := NewIncremental(super)
+	points := make([]Point, 100000)
+	for i := range points {
+		x, y := getRandomPointInCircle(5)
+		points[i] = Point{x, y}
+	}
+	it.InsertBatch(points)
+	queries := make([]Point, 1000)
+	for i := range queries {
+		x, y := getRandomPointInCircle(5)
+		queries[i] = Point{x, y}
+	}
+
+	b.Run("walk", func(b *testing.B) {
+		for n := 0; n < b.N; n++ {
+			it.Locate(queries[n%len(queries)])
+		}
+	})
+	b.Run("locator", func(b *testing.B) {
+		it.BuildLocator()
+		b.ResetTimer()
+		for n := 0; n < b.N; n++ {
+			it.Locate(queries[n%len(queries)])
+		}
+	})
+}
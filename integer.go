@@ -0,0 +1,192 @@
+package bowyer_watson
+
+import (
+	"errors"
+	"math/bits"
+	"sort"
+)
+
+// PointI is a point with integer coordinates, such as a pixel position.
+type PointI struct {
+	X int64 `json:"x"`
+	Y int64 `json:"y"`
+}
+
+// TriangleI is a triangle whose vertices have integer coordinates.
+type TriangleI struct {
+	A, B, C PointI
+}
+
+// MaxCoordinateI is the largest magnitude of a coordinate that
+// DelaunayTriangulationI accepts. Within it the in-circle determinant fits
+// in 128 bits.
+const MaxCoordinateI = 1<<29 - 1
+
+// ErrCoordinateRange means a PointI had a coordinate of magnitude greater
+// than MaxCoordinateI. It is wrapped in a PointError naming the point,
+// converted to a Point.
+var ErrCoordinateRange = errors.New("bowyer_watson: coordinate out of range")
+
+// DelaunayTriangulationI returns the triangles in the Delaunay triangulation
+// of points, with their vertices in counter-clockwise order. The
+// orientation and in-circle predicates are evaluated in integer arithmetic,
+// so the result is exact and reproducible, and ties between cocircular
+// points are broken as EuclideanInCircle breaks them. It needs no super
+// triangle: like DivideAndConquer, which it uses, its result covers the
+// convex hull of points. Duplicate points are ignored. It returns
+// ErrEmptyInput, a PointError for ErrCoordinateRange, ErrTooFewPoints or
+// ErrCollinearInput if points cannot be triangulated.
+func DelaunayTriangulationI(points []PointI) ([]TriangleI, error) {
+	if len(points) == 0 {
+		return nil, ErrEmptyInput
+	}
+	for i, p := range points {
+		if abs64(p.X) > MaxCoordinateI || abs64(p.Y) > MaxCoordinateI {
+			return nil, &PointError{Index: i, Point: Point{float64(p.X), float64(p.Y)}, Err: ErrCoordinateRange}
+		}
+	}
+
+	pts := append([]PointI(nil), points...)
+	sort.Slice(pts, func(i, j int) bool { return lexLessI(pts[i], pts[j]) })
+	n := 0
+	for i, p := range pts {
+		if i == 0 || p != pts[n-1] {
+			pts[n] = p
+			n++
+		}
+	}
+	pts = pts[:n]
+	if len(pts) < 3 {
+		return nil, ErrTooFewPoints
+	}
+	collinear := true
+	for _, p := range pts[2:] {
+		if orientI(pts[0], pts[1], p) != 0 {
+			collinear = false
+			break
+		}
+	}
+	if collinear {
+		return nil, ErrCollinearInput
+	}
+
+	s := subdivision{ints: pts}
+	s.delaunay(0, len(pts))
+	var result []TriangleI
+	s.faces(func(a, b, c int) {
+		result = append(result, TriangleI{A: pts[a], B: pts[b], C: pts[c]})
+	})
+	return result, nil
+}
+
+func abs64(x int64) int64 {
+	if x < 0 {
+		return -x
+	}
+	return x
+}
+
+// lexLessI reports whether a comes before b in (X, Y) order.
+func lexLessI(a, b PointI) bool {
+	return a.X < b.X || a.X == b.X && a.Y < b.Y
+}
+
+// orientI is like orient for points within MaxCoordinateI, where the
+// determinant fits in an int64.
+func orientI(a, b, c PointI) int64 {
+	return (a.X-c.X)*(b.Y-c.Y) - (a.Y-c.Y)*(b.X-c.X)
+}
+
+// inCircleI returns the sign of the in-circle determinant, as inCircle
+// does, for points within MaxCoordinateI. Each lifted coordinate and each
+// 2×2 minor fits in an int64, and their products are summed in 128 bits.
+func inCircleI(a, b, c, d PointI) int {
+	adx, ady := a.X-d.X, a.Y-d.Y
+	bdx, bdy := b.X-d.X, b.Y-d.Y
+	cdx, cdy := c.X-d.X, c.Y-d.Y
+
+	alift := adx*adx + ady*ady
+	blift := bdx*bdx + bdy*bdy
+	clift := cdx*cdx + cdy*cdy
+
+	var det int128
+	det = det.add(mul128(alift, bdx*cdy-cdx*bdy))
+	det = det.add(mul128(blift, cdx*ady-adx*cdy))
+	det = det.add(mul128(clift, adx*bdy-bdx*ady))
+	return det.sign()
+}
+
+// inCirclePerturbedI is inCirclePerturbed for integer points.
+func inCirclePerturbedI(a, b, c, d PointI) bool {
+	if det := inCircleI(a, b, c, d); det != 0 {
+		return det > 0
+	}
+	if d == a || d == b || d == c {
+		return false
+	}
+	ps := [4]PointI{a, b, c, d}
+	var done [4]bool
+	for range ps {
+		k := -1
+		for i, p := range ps {
+			if !done[i] && (k < 0 || lexLessI(ps[k], p)) {
+				k = i
+			}
+		}
+		done[k] = true
+
+		var s int64
+		switch k {
+		case 0:
+			s = orientI(d, b, c)
+		case 1:
+			s = orientI(a, d, c)
+		case 2:
+			s = orientI(a, b, d)
+		case 3:
+			s = -orientI(a, b, c)
+		}
+		if s != 0 {
+			return s > 0
+		}
+	}
+	return false
+}
+
+// int128 is a signed 128-bit integer in two's complement.
+type int128 struct {
+	hi, lo uint64
+}
+
+// mul128 returns the 128-bit product of x and y.
+func mul128(x, y int64) int128 {
+	neg := x < 0 != (y < 0)
+	hi, lo := bits.Mul64(uint64(abs64(x)), uint64(abs64(y)))
+	r := int128{hi, lo}
+	if neg {
+		r = r.neg()
+	}
+	return r
+}
+
+func (x int128) add(y int128) int128 {
+	lo, carry := bits.Add64(x.lo, y.lo, 0)
+	hi, _ := bits.Add64(x.hi, y.hi, carry)
+	return int128{hi, lo}
+}
+
+func (x int128) neg() int128 {
+	lo, borrow := bits.Sub64(0, x.lo, 0)
+	hi, _ := bits.Sub64(0, x.hi, borrow)
+	return int128{hi, lo}
+}
+
+func (x int128) sign() int {
+	switch {
+	case int64(x.hi) < 0:
+		return -1
+	case x.hi == 0 && x.lo == 0:
+		return 0
+	}
+	return 1
+}
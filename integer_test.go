@@ -0,0 +1,96 @@
+package bowyer_watson
+
+import (
+	"errors"
+	"math/rand"
+	"testing"
+)
+
+func TestDelaunayTriangulationI(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	// A small pixel range gives many cocircular points.
+	var points []PointI
+	for i := 0; i < 300; i++ {
+		points = append(points, PointI{r.Int63n(40), r.Int63n(40)})
+	}
+	got, err := DelaunayTriangulationI(points)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	fs := make([]Point, len(points))
+	for i, p := range points {
+		fs[i] = Point{float64(p.X), float64(p.Y)}
+	}
+	want := DivideAndConquer(fs)
+	if len(got) != len(want) {
+		t.Fatalf("#triangles: got %v, want %v", len(got), len(want))
+	}
+	for i, tri := range got {
+		f := Triangle{
+			A: Point{float64(tri.A.X), float64(tri.A.Y)},
+			B: Point{float64(tri.B.X), float64(tri.B.Y)},
+			C: Point{float64(tri.C.X), float64(tri.C.Y)},
+		}
+		if f != want[i] {
+			t.Errorf("triangle %d: got %v, want %v", i, f, want[i])
+		}
+	}
+
+	// The input order does not matter.
+	r.Shuffle(len(points), func(i, j int) { points[i], points[j] = points[j], points[i] })
+	again, err := DelaunayTriangulationI(points)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := range got {
+		if again[i] != got[i] {
+			t.Fatalf("shuffled: triangle %d: got %v, want %v", i, again[i], got[i])
+		}
+	}
+}
+
+func TestDelaunayTriangulationIErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		points []PointI
+		want   error
+	}{
+		{"empty", nil, ErrEmptyInput},
+		{"range", []PointI{{0, 0}, {1, MaxCoordinateI + 1}, {1, 0}}, ErrCoordinateRange},
+		{"too few", []PointI{{0, 0}, {1, 1}, {0, 0}}, ErrTooFewPoints},
+		{"collinear", []PointI{{0, 0}, {2, 2}, {1, 1}, {-3, -3}}, ErrCollinearInput},
+	}
+	for _, tt := range tests {
+		_, err := DelaunayTriangulationI(tt.points)
+		if !errors.Is(err, tt.want) {
+			t.Errorf("%s: got error %v, want %v", tt.name, err, tt.want)
+		}
+	}
+	_, err := DelaunayTriangulationI([]PointI{{0, 0}, {1, 0}, {-MaxCoordinateI - 1, 1}})
+	if pe, ok := err.(*PointError); !ok || pe.Index != 2 {
+		t.Errorf("got error %v, want PointError for point 2", err)
+	}
+}
+
+func TestInCircleI(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	coord := func() int64 { return r.Int63n(2*MaxCoordinateI+1) - MaxCoordinateI }
+	for i := 0; i < 10000; i++ {
+		a, b, c, d := PointI{coord(), coord()}, PointI{coord(), coord()}, PointI{coord(), coord()}, PointI{coord(), coord()}
+		if i%2 == 0 {
+			// Cocircular: the corners of a rectangle.
+			a, b, c, d = PointI{a.X, a.Y}, PointI{b.X, a.Y}, PointI{b.X, b.Y}, PointI{a.X, b.Y}
+		}
+		fa := Point{float64(a.X), float64(a.Y)}
+		fb := Point{float64(b.X), float64(b.Y)}
+		fc := Point{float64(c.X), float64(c.Y)}
+		fd := Point{float64(d.X), float64(d.Y)}
+		if got, want := inCircleI(a, b, c, d), sign(inCircle(fa, fb, fc, fd)); got != want {
+			t.Fatalf("inCircleI(%v, %v, %v, %v): got %v, want %v", a, b, c, d, got, want)
+		}
+		if got, want := inCirclePerturbedI(a, b, c, d), inCirclePerturbed(fa, fb, fc, fd); got != want {
+			t.Fatalf("inCirclePerturbedI(%v, %v, %v, %v): got %v, want %v", a, b, c, d, got, want)
+		}
+	}
+}
@@ -0,0 +1,54 @@
+package bowyer_watson
+
+import (
+	"encoding/json"
+	"io"
+)
+
+// triangleJSON is the JSON representation of a Triangle.
+type triangleJSON struct {
+	A            Point    `json:"a"`
+	B            Point    `json:"b"`
+	C            Point    `json:"c"`
+	Circumcenter *Point   `json:"circumcenter,omitempty"`
+	Circumradius *float64 `json:"circumradius,omitempty"`
+}
+
+// MarshalJSON implements json.Marshaler. The vertices are encoded along with
+// the circumcircle, as calculated by CalcCircumCircle. Degenerate triangles
+// have no circumcircle to encode.
+func (t Triangle) MarshalJSON() ([]byte, error) {
+	c, err := t.CalcCircumCircle()
+	if err != nil {
+		return json.Marshal(triangleJSON{A: t.A, B: t.B, C: t.C})
+	}
+	return json.Marshal(triangleJSON{t.A, t.B, t.C, &c.Center, &c.Radius})
+}
+
+// UnmarshalJSON implements json.Unmarshaler. Only the vertices are decoded;
+// the circumcircle, if present, is ignored.
+func (t *Triangle) UnmarshalJSON(data []byte) error {
+	var v triangleJSON
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	*t = Triangle{A: v.A, B: v.B, C: v.C}
+	return nil
+}
+
+// LoadPoints reads a JSON array of points, as written by SavePoints, from r.
+func LoadPoints(r io.Reader) ([]Point, error) {
+	var points []Point
+	if err := json.NewDecoder(r).Decode(&points); err != nil {
+		return nil, err
+	}
+	return points, nil
+}
+
+// SavePoints writes points to w as a JSON array.
+func SavePoints(w io.Writer, points []Point) error {
+	if points == nil {
+		points = []Point{}
+	}
+	return json.NewEncoder(w).Encode(points)
+}
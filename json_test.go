@@ -0,0 +1,135 @@
+package bowyer_watson
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestTriangleJSON(t *testing.T) {
+	points := make([]Point, 20)
+	for i := range points {
+		x, y := getRandomPointInCircle(5)
+		points[i] = Point{x, y}
+	}
+
+	super := Triangle{
+		A: Point{0, 50},
+		B: Point{50, -50},
+		C: Point{-50, -50},
+	}
+
+	u, err := DelaunayTriangulation(points, super)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := json.Marshal(u)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var got []Triangle
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(got) != len(u) {
+		t.Fatalf("#triangles: got %v, want %v", len(got), len(u))
+	}
+	for i := range u {
+		if got[i] != u[i] {
+			t.Errorf("triangle %d: got %v, want %v", i, got[i], u[i])
+		}
+		for j := 0; j < 10; j++ {
+			x, y := getRandomPointInCircle(10)
+			p := Point{x, y}
+			if got, want := got[i].CircumcircleContains(p), u[i].CircumcircleContains(p); got != want {
+				t.Errorf("triangle %d contains %v: got %v, want %v", i, p, got, want)
+			}
+		}
+	}
+}
+
+func TestTriangleJSONFormat(t *testing.T) {
+	tri := Triangle{A: Point{0, 0}, B: Point{2, 0}, C: Point{0, 2}}
+	data, err := json.Marshal(tri)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `{"a":{"x":0,"y":0},"b":{"x":2,"y":0},"c":{"x":0,"y":2},"circumcenter":{"x":1,"y":1},"circumradius":1.4142135623730951}`
+	if got := string(data); got != want {
+		t.Errorf("got %s, want %s", got, want)
+	}
+
+	var back Triangle
+	if err := json.Unmarshal(data, &back); err != nil {
+		t.Fatal(err)
+	}
+	if back != tri {
+		t.Errorf("got %+v, want %+v", back, tri)
+	}
+
+	// The circumcircle is optional.
+	var old Triangle
+	if err := json.Unmarshal([]byte(`{"a":{"x":0,"y":0},"b":{"x":2,"y":0},"c":{"x":0,"y":2}}`), &old); err != nil {
+		t.Fatal(err)
+	}
+	if old != tri {
+		t.Errorf("got %+v, want %+v", old, tri)
+	}
+}
+
+func TestLoadSavePoints(t *testing.T) {
+	points := make([]Point, 20)
+	for i := range points {
+		x, y := getRandomPointInCircle(5)
+		points[i] = Point{x, y}
+	}
+
+	var buf bytes.Buffer
+	if err := SavePoints(&buf, points); err != nil {
+		t.Fatal(err)
+	}
+	got, err := LoadPoints(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(got, points) {
+		t.Errorf("got %v, want %v", got, points)
+	}
+
+	buf.Reset()
+	if err := SavePoints(&buf, nil); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := buf.String(), "[]\n"; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+
+	if _, err := LoadPoints(strings.NewReader(`[{"x":1,"y":"2"}]`)); err == nil {
+		t.Error("got nil error for invalid input")
+	}
+}
+
+func TestTriangleJSONDegenerate(t *testing.T) {
+	tri := Triangle{A: Point{0, 0}, B: Point{1, 1}, C: Point{2, 2}}
+	data, err := json.Marshal(tri)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `{"a":{"x":0,"y":0},"b":{"x":1,"y":1},"c":{"x":2,"y":2}}`
+	if got := string(data); got != want {
+		t.Errorf("got %s, want %s", got, want)
+	}
+
+	var back Triangle
+	if err := json.Unmarshal(data, &back); err != nil {
+		t.Fatal(err)
+	}
+	if !back.IsDegenerate() {
+		t.Errorf("got %+v, want degenerate", back)
+	}
+}
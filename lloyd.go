@@ -0,0 +1,109 @@
+package bowyer_watson
+
+import "math"
+
+// LloydRelaxation moves each point to the centroid of its Voronoi cell,
+// re-triangulating between each of iterations passes, and returns the
+// relaxed points in the same order as points. The cells are clipped to the
+// convex hull of points, so the relaxed points never leave it. If pinHull
+// is true the vertices of the hull are clamped in place, and so are any
+// points on the boundary of the triangulation whose cells cannot be
+// formed; otherwise every point moves and the hull shrinks. Repeated
+// passes spread the points evenly, like blue noise. All elements of points
+// must lie inside super, as for DelaunayTriangulation, which returns an
+// error, with no points, if they cannot be triangulated. The points are
+// returned unchanged if iterations is not positive.
+func LloydRelaxation(points []Point, iterations int, super Triangle, pinHull bool) ([]Point, error) {
+	pts := make([]Point, len(points))
+	copy(pts, points)
+	if iterations <= 0 {
+		return pts, nil
+	}
+
+	hull := convexHull(pts)
+	pinned := map[Point]bool{}
+	if pinHull {
+		for _, p := range hull {
+			pinned[p] = true
+		}
+	}
+	b := PointsBounds(hull)
+	diameter := math.Hypot(b.Max.X-b.Min.X, b.Max.Y-b.Min.Y)
+
+	for it := 0; it < iterations; it++ {
+		triangles, err := DelaunayTriangulation(pts, super)
+		if err != nil {
+			return nil, err
+		}
+
+		v := newVoronoiCells(triangles)
+		for i, p := range pts {
+			ts := v.incident[p]
+			if pinned[p] || pinHull && v.open[p] || len(ts) == 0 || !v.open[p] && len(ts) < 3 {
+				continue
+			}
+			if c, ok := centroid(clipPolygon(v.cell(p, diameter), hull)); ok {
+				pts[i] = c
+			}
+		}
+	}
+	return pts, nil
+}
+
+// ray returns the point at distance 2*diameter from p in the direction
+// (dx, dy).
+func ray(p Point, dx, dy, diameter float64) Point {
+	d := 2 * diameter / math.Hypot(dx, dy)
+	return Point{p.X + dx*d, p.Y + dy*d}
+}
+
+// centroid returns the centroid of the counter-clockwise polygon poly. It
+// returns false if the polygon has no area.
+func centroid(poly []Point) (Point, bool) {
+	if len(poly) < 3 {
+		return Point{}, false
+	}
+	// Work relative to the first vertex to limit cancellation.
+	o := poly[0]
+	var area, cx, cy float64
+	for i, a := range poly {
+		b := poly[(i+1)%len(poly)]
+		ax, ay, bx, by := a.X-o.X, a.Y-o.Y, b.X-o.X, b.Y-o.Y
+		f := ax*by - bx*ay
+		area += f
+		cx += (ax + bx) * f
+		cy += (ay + by) * f
+	}
+	if area <= 0 {
+		return Point{}, false
+	}
+	return Point{o.X + cx/(3*area), o.Y + cy/(3*area)}, true
+}
+
+// convexHull returns the vertices of the convex hull of points in
+// counter-clockwise order, starting with the leftmost, lowest point. Points
+// in the interior of hull edges are omitted. Turns are decided exactly, by
+// orient, so the hull agrees with the triangulations.
+func convexHull(points []Point) []Point {
+	ps := sortedUnique(points)
+	if len(ps) < 3 {
+		return ps
+	}
+	// Andrew's monotone chain: build the lower hull left to right and the
+	// upper hull right to left.
+	hull := make([]Point, 0, 2*len(ps))
+	for pass := 0; pass < 2; pass++ {
+		start := len(hull)
+		for _, p := range ps {
+			for len(hull) >= start+2 && orient(hull[len(hull)-2], hull[len(hull)-1], p) <= 0 {
+				hull = hull[:len(hull)-1]
+			}
+			hull = append(hull, p)
+		}
+		hull = hull[:len(hull)-1]
+		for i, j := 0, len(ps)-1; i < j; i, j = i+1, j-1 {
+			ps[i], ps[j] = ps[j], ps[i]
+		}
+	}
+	return hull
+}
@@ -0,0 +1,181 @@
+package bowyer_watson
+
+import (
+	"errors"
+	"math"
+	"math/rand"
+	"testing"
+)
+
+// minDistance returns the smallest distance between two elements of points.
+func minDistance(points []Point) float64 {
+	min := math.Inf(1)
+	for i, a := range points {
+		for _, b := range points[i+1:] {
+			min = math.Min(min, math.Hypot(a.X-b.X, a.Y-b.Y))
+		}
+	}
+	return min
+}
+
+func TestLloydRelaxation(t *testing.T) {
+	super := Triangle{
+		A: Point{0, 50},
+		B: Point{50, -50},
+		C: Point{-50, -50},
+	}
+	r := rand.New(rand.NewSource(1))
+	points := make([]Point, 200)
+	for i := range points {
+		points[i] = Point{r.Float64()*10 - 5, r.Float64()*10 - 5}
+	}
+
+	relaxed, err := LloydRelaxation(points, 10, super, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := len(relaxed), len(points); got != want {
+		t.Fatalf("#points: got %v, want %v", got, want)
+	}
+
+	hull := convexHull(points)
+	pinned := map[Point]bool{}
+	for _, p := range hull {
+		pinned[p] = true
+	}
+	moved := 0
+	for i, p := range points {
+		if pinned[p] && relaxed[i] != p {
+			t.Errorf("hull point %v moved to %v", p, relaxed[i])
+		}
+		for j := range hull {
+			if cross(hull[j], hull[(j+1)%len(hull)], relaxed[i]) < 0 {
+				t.Errorf("point %v moved outside the hull to %v", p, relaxed[i])
+				break
+			}
+		}
+		if relaxed[i] != p {
+			moved++
+		}
+	}
+	if moved == 0 {
+		t.Error("no points moved")
+	}
+
+	if before, after := minDistance(points), minDistance(relaxed); after <= before {
+		t.Errorf("minimum spacing: got %v, want more than %v", after, before)
+	}
+}
+
+func TestLloydRelaxationGrid(t *testing.T) {
+	super := Triangle{
+		A: Point{0, 50},
+		B: Point{50, -50},
+		C: Point{-50, -50},
+	}
+	// Each interior point of a square grid is already at the centroid of its
+	// square Voronoi cell.
+	var grid []Point
+	for i := -3; i <= 3; i++ {
+		for j := -3; j <= 3; j++ {
+			grid = append(grid, Point{float64(i), float64(j)})
+		}
+	}
+
+	relaxed, err := LloydRelaxation(grid, 3, super, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, p := range grid {
+		if !PointEqual(relaxed[i], p, 1e-9) {
+			t.Errorf("point %v moved to %v", p, relaxed[i])
+		}
+	}
+
+	if got, err := LloydRelaxation(grid, 0, super, true); err != nil || &got[0] == &grid[0] {
+		t.Errorf("result aliases input or error %v", err)
+	}
+}
+
+func TestLloydRelaxationInvalid(t *testing.T) {
+	super := Triangle{
+		A: Point{0, 50},
+		B: Point{50, -50},
+		C: Point{-50, -50},
+	}
+	for _, tc := range []struct {
+		name   string
+		points []Point
+		want   error
+	}{
+		{"outside", []Point{{0, 0}, {1, 0}, {0, 1}, {100, 100}}, ErrPointOutsideSuper},
+		{"collinear", []Point{{0, 0}, {1, 1}, {2, 2}}, ErrCollinearInput},
+		{"too few", []Point{{0, 0}, {1, 1}}, ErrTooFewPoints},
+	} {
+		got, err := LloydRelaxation(tc.points, 3, super, true)
+		if !errors.Is(err, tc.want) || got != nil {
+			t.Errorf("%s: got %v, %v, want error %v", tc.name, got, err, tc.want)
+		}
+	}
+}
+
+func TestLloydRelaxationUnpinned(t *testing.T) {
+	super := Triangle{
+		A: Point{0, 50},
+		B: Point{50, -50},
+		C: Point{-50, -50},
+	}
+	// A boundary point of a square grid moves a quarter of the spacing
+	// inward, to the centroid of its half or quarter cell.
+	var grid []Point
+	for i := -3; i <= 3; i++ {
+		for j := -3; j <= 3; j++ {
+			grid = append(grid, Point{float64(i), float64(j)})
+		}
+	}
+	relaxed, err := LloydRelaxation(grid, 1, super, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, p := range grid {
+		want := p
+		for _, c := range []*float64{&want.X, &want.Y} {
+			if *c == 3 {
+				*c = 2.75
+			} else if *c == -3 {
+				*c = -2.75
+			}
+		}
+		if !PointEqual(relaxed[i], want, 1e-9) {
+			t.Errorf("point %v moved to %v, want %v", p, relaxed[i], want)
+		}
+	}
+
+	r := rand.New(rand.NewSource(1))
+	points := make([]Point, 200)
+	for i := range points {
+		points[i] = Point{r.Float64()*10 - 5, r.Float64()*10 - 5}
+	}
+	if relaxed, err = LloydRelaxation(points, 10, super, false); err != nil {
+		t.Fatal(err)
+	}
+	hull := convexHull(points)
+	for _, p := range hull {
+		for i := range points {
+			if points[i] == p && relaxed[i] == p {
+				t.Errorf("hull point %v did not move", p)
+			}
+		}
+	}
+	for i, p := range relaxed {
+		for j := range hull {
+			if cross(hull[j], hull[(j+1)%len(hull)], p) < 0 {
+				t.Errorf("point %v moved outside the hull to %v", points[i], p)
+				break
+			}
+		}
+	}
+	if before, after := minDistance(points), minDistance(relaxed); after <= before {
+		t.Errorf("minimum spacing: got %v, want more than %v", after, before)
+	}
+}
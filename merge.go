@@ -0,0 +1,184 @@
+package bowyer_watson
+
+import (
+	"errors"
+	"math"
+	"sort"
+)
+
+// ErrOverlappingTriangulations means the triangulations passed to Merge
+// cover overlapping regions.
+var ErrOverlappingTriangulations = errors.New("bowyer_watson: triangulations overlap")
+
+// Merge combines two Delaunay triangulations of disjoint regions, such as
+// two tiles triangulated separately, and points, which may be empty, into
+// the Delaunay triangulation of all their vertices, as DelaunayTriangulation
+// computes it with super and WithHullCompletion. Vertices of b and points
+// equal within Tolerance to a vertex of a are welded to it, so tiles that
+// share boundary vertices may touch. It returns
+// ErrOverlappingTriangulations if, after welding, the convex hulls of a and
+// b overlap.
+//
+// The triangles of a and b whose circumcircles contain no vertex of the
+// other triangulation and no element of points remain Delaunay and are kept
+// as they are. Only the region they leave uncovered, the gap between the
+// two and any part of a or b invalidated by the other, is triangulated
+// anew: only the vertices bordering it are triangulated, and the triangles
+// that fill it are found by walking into it from the edges of the kept
+// triangles.
+func Merge(a, b []Triangle, points []Point, super Triangle) ([]Triangle, error) {
+	va := triangleVertices(a)
+	weld := func(p Point) Point {
+		i := sort.Search(len(va), func(i int) bool { return va[i].X >= p.X-Tolerance })
+		for ; i < len(va) && va[i].X <= p.X+Tolerance; i++ {
+			if PointEqual(va[i], p, Tolerance) {
+				return va[i]
+			}
+		}
+		return p
+	}
+	welded := make([]Triangle, len(b))
+	for i, t := range b {
+		welded[i] = Triangle{A: weld(t.A), B: weld(t.B), C: weld(t.C)}
+	}
+	b = welded
+	extra := make([]Point, len(points))
+	for i, p := range points {
+		extra[i] = weld(p)
+	}
+	extra = sortedUnique(extra)
+	vb := triangleVertices(b)
+	if hullsOverlap(convexHull(va), convexHull(vb)) {
+		return nil, ErrOverlappingTriangulations
+	}
+
+	// Keep the triangles that are still Delaunay; the vertices of the
+	// others, and those on the hulls of a and b, border the region left.
+	var kept []Triangle
+	var free []Point
+	for _, side := range [2]struct {
+		ts      []Triangle
+		foreign []Point
+	}{{a, mergeSorted(vb, extra)}, {b, mergeSorted(va, extra)}} {
+		edges := map[Edge]bool{}
+		for _, t := range side.ts {
+			if orient(t.A, t.B, t.C) < 0 {
+				t.B, t.C = t.C, t.B
+			}
+			for _, e := range [3]Edge{{t.A, t.B}, {t.B, t.C}, {t.C, t.A}} {
+				edges[e] = true
+			}
+			if emptyCircumcircle(t, side.foreign) {
+				kept = append(kept, t)
+			} else {
+				free = append(free, t.A, t.B, t.C)
+			}
+		}
+		for e := range edges {
+			if !edges[Edge{e.B, e.A}] {
+				free = append(free, e.A, e.B)
+			}
+		}
+	}
+	free = append(free, extra...)
+
+	dt, err := DelaunayTriangulation(free, super, WithHullCompletion())
+	if len(kept) == 0 || err != nil && !errors.Is(err, ErrTooFewPoints) && !errors.Is(err, ErrCollinearInput) {
+		return dt, err
+	}
+	if err != nil {
+		// Too few free vertices to leave any region uncovered.
+		return kept, nil
+	}
+
+	// Every triangle of dt that lies outside the kept triangles is one of
+	// the missing ones. Walk into them across the boundary of the kept
+	// triangles, never crossing back.
+	owner := make(map[Edge]int, 3*len(dt))
+	for i, t := range dt {
+		for _, e := range [3]Edge{{t.A, t.B}, {t.B, t.C}, {t.C, t.A}} {
+			owner[e] = i
+		}
+	}
+	inner := make(map[Edge]bool, 3*len(kept))
+	for _, t := range kept {
+		for _, e := range [3]Edge{{t.A, t.B}, {t.B, t.C}, {t.C, t.A}} {
+			inner[e] = true
+		}
+	}
+	result := kept
+	seen := make([]bool, len(dt))
+	for k := 0; k < len(result); k++ {
+		t := result[k]
+		for _, e := range [3]Edge{{t.A, t.B}, {t.B, t.C}, {t.C, t.A}} {
+			if inner[Edge{e.B, e.A}] {
+				continue
+			}
+			if i, ok := owner[Edge{e.B, e.A}]; ok && !seen[i] {
+				seen[i] = true
+				result = append(result, dt[i])
+			}
+		}
+	}
+	return result, nil
+}
+
+// triangleVertices returns the distinct vertices of triangles, sorted by X,
+// then Y.
+func triangleVertices(triangles []Triangle) []Point {
+	vs := make([]Point, 0, 3*len(triangles))
+	for _, t := range triangles {
+		vs = append(vs, t.A, t.B, t.C)
+	}
+	return sortedUnique(vs)
+}
+
+// mergeSorted returns the union of x and y, which are sorted by X, then Y.
+func mergeSorted(x, y []Point) []Point {
+	return sortedUnique(append(append(make([]Point, 0, len(x)+len(y)), x...), y...))
+}
+
+// emptyCircumcircle reports whether no element of pts, which are sorted by
+// X, lies inside the circumcircle of t, which must be counter-clockwise, as
+// decided by EuclideanInCircle. Only the points whose X lies within the
+// circle's span, widened for rounding error, are tested.
+func emptyCircumcircle(t Triangle, pts []Point) bool {
+	c, err := calcCircumcircle(&t)
+	if err != nil || c.isDegenerate() {
+		return false
+	}
+	r := c.radius + float64(retireSlack*(c.radius+math.Abs(c.center.X)))
+	i := sort.Search(len(pts), func(i int) bool { return pts[i].X >= c.center.X-r })
+	for ; i < len(pts) && pts[i].X <= c.center.X+r; i++ {
+		if inCirclePerturbed(t.A, t.B, t.C, pts[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// hullsOverlap reports whether the interiors of the convex polygons p and
+// q, whose vertices are counter-clockwise, intersect. They do unless a line
+// through an edge of one leaves the other wholly on its outer side.
+func hullsOverlap(p, q []Point) bool {
+	if len(p) < 3 || len(q) < 3 {
+		return false
+	}
+	separates := func(p, q []Point) bool {
+		for i, u := range p {
+			w := p[(i+1)%len(p)]
+			outside := true
+			for _, v := range q {
+				if orient(u, w, v) > 0 {
+					outside = false
+					break
+				}
+			}
+			if outside {
+				return true
+			}
+		}
+		return false
+	}
+	return !separates(p, q) && !separates(q, p)
+}
@@ -0,0 +1,119 @@
+package bowyer_watson
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestMerge(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	var left, right, gap []Point
+	for i := 0; i < 100; i++ {
+		left = append(left, Point{r.Float64() * 4, r.Float64() * 10})
+		right = append(right, Point{6 + r.Float64()*4, r.Float64() * 10})
+	}
+	for i := 0; i < 10; i++ {
+		gap = append(gap, Point{4 + r.Float64()*2, r.Float64() * 10})
+	}
+	all := append(append(append([]Point(nil), left...), right...), gap...)
+	super := SuperTriangleFor(all, 1e6)
+
+	a, err := DelaunayTriangulation(left, super)
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, err := DelaunayTriangulation(right, super)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, points := range [][]Point{nil, gap} {
+		union := append(append([]Point(nil), left...), right...)
+		union = append(union, points...)
+		got, err := Merge(a, b, points, super)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := Validate(union, got); err != nil {
+			t.Errorf("%d extra points: %v", len(points), err)
+		}
+		checkMergeResult(t, union, super, got)
+
+		// The triangles that stay Delaunay are kept.
+		found := map[[3]Point]bool{}
+		for _, tri := range got {
+			found[sortedVertices(tri)] = true
+		}
+		kept := 0
+		for _, tri := range append(append([]Triangle(nil), a...), b...) {
+			if isEmptyCircle(tri, union) {
+				kept++
+				if !found[sortedVertices(tri)] {
+					t.Errorf("%d extra points: triangle %v was not kept", len(points), tri)
+				}
+			}
+		}
+		if kept == 0 {
+			t.Errorf("%d extra points: no triangles kept", len(points))
+		}
+	}
+
+	// Overlapping triangulations are rejected.
+	mid := append(append([]Point(nil), left[:50]...), right[:50]...)
+	c, err := DelaunayTriangulation(mid, super)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, tc := range []struct {
+		name string
+		a, b []Triangle
+	}{{"a, a", a, a}, {"a, c", a, c}, {"c, b", c, b}} {
+		if _, err := Merge(tc.a, tc.b, nil, super); err != ErrOverlappingTriangulations {
+			t.Errorf("Merge(%s): got error %v, want %v", tc.name, err, ErrOverlappingTriangulations)
+		}
+	}
+
+	// Tiles sharing boundary vertices, equal within Tolerance, are welded
+	// along it.
+	defer func(tol float64) { Tolerance = tol }(Tolerance)
+	Tolerance = 1e-9
+	var edge, moved []Point
+	for i := 0; i <= 10; i++ {
+		edge = append(edge, Point{5, float64(i)})
+		moved = append(moved, Point{5 + 1e-12, float64(i)})
+	}
+	a, err = DelaunayTriangulation(append(append([]Point(nil), left...), edge...), super)
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, err = DelaunayTriangulation(append(append([]Point(nil), right...), moved...), super)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := Merge(a, b, nil, super)
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkMergeResult(t, append(append(append([]Point(nil), left...), right...), edge...), super, got)
+}
+
+// checkMergeResult reports an error unless got has the same triangles as
+// DelaunayTriangulation finds for points with WithHullCompletion.
+func checkMergeResult(t *testing.T, points []Point, super Triangle, got []Triangle) {
+	t.Helper()
+	want, err := DelaunayTriangulation(points, super, WithHullCompletion())
+	if err != nil {
+		t.Fatal(err)
+	}
+	found := map[[3]Point]bool{}
+	for _, tri := range want {
+		found[sortedVertices(tri)] = true
+	}
+	if len(got) != len(want) {
+		t.Errorf("#triangles: got %v, want %v", len(got), len(want))
+	}
+	for _, tri := range got {
+		if !found[sortedVertices(tri)] {
+			t.Errorf("unexpected triangle %v", tri)
+		}
+	}
+}
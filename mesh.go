@@ -0,0 +1,63 @@
+package bowyer_watson
+
+// Mesh is a triangulation stored as indexed faces: each distinct vertex is
+// held once, and each face is the indices in Vertices of a triangle's A, B
+// and C. It takes less memory than []Triangle and matches the vertex and
+// element buffer layout of graphics APIs.
+type Mesh struct {
+	Vertices []Point  `json:"vertices"`
+	Faces    [][3]int `json:"faces"`
+}
+
+// ToMesh returns triangles as a Mesh. Vertices are numbered in the order
+// they first occur, and equal vertices share an index.
+func ToMesh(triangles []Triangle) Mesh {
+	var m Mesh
+	index := map[Point]int{}
+	vertex := func(p Point) int {
+		i, ok := index[p]
+		if !ok {
+			i = len(m.Vertices)
+			index[p] = i
+			m.Vertices = append(m.Vertices, p)
+		}
+		return i
+	}
+	m.Faces = make([][3]int, len(triangles))
+	for i, t := range triangles {
+		m.Faces[i] = [3]int{vertex(t.A), vertex(t.B), vertex(t.C)}
+	}
+	return m
+}
+
+// IndexedMesh returns triangles as a vertex array and a flat array of
+// indices into it, three per triangle, in the layout of a graphics element
+// buffer. Vertices equal within Tolerance, as merged by DedupPoints, share
+// an index and are represented by the first of them; vertices are numbered
+// in the order they first occur. The triangles returned by
+// DelaunayTriangulation share exactly equal vertices, so they need no
+// Tolerance; it is for triangles from sources that may round vertices
+// differently. Degenerate triangles, including those whose vertices merge,
+// are kept.
+func IndexedMesh(triangles []Triangle) (vertices []Point, indices []int) {
+	all := make([]Point, 0, 3*len(triangles))
+	for _, t := range triangles {
+		all = append(all, t.A, t.B, t.C)
+	}
+	return DedupPoints(all, Tolerance)
+}
+
+// Triangles returns the faces of m as triangles, in order.
+func (m Mesh) Triangles() []Triangle {
+	triangles := make([]Triangle, len(m.Faces))
+	for i, f := range m.Faces {
+		triangles[i] = Triangle{A: m.Vertices[f[0]], B: m.Vertices[f[1]], C: m.Vertices[f[2]]}
+	}
+	return triangles
+}
+
+// BoundingBox returns the smallest BoundingBox containing the vertices of
+// m, as PointsBounds does.
+func (m Mesh) BoundingBox() BoundingBox {
+	return PointsBounds(m.Vertices)
+}
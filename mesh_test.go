@@ -0,0 +1,64 @@
+package bowyer_watson
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestMesh(t *testing.T) {
+	points := []Point{{0, 0}, {4, 0}, {4, 3}, {0, 3}, {1, 1}, {3, 2}}
+	triangles, err := DelaunayTriangulation(points, SuperTriangleFor(points, 10))
+	if err != nil {
+		t.Fatal(err)
+	}
+	m := ToMesh(triangles)
+	if len(m.Vertices) != len(points) {
+		t.Errorf("#vertices: got %v, want %v", len(m.Vertices), len(points))
+	}
+	if len(m.Faces) != len(triangles) {
+		t.Errorf("#faces: got %v, want %v", len(m.Faces), len(triangles))
+	}
+	if got := m.Triangles(); !reflect.DeepEqual(got, triangles) {
+		t.Errorf("round trip: got %v, want %v", got, triangles)
+	}
+	if got, want := m.BoundingBox(), (BoundingBox{Max: Point{4, 3}}); got != want {
+		t.Errorf("BoundingBox: got %v, want %v", got, want)
+	}
+
+	if m := ToMesh(nil); len(m.Vertices) != 0 || len(m.Faces) != 0 || len(m.Triangles()) != 0 {
+		t.Errorf("empty: got %+v", m)
+	}
+}
+
+func TestIndexedMesh(t *testing.T) {
+	points := []Point{{0, 0}, {4, 0}, {4, 3}, {0, 3}, {1, 1}, {3, 2}}
+	triangles, err := DelaunayTriangulation(points, SuperTriangleFor(points, 10))
+	if err != nil {
+		t.Fatal(err)
+	}
+	vertices, indices := IndexedMesh(triangles)
+	m := ToMesh(triangles)
+	if !reflect.DeepEqual(vertices, m.Vertices) {
+		t.Errorf("vertices: got %v, want %v", vertices, m.Vertices)
+	}
+	for i, f := range m.Faces {
+		if got := [3]int{indices[3*i], indices[3*i+1], indices[3*i+2]}; got != f {
+			t.Errorf("face %d: got %v, want %v", i, got, f)
+		}
+	}
+
+	// Vertices that differ by rounding collapse within Tolerance.
+	defer func(tol float64) { Tolerance = tol }(Tolerance)
+	Tolerance = 1e-9
+	rounded := []Triangle{
+		{A: Point{0, 0}, B: Point{1, 0}, C: Point{0, 1}},
+		{A: Point{1 + 1e-12, 0}, B: Point{1, 1}, C: Point{0, 1 - 1e-12}},
+	}
+	vertices, indices = IndexedMesh(rounded)
+	if want := []Point{{0, 0}, {1, 0}, {0, 1}, {1, 1}}; !reflect.DeepEqual(vertices, want) {
+		t.Errorf("rounded: vertices: got %v, want %v", vertices, want)
+	}
+	if want := []int{0, 1, 2, 1, 3, 2}; !reflect.DeepEqual(indices, want) {
+		t.Errorf("rounded: indices: got %v, want %v", indices, want)
+	}
+}
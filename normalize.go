@@ -0,0 +1,71 @@
+package bowyer_watson
+
+import "math"
+
+// A normalization maps points to coordinates centered on the origin and
+// scaled to unit size, so that the precision of the circumcircles calculated
+// during triangulation depends on the spread of the points rather than
+// their distance from the origin. The scale is a power of two, so scaling
+// is exact; only the translation rounds.
+type normalization struct {
+	center Point
+	scale  float64
+}
+
+// newNormalization returns the normalization that takes the bounding box
+// of points to a box centered on the origin whose larger side is in
+// [0.5, 1).
+func newNormalization(points []Point) normalization {
+	b := PointsBounds(points)
+	n := normalization{
+		center: Point{b.Min.X + (b.Max.X-b.Min.X)/2, b.Min.Y + (b.Max.Y-b.Min.Y)/2},
+		scale:  1,
+	}
+	if size := math.Max(b.Max.X-b.Min.X, b.Max.Y-b.Min.Y); size > 0 && !math.IsInf(size, 0) {
+		_, exp := math.Frexp(size)
+		n.scale = math.Ldexp(1, -exp)
+	}
+	return n
+}
+
+// apply returns p in normalized coordinates.
+func (n normalization) apply(p Point) Point {
+	return Point{(p.X - n.center.X) * n.scale, (p.Y - n.center.Y) * n.scale}
+}
+
+// normalize replaces pts, which must be sorted by X, then Y, and super with
+// their normalized coordinates and returns a map from the new coordinates
+// back to the originals. Points that become equal when rounded are merged,
+// keeping the first. The order of pts is preserved, since the mapping does
+// not decrease either coordinate.
+func normalize(pts []Point, super *Triangle) (normalized []Point, original map[Point]Point) {
+	n := newNormalization(pts)
+	original = make(map[Point]Point, len(pts)+3)
+	m := 0
+	for _, p := range pts {
+		q := n.apply(p)
+		if m > 0 && q == pts[m-1] {
+			continue
+		}
+		original[q] = p
+		pts[m] = q
+		m++
+	}
+	for _, v := range [3]*Point{&super.A, &super.B, &super.C} {
+		q := n.apply(*v)
+		original[q] = *v
+		*v = q
+	}
+	return pts[:m], original
+}
+
+// denormalize replaces the vertices of triangles with their originals,
+// given by the map returned by normalize, and recalculates their
+// circumcircles.
+func denormalize(triangles []cachedTriangle, original map[Point]Point) {
+	for i := range triangles {
+		t := &triangles[i].Triangle
+		t.A, t.B, t.C = original[t.A], original[t.B], original[t.C]
+		triangles[i] = newCachedTriangle(*t)
+	}
+}
@@ -0,0 +1,73 @@
+package bowyer_watson
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestWithNormalization(t *testing.T) {
+	// Coordinates are multiples of 1/1024 so that the offsets are exact.
+	r := rand.New(rand.NewSource(1))
+	shape := make([]Point, 2000)
+	for i := range shape {
+		shape[i] = Point{float64(r.Intn(1<<20)) / 1024, float64(r.Intn(1<<20)) / 1024}
+	}
+	want, err := DelaunayTriangulation(shape, SuperTriangleFor(shape, 10))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, off := range []Point{{0, 0}, {1e7, 1e7}, {500000, 4000000}, {1e9, -1e9}} {
+		points := make([]Point, len(shape))
+		for i, p := range shape {
+			points[i] = Point{p.X + off.X, p.Y + off.Y}
+		}
+		for _, grid := range []bool{false, true} {
+			opts := []Option{WithNormalization()}
+			if grid {
+				opts = append(opts, WithGridIndex(0))
+			}
+			got, err := DelaunayTriangulation(points, SuperTriangleFor(points, 10), opts...)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(got) != len(want) {
+				t.Errorf("offset %v, grid %v: #triangles: got %v, want %v", off, grid, len(got), len(want))
+			}
+			found := map[[3]Point]bool{}
+			for _, tri := range want {
+				tri.A = Point{tri.A.X + off.X, tri.A.Y + off.Y}
+				tri.B = Point{tri.B.X + off.X, tri.B.Y + off.Y}
+				tri.C = Point{tri.C.X + off.X, tri.C.Y + off.Y}
+				found[sortedVertices(tri)] = true
+			}
+			for _, tri := range got {
+				if !found[sortedVertices(tri)] {
+					t.Errorf("offset %v, grid %v: unexpected triangle %v", off, grid, tri)
+					break
+				}
+			}
+		}
+	}
+}
+
+func TestWithNormalizationGhosts(t *testing.T) {
+	points := []Point{{1e8, 1e8}, {1e8 + 1, 1e8}, {1e8, 1e8 + 1}, {1e8 + 1, 1e8 + 1}, {1e8 + 0.25, 1e8 + 0.5}}
+	super := SuperTriangleFor(points, 10)
+	interior, ghosts, err := DelaunayTriangulationWithGhosts(points, super, WithNormalization())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(interior) != 4 {
+		t.Errorf("#interior: got %v, want 4", len(interior))
+	}
+	checkMesh(t, points, interior)
+	for _, g := range ghosts {
+		if !g.HasVertex(super.A) && !g.HasVertex(super.B) && !g.HasVertex(super.C) {
+			t.Errorf("ghost %v has no vertex of super %v", g, super)
+		}
+	}
+	if len(ghosts) == 0 {
+		t.Errorf("got no ghosts")
+	}
+}
@@ -0,0 +1,98 @@
+package bowyer_watson
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// WriteOBJ writes triangles to w in Wavefront OBJ format. Each distinct
+// vertex is written once as a "v x y 0" line and every triangle as an
+// "f i j k" line referencing the shared vertices.
+func WriteOBJ(w io.Writer, triangles []Triangle) error {
+	bw := bufio.NewWriter(w)
+	index := map[Point]int{}
+	vertex := func(p Point) int {
+		i, ok := index[p]
+		if !ok {
+			i = len(index) + 1
+			index[p] = i
+			fmt.Fprintf(bw, "v %s %s 0\n", formatFloat(p.X), formatFloat(p.Y))
+		}
+		return i
+	}
+	for _, t := range triangles {
+		a, b, c := vertex(t.A), vertex(t.B), vertex(t.C)
+		fmt.Fprintf(bw, "f %d %d %d\n", a, b, c)
+	}
+	return bw.Flush()
+}
+
+// ReadOBJ reads the triangular faces of the Wavefront OBJ data in r. The z
+// coordinate of vertices is ignored. Statements other than v and f are
+// skipped.
+func ReadOBJ(r io.Reader) ([]Triangle, error) {
+	var (
+		vertices  []Point
+		triangles []Triangle
+	)
+	sc := bufio.NewScanner(r)
+	for line := 1; sc.Scan(); line++ {
+		fields := strings.Fields(sc.Text())
+		if len(fields) == 0 {
+			continue
+		}
+		switch fields[0] {
+		case "v":
+			if len(fields) < 3 {
+				return nil, fmt.Errorf("bowyer_watson: obj line %d: vertex has %d coordinates, want at least 2", line, len(fields)-1)
+			}
+			x, err := strconv.ParseFloat(fields[1], 64)
+			if err != nil {
+				return nil, fmt.Errorf("bowyer_watson: obj line %d: %v", line, err)
+			}
+			y, err := strconv.ParseFloat(fields[2], 64)
+			if err != nil {
+				return nil, fmt.Errorf("bowyer_watson: obj line %d: %v", line, err)
+			}
+			vertices = append(vertices, Point{x, y})
+		case "f":
+			if len(fields) != 4 {
+				return nil, fmt.Errorf("bowyer_watson: obj line %d: face has %d vertices, want 3", line, len(fields)-1)
+			}
+			var ps [3]Point
+			for i, f := range fields[1:] {
+				// Faces may reference texture and normal indices as
+				// v/vt/vn, only the vertex index is used.
+				if j := strings.IndexByte(f, '/'); j >= 0 {
+					f = f[:j]
+				}
+				n, err := strconv.Atoi(f)
+				if err != nil {
+					return nil, fmt.Errorf("bowyer_watson: obj line %d: %v", line, err)
+				}
+				// Negative indices are relative to the end of the vertex
+				// list.
+				if n < 0 {
+					n += len(vertices) + 1
+				}
+				if n < 1 || n > len(vertices) {
+					return nil, fmt.Errorf("bowyer_watson: obj line %d: vertex index %s out of range", line, f)
+				}
+				ps[i] = vertices[n-1]
+			}
+			triangles = append(triangles, Triangle{A: ps[0], B: ps[1], C: ps[2]})
+		}
+	}
+	if err := sc.Err(); err != nil {
+		return nil, err
+	}
+	return triangles, nil
+}
+
+// formatFloat formats f with the minimum precision that round trips.
+func formatFloat(f float64) string {
+	return strconv.FormatFloat(f, 'g', -1, 64)
+}
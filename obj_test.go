@@ -0,0 +1,93 @@
+package bowyer_watson
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestOBJRoundTrip(t *testing.T) {
+	points := make([]Point, 30)
+	for i := range points {
+		x, y := getRandomPointInCircle(5)
+		points[i] = Point{x, y}
+	}
+
+	super := Triangle{
+		A: Point{0, 50},
+		B: Point{50, -50},
+		C: Point{-50, -50},
+	}
+
+	u, err := DelaunayTriangulation(points, super)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var buf bytes.Buffer
+	if err := WriteOBJ(&buf, u); err != nil {
+		t.Fatal(err)
+	}
+
+	vertices := map[Point]bool{}
+	for _, tri := range u {
+		vertices[tri.A], vertices[tri.B], vertices[tri.C] = true, true, true
+	}
+	if got, want := strings.Count(buf.String(), "v "), len(vertices); got != want {
+		t.Errorf("#vertices: got %v, want %v", got, want)
+	}
+
+	got, err := ReadOBJ(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != len(u) {
+		t.Fatalf("#triangles: got %v, want %v", len(got), len(u))
+	}
+	for i := range u {
+		if got[i] != u[i] {
+			t.Errorf("triangle %d: got %v, want %v", i, got[i], u[i])
+		}
+	}
+}
+
+func TestReadOBJ(t *testing.T) {
+	const obj = `# square
+o square
+v 0 0 0
+v 1 0 0
+v 1 1 0.5
+vn 0 0 1
+v 0 1
+f 1/1/1 2/2/1 3/3/1
+f -4 -2 -1
+`
+	got, err := ReadOBJ(strings.NewReader(obj))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []Triangle{
+		{A: Point{0, 0}, B: Point{1, 0}, C: Point{1, 1}},
+		{A: Point{0, 0}, B: Point{1, 1}, C: Point{0, 1}},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("#triangles: got %v, want %v", len(got), len(want))
+	}
+	for i := range want {
+		if got[i].A != want[i].A || got[i].B != want[i].B || got[i].C != want[i].C {
+			t.Errorf("triangle %d: got %v, want %v", i, got[i], want[i])
+		}
+	}
+
+	for _, bad := range []string{
+		"v 1\n",
+		"v 1 x\n",
+		"v 0 0\nv 1 0\nv 0 1\nv 1 1\nf 1 2 3 4\n",
+		"v 0 0\nv 1 0\nf 1 2 3\n",
+		"v 0 0\nv 1 0\nv 0 1\nf 1 2 x\n",
+	} {
+		if _, err := ReadOBJ(strings.NewReader(bad)); err == nil {
+			t.Errorf("%q: got nil error", bad)
+		}
+	}
+}
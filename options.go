@@ -0,0 +1,69 @@
+package bowyer_watson
+
+// Options configures DelaunayWith. It gathers the most common settings in
+// one value that can be stored and passed around; the Option functions
+// accepted by DelaunayTriangulation remain the complete set.
+type Options struct {
+	// Robust selects the exact in-circle test, EuclideanInCircle. Without
+	// it the circumcircle is computed in floating point and tested with
+	// Triangle.CircumcircleContains, which can misjudge points very near
+	// the circle and is not faster, since triangles are then kept under
+	// test until the end. See WithInCircle.
+	Robust bool
+
+	// Epsilon is the distance in each coordinate within which input points
+	// are merged as duplicates. Zero means Tolerance. See
+	// WithDuplicateTolerance.
+	Epsilon float64
+
+	// KeepGhosts appends the ghost triangles, those with a vertex of super,
+	// after the others. See DelaunayTriangulationWithGhosts.
+	KeepGhosts bool
+
+	// Parallelism is the number of goroutines to use. Values above one
+	// select DelaunayTriangulationParallel, which gives the same result,
+	// but only together with Robust, the default Epsilon and no ghosts;
+	// otherwise it is ignored.
+	Parallelism int
+
+	// PreSorted tells DelaunayWith that points are already sorted by X,
+	// then Y. See WithPresortedInput.
+	PreSorted bool
+}
+
+// DefaultOptions returns the Options under which DelaunayWith behaves like
+// DelaunayTriangulation with no options. They differ from the zero Options
+// only in Robust.
+func DefaultOptions() Options {
+	return Options{Robust: true}
+}
+
+// DelaunayWith returns the Delaunay triangulation of points, like
+// DelaunayTriangulation, configured by opts.
+func DelaunayWith(points []Point, super Triangle, opts Options) ([]Triangle, error) {
+	if opts.Parallelism > 1 && opts.Robust && opts.Epsilon == 0 && !opts.KeepGhosts {
+		if triangles := DelaunayTriangulationParallel(points, super, opts.Parallelism); triangles != nil {
+			return triangles, nil
+		}
+		// Fall through to report the error.
+	}
+
+	var o []Option
+	if !opts.Robust {
+		o = append(o, WithInCircle((*Triangle).CircumcircleContains))
+	}
+	if opts.Epsilon != 0 {
+		o = append(o, WithDuplicateTolerance(opts.Epsilon))
+	}
+	if opts.PreSorted {
+		o = append(o, WithPresortedInput())
+	}
+	interior, ghosts, err := DelaunayTriangulationWithGhosts(points, super, o...)
+	if err != nil {
+		return nil, err
+	}
+	if opts.KeepGhosts {
+		interior = append(interior[:len(interior):len(interior)], ghosts...)
+	}
+	return interior, nil
+}
@@ -0,0 +1,80 @@
+package bowyer_watson
+
+import (
+	"sort"
+	"testing"
+)
+
+func TestDelaunayWith(t *testing.T) {
+	points := make([]Point, 300)
+	for i := range points {
+		x, y := getRandomPointInCircle(10)
+		points[i] = Point{x, y}
+	}
+	super := SuperTriangleFor(points, 10)
+	interior, ghosts, err := DelaunayTriangulationWithGhosts(points, super)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := map[[3]Point]bool{}
+	for _, tri := range interior {
+		want[sortedVertices(tri)] = true
+	}
+
+	sorted := append([]Point(nil), points...)
+	sort.Sort(pointsByX(sorted))
+	for name, tt := range map[string]struct {
+		points []Point
+		opts   func(*Options)
+	}{
+		"default":        {points, func(*Options) {}},
+		"not robust":     {points, func(o *Options) { o.Robust = false }},
+		"parallel":       {points, func(o *Options) { o.Parallelism = 4 }},
+		"presorted":      {sorted, func(o *Options) { o.PreSorted = true }},
+		"wrongly sorted": {points, func(o *Options) { o.PreSorted = true }},
+	} {
+		opts := DefaultOptions()
+		tt.opts(&opts)
+		got, err := DelaunayWith(tt.points, super, opts)
+		if err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		if len(got) != len(want) {
+			t.Errorf("%s: #triangles: got %d, want %d", name, len(got), len(want))
+		}
+		for _, tri := range got {
+			if !want[sortedVertices(tri)] {
+				t.Errorf("%s: unexpected triangle %v", name, tri)
+			}
+		}
+	}
+
+	opts := DefaultOptions()
+	opts.KeepGhosts = true
+	got, err := DelaunayWith(points, super, opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != len(interior)+len(ghosts) {
+		t.Errorf("KeepGhosts: got %d triangles, want %d", len(got), len(interior)+len(ghosts))
+	}
+
+	// Epsilon merges the points that differ by less than it.
+	near := []Point{{0, 0}, {1, 0}, {0, 1}, {1, 1}, {1.001, 1}}
+	opts = DefaultOptions()
+	if got, _ := DelaunayWith(near, SuperTriangleFor(near, 10), opts); len(got) != 3 {
+		t.Errorf("Epsilon 0: got %d triangles, want 3", len(got))
+	}
+	opts.Epsilon = 0.01
+	if got, _ := DelaunayWith(near, SuperTriangleFor(near, 10), opts); len(got) != 2 {
+		t.Errorf("Epsilon 0.01: got %d triangles, want 2", len(got))
+	}
+
+	// Errors are reported whichever method is selected.
+	collinear := []Point{{0, 0}, {1, 1}, {2, 2}}
+	opts = DefaultOptions()
+	opts.Parallelism = 4
+	if _, err := DelaunayWith(collinear, SuperTriangleFor(collinear, 10), opts); err != ErrCollinearInput {
+		t.Errorf("got error %v, want %v", err, ErrCollinearInput)
+	}
+}
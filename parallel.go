@@ -0,0 +1,97 @@
+package bowyer_watson
+
+import (
+	"runtime"
+	"sync"
+)
+
+// parallelMinStrip is the smallest number of points DelaunayTriangulationParallel
+// gives each worker. Below it the cost of the extra merges outweighs the gain
+// from concurrency.
+const parallelMinStrip = 4096
+
+// DelaunayTriangulationParallel returns the same triangles as
+// DelaunayTriangulation, with default options, using up to workers
+// goroutines, or GOMAXPROCS if workers is less than one. It returns nil
+// where DelaunayTriangulation would return an error.
+//
+// The sorted points are divided into vertical strips, one per worker, which
+// are triangulated concurrently with the algorithm of DivideAndConquer.
+// Neighboring strips are then merged in pairs, concurrently, with the
+// Guibas-Stolfi merge step, until one triangulation covering the convex
+// hull remains. Finally the triangles that DelaunayTriangulation would not
+// have formed, those whose circumcircles contain a vertex of super, are
+// removed. Only inputs of tens of thousands of points are divided.
+func DelaunayTriangulationParallel(points []Point, super Triangle, workers int) []Triangle {
+	if workers < 1 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+	if len(points) == 0 || checkFinite(points) != nil || super.IsDegenerate() {
+		return nil
+	}
+	if orient(super.A, super.B, super.C) < 0 {
+		super.B, super.C = super.C, super.B
+	}
+	if checkInsideSuper(points, super) != nil {
+		return nil
+	}
+	pts, _ := sortDedup(append([]Point(nil), points...), false)
+	if len(pts) < 3 || collinear(pts) || checkSuperMargin(points, super) != nil {
+		return nil
+	}
+
+	k := len(pts) / parallelMinStrip
+	if k > workers {
+		k = workers
+	}
+	if k < 1 {
+		k = 1
+	}
+
+	// Each strip, and later each merge, records its new edges in its own
+	// subdivision so that goroutines do not share state.
+	subs := make([]subdivision, k)
+	ldo := make([]*directedEdge, k)
+	rdo := make([]*directedEdge, k)
+	var wg sync.WaitGroup
+	for i := range subs {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			subs[i].pts = pts
+			ldo[i], rdo[i] = subs[i].delaunay(i*len(pts)/k, (i+1)*len(pts)/k)
+		}(i)
+	}
+	wg.Wait()
+
+	for width := 1; width < k; width *= 2 {
+		for i := 0; i+width < k; i += 2 * width {
+			wg.Add(1)
+			go func(i, j int) {
+				defer wg.Done()
+				ldo[i], rdo[i] = subs[i].merge(ldo[i], rdo[i], ldo[j], rdo[j])
+			}(i, i+width)
+		}
+		wg.Wait()
+	}
+
+	s := subdivision{pts: pts}
+	for i := range subs {
+		s.edges = append(s.edges, subs[i].edges...)
+	}
+	triangles := s.triangles()
+
+	n := 0
+	for _, t := range triangles {
+		if inCirclePerturbed(t.A, t.B, t.C, super.A) || inCirclePerturbed(t.A, t.B, t.C, super.B) ||
+			inCirclePerturbed(t.A, t.B, t.C, super.C) {
+			continue
+		}
+		triangles[n] = t
+		n++
+	}
+	if n == 0 {
+		return nil
+	}
+	return triangles[:n]
+}
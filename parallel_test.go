@@ -0,0 +1,86 @@
+package bowyer_watson
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestDelaunayTriangulationParallel(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	random := make([]Point, 3*parallelMinStrip+17)
+	for i := range random {
+		random[i] = Point{r.Float64() * 100, r.Float64() * 100}
+	}
+	var lattice []Point
+	for i := 0; i < 100; i++ {
+		for j := 0; j < 100; j++ {
+			lattice = append(lattice, Point{float64(i), float64(j)})
+		}
+	}
+	// Few enough points that there is a single strip.
+	small := random[:500]
+
+	for _, tt := range []struct {
+		name   string
+		points []Point
+		margin float64
+	}{
+		{"random", random, 1},
+		{"lattice", lattice, 1},
+		{"small", small, 1},
+		// With the minimum margin some hull triangles are lost, and
+		// DelaunayTriangulationParallel must lose the same ones.
+		{"tight", random, MinSuperMargin},
+	} {
+		super := SuperTriangleFor(tt.points, tt.margin)
+		want, err := DelaunayTriangulation(tt.points, super)
+		if err != nil {
+			t.Fatal(err)
+		}
+		found := map[[3]Point]bool{}
+		for _, tri := range want {
+			found[sortedVertices(tri)] = true
+		}
+		for _, workers := range []int{0, 1, 3, 8} {
+			got := DelaunayTriangulationParallel(tt.points, super, workers)
+			if len(got) != len(want) {
+				t.Errorf("%s, %d workers: #triangles: got %v, want %v", tt.name, workers, len(got), len(want))
+				continue
+			}
+			for _, tri := range got {
+				if !found[sortedVertices(tri)] {
+					t.Errorf("%s, %d workers: unexpected triangle %v", tt.name, workers, tri)
+					break
+				}
+				if orient(tri.A, tri.B, tri.C) <= 0 {
+					t.Errorf("%s, %d workers: triangle %v is not counter-clockwise", tt.name, workers, tri)
+					break
+				}
+			}
+		}
+	}
+}
+
+func TestDelaunayTriangulationParallelInvalid(t *testing.T) {
+	super := SuperTriangleFor([]Point{{0, 0}, {1, 1}}, 1)
+	for name, points := range map[string][]Point{
+		"empty":     nil,
+		"collinear": {{0, 0}, {0.5, 0.5}, {1, 1}},
+		"outside":   {{0, 0}, {1, 0}, {1e9, 1}},
+		"one":       {{1, 1}, {1, 1}, {1, 1}},
+		"two":       {{0, 0}, {1, 1}, {0, 0}, {1, 1}},
+	} {
+		if got := DelaunayTriangulationParallel(points, super, 4); got != nil {
+			t.Errorf("%s: got %v, want nil", name, got)
+		}
+	}
+}
+
+func BenchmarkDelaunayTriangulationParallel100K(b *testing.B) {
+	points := benchmarkPoints(100000)
+	super := SuperTriangleFor(points, 10)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		DelaunayTriangulationParallel(points, super, 8)
+	}
+}
@@ -0,0 +1,50 @@
+package bowyer_watson
+
+// Polygon is a region bounded by an outer ring with zero or more holes,
+// such as the boundary a constrained triangulation must conform to. Each
+// ring is a sequence of vertices joined in order and closed implicitly from
+// the last vertex back to the first; repeating the first vertex at the end
+// is allowed. Rings may be wound either way.
+type Polygon struct {
+	Outer []Point   `json:"outer"`
+	Holes [][]Point `json:"holes,omitempty"`
+}
+
+// Edges returns the edges of the outer ring followed by those of each hole,
+// each ring in order. Edges of zero length, such as the one closing a ring
+// that repeats its first vertex, are omitted.
+func (p Polygon) Edges() []Edge {
+	var edges []Edge
+	for _, ring := range p.rings() {
+		for i, a := range ring {
+			if b := ring[(i+1)%len(ring)]; a != b {
+				edges = append(edges, Edge{a, b})
+			}
+		}
+	}
+	return edges
+}
+
+// Contains reports whether pt lies inside p: inside the outer ring and
+// outside every hole. It uses the ray-casting algorithm, counting the edges
+// crossed by a ray from pt in the +X direction, with each crossing decided
+// exactly. Points on the boundary may be reported either way.
+func (p Polygon) Contains(pt Point) bool {
+	inside := false
+	for _, e := range p.Edges() {
+		a, b := e.A, e.B
+		if a.Y > pt.Y == (b.Y > pt.Y) {
+			continue
+		}
+		// The edge crosses the ray if pt is to its left going up or to
+		// its right going down.
+		if o := orient(a, b, pt); o != 0 && o > 0 == (b.Y > a.Y) {
+			inside = !inside
+		}
+	}
+	return inside
+}
+
+func (p Polygon) rings() [][]Point {
+	return append([][]Point{p.Outer}, p.Holes...)
+}
@@ -0,0 +1,56 @@
+package bowyer_watson
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestPolygon(t *testing.T) {
+	p := Polygon{
+		Outer: []Point{{0, 0}, {10, 0}, {10, 10}, {0, 10}},
+		Holes: [][]Point{
+			// Wound clockwise and explicitly closed.
+			{{4, 4}, {4, 6}, {6, 6}, {6, 4}, {4, 4}},
+		},
+	}
+	want := []Edge{
+		{Point{0, 0}, Point{10, 0}}, {Point{10, 0}, Point{10, 10}}, {Point{10, 10}, Point{0, 10}}, {Point{0, 10}, Point{0, 0}},
+		{Point{4, 4}, Point{4, 6}}, {Point{4, 6}, Point{6, 6}}, {Point{6, 6}, Point{6, 4}}, {Point{6, 4}, Point{4, 4}},
+	}
+	if got := p.Edges(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Edges: got %v, want %v", got, want)
+	}
+
+	tests := []struct {
+		pt   Point
+		want bool
+	}{
+		{Point{1, 1}, true},
+		{Point{9, 5}, true},
+		{Point{5, 5}, false},
+		{Point{-1, 5}, false},
+		{Point{11, 5}, false},
+		// On the ray through vertices (4, 4) and (6, 4), which must not be
+		// counted twice.
+		{Point{2, 4}, true},
+		{Point{2, 6}, true},
+		{Point{5, 4.5}, false},
+	}
+	for _, tt := range tests {
+		if got := p.Contains(tt.pt); got != tt.want {
+			t.Errorf("Contains(%v): got %v, want %v", tt.pt, got, tt.want)
+		}
+	}
+
+	// A concave outer ring.
+	u := Polygon{Outer: []Point{{0, 0}, {6, 0}, {6, 6}, {4, 6}, {4, 2}, {2, 2}, {2, 6}, {0, 6}}}
+	for pt, want := range map[Point]bool{{1, 5}: true, {3, 5}: false, {5, 5}: true, {3, 1}: true} {
+		if got := u.Contains(pt); got != want {
+			t.Errorf("U: Contains(%v): got %v, want %v", pt, got, want)
+		}
+	}
+
+	if (Polygon{}).Contains(Point{}) || len((Polygon{}).Edges()) != 0 {
+		t.Errorf("empty polygon has edges or contains a point")
+	}
+}
@@ -0,0 +1,129 @@
+package bowyer_watson
+
+import (
+	"math"
+	"sort"
+)
+
+// WeightedPoint is a point with a weight, the square of the radius of a
+// circle centered on it. The power distance of a point q from it is
+// (q.X-X)² + (q.Y-Y)² - W.
+type WeightedPoint struct {
+	X float64 `json:"x"`
+	Y float64 `json:"y"`
+	W float64 `json:"w"`
+}
+
+// Point returns the position of p.
+func (p WeightedPoint) Point() Point {
+	return Point{p.X, p.Y}
+}
+
+// PowerDiagram returns the weighted Delaunay, or regular, triangulation of
+// points: the dual of their power diagram, in which each point owns the
+// region nearer to it than to any other by power distance. A point whose
+// region is empty, because heavier neighbours cover it, is redundant and is
+// not a vertex of any triangle. Of points at the same position, within
+// Tolerance, only the heaviest is kept. With equal weights the result is
+// the Delaunay triangulation.
+//
+// The positions of points must lie inside super as for
+// DelaunayTriangulation, and the vertices of super, which take the least of
+// the weights, must not be made redundant by them; nil is returned
+// otherwise. The triangles are counter-clockwise. PowerDiagram takes time
+// quadratic in the number of points.
+func PowerDiagram(points []WeightedPoint, super Triangle) []Triangle {
+	pts := make([]WeightedPoint, len(points))
+	copy(pts, points)
+	positions := make([]Point, len(pts))
+	for i, p := range pts {
+		positions[i] = p.Point()
+	}
+	if orient(super.A, super.B, super.C) < 0 {
+		super.A, super.B = super.B, super.A
+	}
+	if len(pts) == 0 || orient(super.A, super.B, super.C) == 0 ||
+		checkFinite(positions) != nil || checkInsideSuper(positions, super) != nil || checkSuperMargin(positions, super) != nil {
+		return nil
+	}
+	for _, p := range pts {
+		if math.IsNaN(p.W) || math.IsInf(p.W, 0) {
+			return nil
+		}
+	}
+
+	sort.SliceStable(pts, func(i, j int) bool {
+		return lexLess(pts[i].Point(), pts[j].Point())
+	})
+	n := 0
+	for k, p := range pts {
+		if k > 0 && PointEqual(p.Point(), pts[n-1].Point(), Tolerance) {
+			if p.W > pts[n-1].W {
+				pts[n-1].W = p.W
+			}
+			continue
+		}
+		pts[n] = p
+		n++
+	}
+	pts = pts[:n]
+
+	// Adding the same amount to every weight leaves the triangulation
+	// unchanged, so giving super the least weight makes equal weights
+	// behave exactly like none.
+	least := pts[0].W
+	for _, p := range pts {
+		least = math.Min(least, p.W)
+	}
+	weights := map[Point]float64{super.A: least, super.B: least, super.C: least}
+	for _, p := range pts {
+		weights[p.Point()] = p.W
+	}
+	weighted := func(p Point) WeightedPoint {
+		return WeightedPoint{p.X, p.Y, weights[p]}
+	}
+
+	ts := []Triangle{super}
+	var edges []Edge
+	for _, wp := range pts {
+		p := wp.Point()
+		edges = edges[:0]
+		for i := 0; i < len(ts); {
+			t := &ts[i]
+			if orient(t.A, t.B, t.C) != 0 && inPowerCircle(weighted(t.A), weighted(t.B), weighted(t.C), wp) > 0 {
+				edges = append(edges, Edge{t.A, t.B}, Edge{t.A, t.C}, Edge{t.B, t.C})
+				n := len(ts) - 1
+				ts[i] = ts[n]
+				ts = ts[:n]
+			} else {
+				i++
+			}
+		}
+		for _, e := range holeBoundary(edges) {
+			ts = append(ts, newTriangle(e, p).Triangle)
+		}
+	}
+
+	// A super vertex made redundant took the hull with it.
+	for _, s := range [3]Point{super.A, super.B, super.C} {
+		found := false
+		for i := range ts {
+			if ts[i].HasVertex(s) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return nil
+		}
+	}
+
+	result := ts[:0]
+	for _, t := range ts {
+		if orient(t.A, t.B, t.C) == 0 || t.HasVertex(super.A) || t.HasVertex(super.B) || t.HasVertex(super.C) {
+			continue
+		}
+		result = append(result, t)
+	}
+	return result
+}
@@ -0,0 +1,93 @@
+package bowyer_watson
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func TestPowerDiagram(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	points := make([]Point, 200)
+	equal := make([]WeightedPoint, len(points))
+	for i := range points {
+		points[i] = Point{r.Float64() * 100, r.Float64() * 100}
+		equal[i] = WeightedPoint{points[i].X, points[i].Y, 3}
+	}
+	super := SuperTriangleFor(points, 10)
+
+	// Equal weights give the Delaunay triangulation.
+	want, err := DelaunayTriangulation(points, super)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := PowerDiagram(equal, super)
+	if HashTriangulation(got) != HashTriangulation(want) {
+		t.Errorf("equal weights: got %v triangles, want the %v of the Delaunay triangulation", len(got), len(want))
+	}
+
+	// With random weights no vertex conflicts with any triangle.
+	weighted := make([]WeightedPoint, len(points))
+	for i, p := range points {
+		weighted[i] = WeightedPoint{p.X, p.Y, r.Float64() * 25}
+	}
+	got = PowerDiagram(weighted, super)
+	if len(got) == 0 {
+		t.Fatal("random weights: no triangles")
+	}
+	weights := map[Point]float64{}
+	for _, p := range weighted {
+		weights[p.Point()] = p.W
+	}
+	vertices := map[Point]bool{}
+	for _, tri := range got {
+		for _, p := range [3]Point{tri.A, tri.B, tri.C} {
+			vertices[p] = true
+		}
+	}
+	w := func(p Point) WeightedPoint { return WeightedPoint{p.X, p.Y, weights[p]} }
+	for _, tri := range got {
+		if orient(tri.A, tri.B, tri.C) <= 0 {
+			t.Fatalf("%v is not counter-clockwise", tri)
+		}
+		for p := range vertices {
+			if inPowerCircle(w(tri.A), w(tri.B), w(tri.C), w(p)) > 0 {
+				t.Fatalf("%v conflicts with %v", p, tri)
+			}
+		}
+	}
+
+	// A light point among heavy neighbours has an empty cell.
+	ring := []WeightedPoint{{0, 0, -0.5}}
+	for k := 0; k < 6; k++ {
+		theta := float64(k) * math.Pi / 3
+		ring = append(ring, WeightedPoint{math.Cos(theta), math.Sin(theta), 1})
+	}
+	got = PowerDiagram(ring, Triangle{A: Point{-100, -100}, B: Point{100, -100}, C: Point{0, 100}})
+	if len(got) != 4 {
+		t.Errorf("ring: got %v triangles, want 4", len(got))
+	}
+	for _, tri := range got {
+		if tri.HasVertex(Point{0, 0}) {
+			t.Errorf("ring: redundant center is a vertex of %v", tri)
+		}
+	}
+	ring[0].W = 0.5
+	if got := PowerDiagram(ring, Triangle{A: Point{-100, -100}, B: Point{100, -100}, C: Point{0, 100}}); len(got) != 6 {
+		t.Errorf("heavier center: got %v triangles, want 6", len(got))
+	}
+
+	for _, tc := range []struct {
+		name   string
+		points []WeightedPoint
+	}{
+		{"empty", nil},
+		{"outside", []WeightedPoint{{1, 1, 0}, {1000, 1, 0}, {1, 2, 0}}},
+		{"NaN", []WeightedPoint{{1, 1, 0}, {math.NaN(), 1, 0}, {1, 2, 0}}},
+		{"infinite weight", []WeightedPoint{{1, 1, 0}, {2, 1, math.Inf(1)}, {1, 2, 0}}},
+	} {
+		if got := PowerDiagram(tc.points, super); got != nil {
+			t.Errorf("%s: got %v, want nil", tc.name, got)
+		}
+	}
+}
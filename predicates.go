@@ -0,0 +1,411 @@
+package bowyer_watson
+
+import "math"
+
+// The geometric predicates below use the approach of Shewchuk, J. R.,
+// "Adaptive Precision Floating-Point Arithmetic and Fast Robust Geometric
+// Predicates", Discrete & Computational Geometry 18(3), 1997. Each is first
+// evaluated in ordinary floating point. If the result is smaller than a
+// bound on its rounding error, the sign could be wrong, and the determinant
+// is evaluated again exactly using expansion arithmetic: a value is held as
+// a sum of non-overlapping float64 components, ordered by increasing
+// magnitude, whose sign is the sign of its largest component.
+//
+// The floating-point filters convert every product to float64 before it is
+// added, which stops the compiler from fusing the multiply and add. Fused
+// operations round differently, so on architectures that fuse them, such as
+// arm64, the filters would otherwise return different values than on
+// amd64. The exact fallbacks use math.FMA explicitly.
+
+const epsilon = 1.0 / (1 << 53)
+
+var (
+	orientErrBound   = (3 + 16*epsilon) * epsilon
+	inCircleErrBound = (10 + 96*epsilon) * epsilon
+	orient3dErrBound = (7 + 56*epsilon) * epsilon
+	inSphereErrBound = (16 + 224*epsilon) * epsilon
+
+	// powerErrBound bounds, with room to spare, the relative rounding error
+	// of inPowerCircle, whose lifts also subtract the weights.
+	powerErrBound = (16 + 128*epsilon) * epsilon
+
+	// circumErrBound bounds, with room to spare, the relative rounding
+	// error of the denominator in calcCircumcircle, a difference of two
+	// products of differences.
+	circumErrBound = 8 * epsilon
+)
+
+// orient returns a positive value if a, b and c are in counter-clockwise
+// order, a negative value if they are clockwise and zero if they are
+// collinear. The sign is exact.
+func orient(a, b, c Point) float64 {
+	detLeft := float64((a.X - c.X) * (b.Y - c.Y))
+	detRight := float64((a.Y - c.Y) * (b.X - c.X))
+	det := detLeft - detRight
+	if math.Abs(det) >= orientErrBound*(math.Abs(detLeft)+math.Abs(detRight)) {
+		return det
+	}
+	return orientExact(a, b, c)
+}
+
+func orientExact(a, b, c Point) float64 {
+	acx, acy := twoDiff(a.X, c.X), twoDiff(a.Y, c.Y)
+	bcx, bcy := twoDiff(b.X, c.X), twoDiff(b.Y, c.Y)
+	det := expansionDiff(expansionProduct(acx, bcy), expansionProduct(acy, bcx))
+	return estimate(det)
+}
+
+// orient3d returns a positive value if d lies below the plane through a, b
+// and c, where below is the side from which a, b and c appear clockwise, a
+// negative value if it lies above and zero if the four points are coplanar.
+// The sign is exact.
+func orient3d(a, b, c, d vec3) float64 {
+	adx, ady, adz := a.x-d.x, a.y-d.y, a.z-d.z
+	bdx, bdy, bdz := b.x-d.x, b.y-d.y, b.z-d.z
+	cdx, cdy, cdz := c.x-d.x, c.y-d.y, c.z-d.z
+
+	bdxcdy, cdxbdy := float64(bdx*cdy), float64(cdx*bdy)
+	cdxady, adxcdy := float64(cdx*ady), float64(adx*cdy)
+	adxbdy, bdxady := float64(adx*bdy), float64(bdx*ady)
+
+	det := float64(adz*(bdxcdy-cdxbdy)) + float64(bdz*(cdxady-adxcdy)) + float64(cdz*(adxbdy-bdxady))
+	permanent := float64((math.Abs(bdxcdy)+math.Abs(cdxbdy))*math.Abs(adz)) +
+		float64((math.Abs(cdxady)+math.Abs(adxcdy))*math.Abs(bdz)) +
+		float64((math.Abs(adxbdy)+math.Abs(bdxady))*math.Abs(cdz))
+	if math.Abs(det) > orient3dErrBound*permanent {
+		return det
+	}
+	return orient3dExact(a, b, c, d)
+}
+
+func orient3dExact(a, b, c, d vec3) float64 {
+	adx, ady, adz := twoDiff(a.x, d.x), twoDiff(a.y, d.y), twoDiff(a.z, d.z)
+	bdx, bdy, bdz := twoDiff(b.x, d.x), twoDiff(b.y, d.y), twoDiff(b.z, d.z)
+	cdx, cdy, cdz := twoDiff(c.x, d.x), twoDiff(c.y, d.y), twoDiff(c.z, d.z)
+
+	minor := func(x1, y1, x2, y2 []float64) []float64 {
+		return expansionDiff(expansionProduct(x1, y2), expansionProduct(y1, x2))
+	}
+
+	det := expansionProduct(adz, minor(bdx, bdy, cdx, cdy))
+	det = expansionSum(det, expansionProduct(bdz, minor(cdx, cdy, adx, ady)))
+	det = expansionSum(det, expansionProduct(cdz, minor(adx, ady, bdx, bdy)))
+	return estimate(det)
+}
+
+// inCircle returns a positive value if d lies inside the circle through a,
+// b and c, a negative value if it lies outside and zero if it lies on the
+// circle, provided a, b and c are in counter-clockwise order. The sign is
+// exact.
+func inCircle(a, b, c, d Point) float64 {
+	adx, ady := a.X-d.X, a.Y-d.Y
+	bdx, bdy := b.X-d.X, b.Y-d.Y
+	cdx, cdy := c.X-d.X, c.Y-d.Y
+
+	bdxcdy, cdxbdy := float64(bdx*cdy), float64(cdx*bdy)
+	alift := float64(adx*adx) + float64(ady*ady)
+	cdxady, adxcdy := float64(cdx*ady), float64(adx*cdy)
+	blift := float64(bdx*bdx) + float64(bdy*bdy)
+	adxbdy, bdxady := float64(adx*bdy), float64(bdx*ady)
+	clift := float64(cdx*cdx) + float64(cdy*cdy)
+
+	det := float64(alift*(bdxcdy-cdxbdy)) + float64(blift*(cdxady-adxcdy)) + float64(clift*(adxbdy-bdxady))
+	permanent := float64((math.Abs(bdxcdy)+math.Abs(cdxbdy))*alift) +
+		float64((math.Abs(cdxady)+math.Abs(adxcdy))*blift) +
+		float64((math.Abs(adxbdy)+math.Abs(bdxady))*clift)
+	if math.Abs(det) > inCircleErrBound*permanent {
+		return det
+	}
+	return inCircleExact(a, b, c, d)
+}
+
+func inCircleExact(a, b, c, d Point) float64 {
+	adx, ady := twoDiff(a.X, d.X), twoDiff(a.Y, d.Y)
+	bdx, bdy := twoDiff(b.X, d.X), twoDiff(b.Y, d.Y)
+	cdx, cdy := twoDiff(c.X, d.X), twoDiff(c.Y, d.Y)
+
+	lift := func(x, y []float64) []float64 {
+		return expansionSum(expansionProduct(x, x), expansionProduct(y, y))
+	}
+	minor := func(x1, y1, x2, y2 []float64) []float64 {
+		return expansionDiff(expansionProduct(x1, y2), expansionProduct(y1, x2))
+	}
+
+	det := expansionProduct(lift(adx, ady), minor(bdx, bdy, cdx, cdy))
+	det = expansionSum(det, expansionProduct(lift(bdx, bdy), minor(cdx, cdy, adx, ady)))
+	det = expansionSum(det, expansionProduct(lift(cdx, cdy), minor(adx, ady, bdx, bdy)))
+	return estimate(det)
+}
+
+// inPowerCircle is inCircle for weighted points, each lifted to
+// x²+y²-w. It returns a positive value if d lies inside the power circle of
+// a, b and c, the circle orthogonal to theirs, so that the triangle abc is
+// not in the weighted Delaunay triangulation once d is added, a negative
+// value if it lies outside and zero if it lies on it, provided orient(a, b,
+// c) is positive. With equal weights it is inCircle. The sign is exact.
+func inPowerCircle(a, b, c, d WeightedPoint) float64 {
+	adx, ady := a.X-d.X, a.Y-d.Y
+	bdx, bdy := b.X-d.X, b.Y-d.Y
+	cdx, cdy := c.X-d.X, c.Y-d.Y
+
+	bdxcdy, cdxbdy := float64(bdx*cdy), float64(cdx*bdy)
+	cdxady, adxcdy := float64(cdx*ady), float64(adx*cdy)
+	adxbdy, bdxady := float64(adx*bdy), float64(bdx*ady)
+	asq := float64(adx*adx) + float64(ady*ady)
+	bsq := float64(bdx*bdx) + float64(bdy*bdy)
+	csq := float64(cdx*cdx) + float64(cdy*cdy)
+	alift, blift, clift := asq-(a.W-d.W), bsq-(b.W-d.W), csq-(c.W-d.W)
+
+	det := float64(alift*(bdxcdy-cdxbdy)) + float64(blift*(cdxady-adxcdy)) + float64(clift*(adxbdy-bdxady))
+	dw := math.Abs(d.W)
+	permanent := float64((math.Abs(bdxcdy)+math.Abs(cdxbdy))*(asq+math.Abs(a.W)+dw)) +
+		float64((math.Abs(cdxady)+math.Abs(adxcdy))*(bsq+math.Abs(b.W)+dw)) +
+		float64((math.Abs(adxbdy)+math.Abs(bdxady))*(csq+math.Abs(c.W)+dw))
+	if math.Abs(det) > powerErrBound*permanent {
+		return det
+	}
+	return inPowerCircleExact(a, b, c, d)
+}
+
+func inPowerCircleExact(a, b, c, d WeightedPoint) float64 {
+	adx, ady := twoDiff(a.X, d.X), twoDiff(a.Y, d.Y)
+	bdx, bdy := twoDiff(b.X, d.X), twoDiff(b.Y, d.Y)
+	cdx, cdy := twoDiff(c.X, d.X), twoDiff(c.Y, d.Y)
+
+	lift := func(x, y []float64, w float64) []float64 {
+		sq := expansionSum(expansionProduct(x, x), expansionProduct(y, y))
+		return expansionDiff(sq, twoDiff(w, d.W))
+	}
+	minor := func(x1, y1, x2, y2 []float64) []float64 {
+		return expansionDiff(expansionProduct(x1, y2), expansionProduct(y1, x2))
+	}
+
+	det := expansionProduct(lift(adx, ady, a.W), minor(bdx, bdy, cdx, cdy))
+	det = expansionSum(det, expansionProduct(lift(bdx, bdy, b.W), minor(cdx, cdy, adx, ady)))
+	det = expansionSum(det, expansionProduct(lift(cdx, cdy, c.W), minor(adx, ady, bdx, bdy)))
+	return estimate(det)
+}
+
+// inSphere returns a positive value if e lies inside the sphere through a,
+// b, c and d, a negative value if it lies outside and zero if it lies on the
+// sphere, provided orient3d(a, b, c, d) is positive. The sign is exact.
+func inSphere(a, b, c, d, e vec3) float64 {
+	aex, aey, aez := a.x-e.x, a.y-e.y, a.z-e.z
+	bex, bey, bez := b.x-e.x, b.y-e.y, b.z-e.z
+	cex, cey, cez := c.x-e.x, c.y-e.y, c.z-e.z
+	dex, dey, dez := d.x-e.x, d.y-e.y, d.z-e.z
+
+	aexbey, bexaey := float64(aex*bey), float64(bex*aey)
+	bexcey, cexbey := float64(bex*cey), float64(cex*bey)
+	cexdey, dexcey := float64(cex*dey), float64(dex*cey)
+	dexaey, aexdey := float64(dex*aey), float64(aex*dey)
+	aexcey, cexaey := float64(aex*cey), float64(cex*aey)
+	bexdey, dexbey := float64(bex*dey), float64(dex*bey)
+	ab, bc, cd, da := aexbey-bexaey, bexcey-cexbey, cexdey-dexcey, dexaey-aexdey
+	ac, bd := aexcey-cexaey, bexdey-dexbey
+
+	abc := float64(aez*bc) - float64(bez*ac) + float64(cez*ab)
+	bcd := float64(bez*cd) - float64(cez*bd) + float64(dez*bc)
+	cda := float64(cez*da) + float64(dez*ac) + float64(aez*cd)
+	dab := float64(dez*ab) + float64(aez*bd) + float64(bez*da)
+	alift := float64(aex*aex) + float64(aey*aey) + float64(aez*aez)
+	blift := float64(bex*bex) + float64(bey*bey) + float64(bez*bez)
+	clift := float64(cex*cex) + float64(cey*cey) + float64(cez*cez)
+	dlift := float64(dex*dex) + float64(dey*dey) + float64(dez*dez)
+
+	det := (float64(dlift*abc) - float64(clift*dab)) + (float64(blift*cda) - float64(alift*bcd))
+
+	abs := math.Abs
+	abP, bcP, cdP := abs(aexbey)+abs(bexaey), abs(bexcey)+abs(cexbey), abs(cexdey)+abs(dexcey)
+	daP, acP, bdP := abs(dexaey)+abs(aexdey), abs(aexcey)+abs(cexaey), abs(bexdey)+abs(dexbey)
+	permanent := float64((float64(cdP*abs(bez))+float64(bdP*abs(cez))+float64(bcP*abs(dez)))*alift) +
+		float64((float64(daP*abs(cez))+float64(acP*abs(dez))+float64(cdP*abs(aez)))*blift) +
+		float64((float64(abP*abs(dez))+float64(bdP*abs(aez))+float64(daP*abs(bez)))*clift) +
+		float64((float64(bcP*abs(aez))+float64(acP*abs(bez))+float64(abP*abs(cez)))*dlift)
+	if abs(det) > inSphereErrBound*permanent {
+		return det
+	}
+	return inSphereExact(a, b, c, d, e)
+}
+
+func inSphereExact(a, b, c, d, e vec3) float64 {
+	type diff struct{ x, y, z []float64 }
+	sub := func(p vec3) diff {
+		return diff{twoDiff(p.x, e.x), twoDiff(p.y, e.y), twoDiff(p.z, e.z)}
+	}
+	ae, be, ce, de := sub(a), sub(b), sub(c), sub(d)
+
+	minor := func(p, q diff) []float64 {
+		return expansionDiff(expansionProduct(p.x, q.y), expansionProduct(q.x, p.y))
+	}
+	ab, bc, cd, da := minor(ae, be), minor(be, ce), minor(ce, de), minor(de, ae)
+	ac, bd := minor(ae, ce), minor(be, de)
+
+	// sum3 returns p.z*x + q.z*y + r.z*z.
+	sum3 := func(p diff, x []float64, q diff, y []float64, r diff, z []float64) []float64 {
+		s := expansionSum(expansionProduct(p.z, x), expansionProduct(q.z, y))
+		return expansionSum(s, expansionProduct(r.z, z))
+	}
+	neg := func(x []float64) []float64 { return expansionDiff([]float64{0}, x) }
+	abc := sum3(ae, bc, be, neg(ac), ce, ab)
+	bcd := sum3(be, cd, ce, neg(bd), de, bc)
+	cda := sum3(ce, da, de, ac, ae, cd)
+	dab := sum3(de, ab, ae, bd, be, da)
+
+	lift := func(p diff) []float64 {
+		s := expansionSum(expansionProduct(p.x, p.x), expansionProduct(p.y, p.y))
+		return expansionSum(s, expansionProduct(p.z, p.z))
+	}
+	det := expansionDiff(expansionProduct(lift(de), abc), expansionProduct(lift(ce), dab))
+	det = expansionSum(det, expansionProduct(lift(be), cda))
+	det = expansionDiff(det, expansionProduct(lift(ae), bcd))
+	return estimate(det)
+}
+
+// twoSum returns a+b as the expansion [err, sum].
+func twoSum(a, b float64) (sum, err float64) {
+	sum = a + b
+	bv := sum - a
+	av := sum - bv
+	err = (a - av) + (b - bv)
+	return sum, err
+}
+
+// twoDiff returns the exact expansion of a-b.
+func twoDiff(a, b float64) []float64 {
+	x, y := twoSum(a, -b)
+	return []float64{y, x}
+}
+
+// twoProduct returns a*b as the expansion [err, product].
+func twoProduct(a, b float64) (product, err float64) {
+	product = float64(a * b)
+	return product, math.FMA(a, b, -product)
+}
+
+// growExpansion returns the expansion e+b, omitting zero components.
+func growExpansion(e []float64, b float64) []float64 {
+	h := make([]float64, 0, len(e)+1)
+	q := b
+	for _, x := range e {
+		var err float64
+		q, err = twoSum(q, x)
+		if err != 0 {
+			h = append(h, err)
+		}
+	}
+	if q != 0 || len(h) == 0 {
+		h = append(h, q)
+	}
+	return h
+}
+
+// expansionSum returns the expansion e+f.
+func expansionSum(e, f []float64) []float64 {
+	for _, x := range f {
+		e = growExpansion(e, x)
+	}
+	return e
+}
+
+// expansionDiff returns the expansion e-f.
+func expansionDiff(e, f []float64) []float64 {
+	for _, x := range f {
+		e = growExpansion(e, -x)
+	}
+	return e
+}
+
+// scaleExpansion returns the expansion e*b, omitting zero components.
+func scaleExpansion(e []float64, b float64) []float64 {
+	h := make([]float64, 0, 2*len(e))
+	q, err := twoProduct(e[0], b)
+	if err != 0 {
+		h = append(h, err)
+	}
+	for _, x := range e[1:] {
+		hi, lo := twoProduct(x, b)
+		sum, err := twoSum(q, lo)
+		if err != 0 {
+			h = append(h, err)
+		}
+		q, err = twoSum(hi, sum)
+		if err != 0 {
+			h = append(h, err)
+		}
+	}
+	if q != 0 || len(h) == 0 {
+		h = append(h, q)
+	}
+	return h
+}
+
+// expansionProduct returns the expansion e*f.
+func expansionProduct(e, f []float64) []float64 {
+	result := []float64{0}
+	for _, x := range f {
+		result = expansionSum(result, scaleExpansion(e, x))
+	}
+	return result
+}
+
+// estimate returns the largest component of the expansion e, which has the
+// sign of e.
+func estimate(e []float64) float64 {
+	return e[len(e)-1]
+}
+
+// inCirclePerturbed reports whether d lies strictly inside the circle through
+// a, b and c, which must be in counter-clockwise order, breaking ties with a
+// symbolic perturbation so that no four distinct points are ever
+// cocircular. A point equal to a vertex is never inside.
+//
+// Each point's lifted coordinate x²+y² is raised by an infinitesimal amount,
+// larger for points later in (X, Y) order, so the lift of the last point
+// dominates. When the unperturbed determinant is zero its sign is decided by
+// the derivative with respect to the dominant perturbation, which is an
+// orientation of the other three points. If those are collinear the next
+// largest perturbation decides instead. The result depends only on the
+// coordinates, so it is the same whatever order points are inserted.
+func inCirclePerturbed(a, b, c, d Point) bool {
+	if det := inCircle(a, b, c, d); det != 0 {
+		return det > 0
+	}
+	if d == a || d == b || d == c {
+		return false
+	}
+	ps := [4]Point{a, b, c, d}
+	var done [4]bool
+	for range ps {
+		k := -1
+		for i, p := range ps {
+			if !done[i] && (k < 0 || lexLess(ps[k], p)) {
+				k = i
+			}
+		}
+		done[k] = true
+
+		// The derivative of the determinant with respect to the lift of
+		// point k. Raising d's lift moves it outside the circle.
+		var s float64
+		switch k {
+		case 0:
+			s = orient(d, b, c)
+		case 1:
+			s = orient(a, d, c)
+		case 2:
+			s = orient(a, b, d)
+		case 3:
+			s = -orient(a, b, c)
+		}
+		if s != 0 {
+			return s > 0
+		}
+	}
+	return false
+}
+
+// lexLess reports whether a comes before b in (X, Y) order.
+func lexLess(a, b Point) bool {
+	return a.X < b.X || a.X == b.X && a.Y < b.Y
+}
@@ -0,0 +1,381 @@
+package bowyer_watson
+
+import (
+	"math"
+	"math/big"
+	"math/rand"
+	"testing"
+)
+
+func ratSub(a, b float64) *big.Rat {
+	x, y := new(big.Rat).SetFloat64(a), new(big.Rat).SetFloat64(b)
+	return x.Sub(x, y)
+}
+
+func ratMul(a, b *big.Rat) *big.Rat {
+	return new(big.Rat).Mul(a, b)
+}
+
+func orientRat(a, b, c Point) int {
+	l := ratMul(ratSub(a.X, c.X), ratSub(b.Y, c.Y))
+	r := ratMul(ratSub(a.Y, c.Y), ratSub(b.X, c.X))
+	return l.Sub(l, r).Sign()
+}
+
+func inCircleRat(a, b, c, d Point) int {
+	lift := func(p Point) *big.Rat {
+		x, y := ratSub(p.X, d.X), ratSub(p.Y, d.Y)
+		l := ratMul(x, x)
+		return l.Add(l, ratMul(y, y))
+	}
+	minor := func(p, q Point) *big.Rat {
+		l := ratMul(ratSub(p.X, d.X), ratSub(q.Y, d.Y))
+		return l.Sub(l, ratMul(ratSub(p.Y, d.Y), ratSub(q.X, d.X)))
+	}
+	det := ratMul(lift(a), minor(b, c))
+	det.Add(det, ratMul(lift(b), minor(c, a)))
+	det.Add(det, ratMul(lift(c), minor(a, b)))
+	return det.Sign()
+}
+
+func sign(x float64) int {
+	switch {
+	case x > 0:
+		return 1
+	case x < 0:
+		return -1
+	}
+	return 0
+}
+
+// nudge returns x moved by up to n ulps.
+func nudge(r *rand.Rand, x float64, n int) float64 {
+	for i := r.Intn(2*n+1) - n; i != 0; {
+		if i > 0 {
+			x = math.Nextafter(x, math.Inf(1))
+			i--
+		} else {
+			x = math.Nextafter(x, math.Inf(-1))
+			i++
+		}
+	}
+	return x
+}
+
+func TestOrient(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	wrong := 0
+	for i := 0; i < 2000; i++ {
+		// Nearly collinear points, where the naive determinant is often
+		// wrong.
+		s := r.Float64() * 100
+		a := Point{nudge(r, 0.5, 4), nudge(r, 0.5, 4)}
+		b := Point{nudge(r, s, 4), nudge(r, s, 4)}
+		c := Point{nudge(r, 2*s, 4), nudge(r, 2*s, 4)}
+		want := orientRat(a, b, c)
+		if got := sign(orient(a, b, c)); got != want {
+			t.Fatalf("orient(%v, %v, %v): got %v, want %v", a, b, c, got, want)
+		}
+		if sign(cross(a, b, c)) != want {
+			wrong++
+		}
+	}
+	if wrong == 0 {
+		t.Error("test inputs are not hard enough to defeat the naive determinant")
+	}
+}
+
+func TestInCircle(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	wrong := 0
+	for i := 0; i < 2000; i++ {
+		// Nearly cocircular points.
+		center := Point{r.Float64() * 10, r.Float64() * 10}
+		radius := r.Float64()*100 + 1
+		var ps [4]Point
+		for j := range ps {
+			theta := r.Float64() * 2 * math.Pi
+			ps[j] = Point{
+				nudge(r, center.X+radius*math.Cos(theta), 2),
+				nudge(r, center.Y+radius*math.Sin(theta), 2),
+			}
+		}
+		a, b, c, d := ps[0], ps[1], ps[2], ps[3]
+		if orientRat(a, b, c) < 0 {
+			b, c = c, b
+		}
+		want := inCircleRat(a, b, c, d)
+		if got := sign(inCircle(a, b, c, d)); got != want {
+			t.Fatalf("inCircle(%v, %v, %v, %v): got %v, want %v", a, b, c, d, got, want)
+		}
+		adx, ady := a.X-d.X, a.Y-d.Y
+		bdx, bdy := b.X-d.X, b.Y-d.Y
+		cdx, cdy := c.X-d.X, c.Y-d.Y
+		naive := (adx*adx+ady*ady)*(bdx*cdy-bdy*cdx) + (bdx*bdx+bdy*bdy)*(cdx*ady-cdy*adx) + (cdx*cdx+cdy*cdy)*(adx*bdy-ady*bdx)
+		if sign(naive) != want {
+			wrong++
+		}
+	}
+	if wrong == 0 {
+		t.Error("test inputs are not hard enough to defeat the naive determinant")
+	}
+
+	// Exactly cocircular points.
+	a, b, c, d := Point{5, 0}, Point{0, 5}, Point{-3, -4}, Point{4, -3}
+	if got := inCircle(a, b, c, d); got != 0 {
+		t.Errorf("cocircular: got %v, want 0", got)
+	}
+}
+
+func TestOrient3d(t *testing.T) {
+	a, b, c := vec3{0, 0, 0}, vec3{1, 0, 0}, vec3{0, 1, 0}
+	if got := orient3d(a, b, c, vec3{0, 0, -1}); got <= 0 {
+		t.Errorf("below: got %v, want > 0", got)
+	}
+	if got := orient3d(a, b, c, vec3{0, 0, 1}); got >= 0 {
+		t.Errorf("above: got %v, want < 0", got)
+	}
+
+	// Points nearly on a plane, where the naive determinant is often
+	// wrong.
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 1000; i++ {
+		p := vec3{nudge(r, 0.5, 4), nudge(r, 0.5, 4), nudge(r, 0.5, 4)}
+		s := r.Float64() * 100
+		q := vec3{nudge(r, s, 4), nudge(r, 0.5, 4), nudge(r, s, 4)}
+		u := vec3{nudge(r, 0.5, 4), nudge(r, s, 4), nudge(r, s, 4)}
+		w := vec3{nudge(r, s, 4), nudge(r, s, 4), nudge(r, 2*s-0.5, 4)}
+		want := orient3dRat(p, q, u, w)
+		if got := sign(orient3d(p, q, u, w)); got != want {
+			t.Fatalf("orient3d(%v, %v, %v, %v): got %v, want %v", p, q, u, w, got, want)
+		}
+	}
+}
+
+func orient3dRat(a, b, c, d vec3) int {
+	minor := func(p, q vec3) *big.Rat {
+		l := ratMul(ratSub(p.x, d.x), ratSub(q.y, d.y))
+		return l.Sub(l, ratMul(ratSub(p.y, d.y), ratSub(q.x, d.x)))
+	}
+	det := ratMul(ratSub(a.z, d.z), minor(b, c))
+	det.Add(det, ratMul(ratSub(b.z, d.z), minor(c, a)))
+	det.Add(det, ratMul(ratSub(c.z, d.z), minor(a, b)))
+	return det.Sign()
+}
+
+func BenchmarkInCircle(b *testing.B) {
+	a, c, d, p := Point{0, 0}, Point{1, 0}, Point{0, 1}, Point{0.3, 0.3}
+	for i := 0; i < b.N; i++ {
+		inCircle(a, c, d, p)
+	}
+}
+
+// inCirclePerturbedRat evaluates the in-circle determinant exactly with the
+// lift of each point raised by eps^rank, where rank is 1 for the last point
+// in (X, Y) order and 4 for the first.
+func inCirclePerturbedRat(a, b, c, d Point) bool {
+	ps := []Point{a, b, c, d}
+	eps := big.NewRat(1, 1e15)
+	lift := make([]*big.Rat, 4)
+	for i, p := range ps {
+		rank := 1
+		for _, q := range ps {
+			if lexLess(p, q) {
+				rank++
+			}
+		}
+		delta := big.NewRat(1, 1)
+		for j := 0; j < rank; j++ {
+			delta.Mul(delta, eps)
+		}
+		x, y := new(big.Rat).SetFloat64(p.X), new(big.Rat).SetFloat64(p.Y)
+		lift[i] = ratMul(x, x)
+		lift[i].Add(lift[i], ratMul(y, y))
+		lift[i].Add(lift[i], delta)
+	}
+	row := func(i int) [3]*big.Rat {
+		z := new(big.Rat).Sub(lift[i], lift[3])
+		return [3]*big.Rat{ratSub(ps[i].X, d.X), ratSub(ps[i].Y, d.Y), z}
+	}
+	r0, r1, r2 := row(0), row(1), row(2)
+	minor := func(u, v [3]*big.Rat, i, j int) *big.Rat {
+		m := ratMul(u[i], v[j])
+		return m.Sub(m, ratMul(u[j], v[i]))
+	}
+	det := ratMul(r0[0], minor(r1, r2, 1, 2))
+	det.Sub(det, ratMul(r0[1], minor(r1, r2, 0, 2)))
+	det.Add(det, ratMul(r0[2], minor(r1, r2, 0, 1)))
+	return det.Sign() > 0
+}
+
+func TestInCirclePerturbed(t *testing.T) {
+	// Points on the circle x²+y² = 25 and the grid around it, so that many
+	// quadruples are exactly cocircular or have collinear triples.
+	var ps []Point
+	for x := -5; x <= 5; x++ {
+		for y := -5; y <= 5; y++ {
+			if x*x+y*y == 25 || x >= -1 && x <= 1 && y >= -1 && y <= 1 {
+				ps = append(ps, Point{float64(x), float64(y)})
+			}
+		}
+	}
+	r := rand.New(rand.NewSource(1))
+	ties := 0
+	for i := 0; i < 5000; i++ {
+		a, b, c, d := ps[r.Intn(len(ps))], ps[r.Intn(len(ps))], ps[r.Intn(len(ps))], ps[r.Intn(len(ps))]
+		o := orient(a, b, c)
+		if o == 0 || d == a || d == b || d == c {
+			continue
+		}
+		if o < 0 {
+			b, c = c, b
+		}
+		if inCircle(a, b, c, d) == 0 {
+			ties++
+		}
+		if got, want := inCirclePerturbed(a, b, c, d), inCirclePerturbedRat(a, b, c, d); got != want {
+			t.Errorf("inCirclePerturbed(%v, %v, %v, %v): got %v, want %v", a, b, c, d, got, want)
+		}
+	}
+	if ties == 0 {
+		t.Error("no cocircular inputs were tested")
+	}
+}
+
+func inPowerCircleRat(a, b, c, d WeightedPoint) int {
+	lift := func(p WeightedPoint) *big.Rat {
+		x, y := ratSub(p.X, d.X), ratSub(p.Y, d.Y)
+		l := ratMul(x, x)
+		l.Add(l, ratMul(y, y))
+		return l.Sub(l, ratSub(p.W, d.W))
+	}
+	minor := func(p, q WeightedPoint) *big.Rat {
+		l := ratMul(ratSub(p.X, d.X), ratSub(q.Y, d.Y))
+		return l.Sub(l, ratMul(ratSub(p.Y, d.Y), ratSub(q.X, d.X)))
+	}
+	det := ratMul(lift(a), minor(b, c))
+	det.Add(det, ratMul(lift(b), minor(c, a)))
+	det.Add(det, ratMul(lift(c), minor(a, b)))
+	return det.Sign()
+}
+
+func TestInPowerCircle(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 2000; i++ {
+		// Weights that nearly make a circle about o orthogonal to all four
+		// weighted points.
+		o := Point{r.Float64() * 10, r.Float64() * 10}
+		radius2 := r.Float64() * 100
+		var ps [4]WeightedPoint
+		for j := range ps {
+			p := Point{o.X + r.Float64()*20 - 10, o.Y + r.Float64()*20 - 10}
+			w := sqr(p.X-o.X) + sqr(p.Y-o.Y) - radius2
+			ps[j] = WeightedPoint{p.X, p.Y, nudge(r, w, 2)}
+		}
+		a, b, c, d := ps[0], ps[1], ps[2], ps[3]
+		switch orientRat(a.Point(), b.Point(), c.Point()) {
+		case 0:
+			continue
+		case -1:
+			b, c = c, b
+		}
+		want := inPowerCircleRat(a, b, c, d)
+		if got := sign(inPowerCircle(a, b, c, d)); got != want {
+			t.Fatalf("inPowerCircle(%v, %v, %v, %v): got %v, want %v", a, b, c, d, got, want)
+		}
+	}
+
+	// Equal weights reduce to inCircle.
+	a, b, c, d := Point{5, 0}, Point{0, 5}, Point{-3, -4}, Point{1, 1}
+	w := func(p Point) WeightedPoint { return WeightedPoint{p.X, p.Y, 7} }
+	if got, want := sign(inPowerCircle(w(a), w(b), w(c), w(d))), sign(inCircle(a, b, c, d)); got != want {
+		t.Errorf("equal weights: got %v, want %v", got, want)
+	}
+	// The origin has power 24 with respect to the unit circles about a, b
+	// and c, so d there conflicts with them when its weight exceeds -24.
+	d = Point{0, 0}
+	one := func(p Point) WeightedPoint { return WeightedPoint{p.X, p.Y, 1} }
+	for _, tc := range []struct {
+		w    float64
+		want int
+	}{{-24, 0}, {-25, -1}, {-23, 1}} {
+		if got := sign(inPowerCircle(one(a), one(b), one(c), WeightedPoint{d.X, d.Y, tc.w})); got != tc.want {
+			t.Errorf("weight %v: got %v, want %v", tc.w, got, tc.want)
+		}
+	}
+}
+
+func TestInSphere(t *testing.T) {
+	a, b, c, d := vec3{0, 0, 0}, vec3{1, 0, 0}, vec3{0, 1, 0}, vec3{0, 0, -1}
+	if got := inSphere(a, b, c, d, vec3{0.2, 0.2, -0.2}); got <= 0 {
+		t.Errorf("inside: got %v, want > 0", got)
+	}
+	if got := inSphere(a, b, c, d, vec3{2, 2, 2}); got >= 0 {
+		t.Errorf("outside: got %v, want < 0", got)
+	}
+	if got := inSphere(a, b, c, d, vec3{1, 1, 0}); got != 0 {
+		t.Errorf("on the sphere: got %v, want 0", got)
+	}
+
+	// Points nearly on a sphere, where the naive determinant is often
+	// wrong.
+	r := rand.New(rand.NewSource(1))
+	wrong := 0
+	for i := 0; i < 1000; i++ {
+		center := vec3{r.Float64() * 100, r.Float64() * 100, r.Float64() * 100}
+		radius := r.Float64() * 10
+		var ps [5]vec3
+		for j := range ps {
+			theta, phi := r.Float64()*2*math.Pi, math.Acos(2*r.Float64()-1)
+			ps[j] = vec3{
+				nudge(r, center.x+radius*math.Sin(phi)*math.Cos(theta), 2),
+				nudge(r, center.y+radius*math.Sin(phi)*math.Sin(theta), 2),
+				nudge(r, center.z+radius*math.Cos(phi), 2),
+			}
+		}
+		a, b, c, d, e := ps[0], ps[1], ps[2], ps[3], ps[4]
+		if orient3dRat(a, b, c, d) < 0 {
+			b, c = c, b
+		}
+		want := inSphereRat(a, b, c, d, e)
+		if got := sign(inSphere(a, b, c, d, e)); got != want {
+			t.Fatalf("inSphere(%v, %v, %v, %v, %v): got %v, want %v", a, b, c, d, e, got, want)
+		}
+		if inSphereErrBound = 0; sign(inSphere(a, b, c, d, e)) != want {
+			wrong++
+		}
+		inSphereErrBound = (16 + 224*epsilon) * epsilon
+	}
+	if wrong == 0 {
+		t.Error("test inputs are not hard enough to defeat the floating-point determinant")
+	}
+}
+
+func inSphereRat(a, b, c, d, e vec3) int {
+	type row struct{ x, y, z, lift *big.Rat }
+	rows := make([]row, 4)
+	for i, p := range []vec3{a, b, c, d} {
+		x, y, z := ratSub(p.x, e.x), ratSub(p.y, e.y), ratSub(p.z, e.z)
+		lift := ratMul(x, x)
+		lift.Add(lift, ratMul(y, y))
+		lift.Add(lift, ratMul(z, z))
+		rows[i] = row{x, y, z, lift}
+	}
+	// det3 is the determinant of the x, y and z columns of rows i, j, k.
+	det3 := func(i, j, k int) *big.Rat {
+		p, q, s := rows[i], rows[j], rows[k]
+		minor := func(u, v, w, x *big.Rat) *big.Rat {
+			m := ratMul(u, v)
+			return m.Sub(m, ratMul(w, x))
+		}
+		det := ratMul(p.x, minor(q.y, s.z, q.z, s.y))
+		det.Sub(det, ratMul(p.y, minor(q.x, s.z, q.z, s.x)))
+		return det.Add(det, ratMul(p.z, minor(q.x, s.y, q.y, s.x)))
+	}
+	// Expand along the lift column.
+	det := ratMul(rows[3].lift, det3(0, 1, 2))
+	det.Sub(det, ratMul(rows[2].lift, det3(0, 1, 3)))
+	det.Add(det, ratMul(rows[1].lift, det3(0, 2, 3)))
+	det.Sub(det, ratMul(rows[0].lift, det3(1, 2, 3)))
+	return det.Sign()
+}
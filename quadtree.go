@@ -0,0 +1,240 @@
+package bowyer_watson
+
+import (
+	"container/heap"
+	"math"
+)
+
+// DefaultQuadtreeCapacity is the node capacity used by NewQuadtree when it
+// is given a capacity less than one.
+const DefaultQuadtreeCapacity = 8
+
+// quadtreeMaxDepth limits how often a node is split, so that many equal
+// points cannot cause unbounded splitting. Nodes at this depth hold any
+// number of points.
+const quadtreeMaxDepth = 48
+
+// Quadtree is a point index that adapts to the distribution of its points:
+// each node covers a rectangle and is split into four quadrants when it
+// holds more points than its capacity, so dense regions are subdivided
+// finely and sparse regions are not. The tree is rebuilt over larger
+// bounds when a point is inserted outside them.
+//
+// The zero value is not usable; create one with NewQuadtree.
+type Quadtree struct {
+	root     *quadNode
+	capacity int
+	size     int
+}
+
+// A quadNode is a leaf holding points, or an interior node with four
+// children covering the quadrants of bounds.
+type quadNode struct {
+	bounds   BoundingBox
+	depth    int
+	points   []Point
+	children *[4]quadNode // south-west, south-east, north-west, north-east
+}
+
+// NewQuadtree returns an empty Quadtree over bounds whose nodes split when
+// they hold more than capacity points.
+func NewQuadtree(bounds BoundingBox, capacity int) *Quadtree {
+	if capacity < 1 {
+		capacity = DefaultQuadtreeCapacity
+	}
+	if !(bounds.Min.X <= bounds.Max.X && bounds.Min.Y <= bounds.Max.Y) {
+		bounds = BoundingBox{}
+	}
+	return &Quadtree{root: &quadNode{bounds: bounds}, capacity: capacity}
+}
+
+// Len returns the number of points in q.
+func (q *Quadtree) Len() int { return q.size }
+
+// Insert adds p to q. Equal points are stored separately, and points with
+// a NaN or infinite coordinate are ignored.
+func (q *Quadtree) Insert(p Point) {
+	if math.IsNaN(p.X) || math.IsNaN(p.Y) || math.IsInf(p.X, 0) || math.IsInf(p.Y, 0) {
+		return
+	}
+	for !q.root.bounds.Contains(p) {
+		q.grow(p)
+	}
+	n := q.root
+	for n.children != nil {
+		n = &n.children[n.quadrant(p)]
+	}
+	n.points = append(n.points, p)
+	q.size++
+	if len(n.points) > q.capacity && n.depth < quadtreeMaxDepth {
+		n.split(q.capacity)
+	}
+}
+
+// grow rebuilds q over bounds enlarged to contain p with room to spare, so
+// that repeated growth takes amortized constant time per point.
+func (q *Quadtree) grow(p Point) {
+	b := q.root.bounds
+	b.Min.X, b.Min.Y = math.Min(b.Min.X, p.X), math.Min(b.Min.Y, p.Y)
+	b.Max.X, b.Max.Y = math.Max(b.Max.X, p.X), math.Max(b.Max.Y, p.Y)
+	pad := math.Max(b.Max.X-b.Min.X, b.Max.Y-b.Min.Y)
+	if pad == 0 {
+		pad = 1
+	}
+	b.Min.X, b.Min.Y = b.Min.X-pad, b.Min.Y-pad
+	b.Max.X, b.Max.Y = b.Max.X+pad, b.Max.Y+pad
+
+	points := q.PointsInRect(q.root.bounds)
+	q.root, q.size = &quadNode{bounds: b}, 0
+	for _, v := range points {
+		q.Insert(v)
+	}
+}
+
+// quadrant returns the index of the child of n whose quadrant contains p.
+func (n *quadNode) quadrant(p Point) int {
+	mid := n.mid()
+	i := 0
+	if p.X >= mid.X {
+		i |= 1
+	}
+	if p.Y >= mid.Y {
+		i |= 2
+	}
+	return i
+}
+
+func (n *quadNode) mid() Point {
+	b := n.bounds
+	return Point{b.Min.X + (b.Max.X-b.Min.X)/2, b.Min.Y + (b.Max.Y-b.Min.Y)/2}
+}
+
+// quadrantBounds returns the rectangle covered by child i of n.
+func (n *quadNode) quadrantBounds(i int) BoundingBox {
+	b, mid := n.bounds, n.mid()
+	if i&1 == 0 {
+		b.Max.X = mid.X
+	} else {
+		b.Min.X = mid.X
+	}
+	if i&2 == 0 {
+		b.Max.Y = mid.Y
+	} else {
+		b.Min.Y = mid.Y
+	}
+	return b
+}
+
+// split moves the points of the leaf n into four new children, splitting
+// them in turn while they hold more than capacity points.
+func (n *quadNode) split(capacity int) {
+	n.children = new([4]quadNode)
+	for i := range n.children {
+		n.children[i] = quadNode{bounds: n.quadrantBounds(i), depth: n.depth + 1}
+	}
+	for _, p := range n.points {
+		c := &n.children[n.quadrant(p)]
+		c.points = append(c.points, p)
+	}
+	n.points = nil
+	for i := range n.children {
+		c := &n.children[i]
+		if len(c.points) > capacity && c.depth < quadtreeMaxDepth {
+			c.split(capacity)
+		}
+	}
+}
+
+// NearestNeighbor returns the point in q nearest to p. It returns the zero
+// Point if q is empty.
+func (q *Quadtree) NearestNeighbor(p Point) Point {
+	nn := q.KNearestNeighbors(p, 1)
+	if len(nn) == 0 {
+		return Point{}
+	}
+	return nn[0]
+}
+
+// KNearestNeighbors returns the k points in q nearest to p, nearest first,
+// or all of them if q holds fewer than k. Points at equal distances are
+// returned in no particular order.
+func (q *Quadtree) KNearestNeighbors(p Point, k int) []Point {
+	if k <= 0 || q.size == 0 {
+		return nil
+	}
+	// Search best first: a node is expanded only once it is nearer than
+	// every point not yet returned.
+	h := &quadQueue{{node: q.root, dist2: boxDist2(q.root.bounds, p)}}
+	var result []Point
+	for h.Len() > 0 && len(result) < k {
+		it := heap.Pop(h).(quadItem)
+		switch n := it.node; {
+		case n == nil:
+			result = append(result, it.point)
+		case n.children == nil:
+			for _, v := range n.points {
+				heap.Push(h, quadItem{point: v, dist2: dist2(v, p)})
+			}
+		default:
+			for i := range n.children {
+				c := &n.children[i]
+				heap.Push(h, quadItem{node: c, dist2: boxDist2(c.bounds, p)})
+			}
+		}
+	}
+	return result
+}
+
+// PointsInRect returns the points of q that lie in box, including its
+// boundary.
+func (q *Quadtree) PointsInRect(box BoundingBox) []Point {
+	var result []Point
+	var visit func(n *quadNode)
+	visit = func(n *quadNode) {
+		b := n.bounds
+		if b.Max.X < box.Min.X || b.Min.X > box.Max.X || b.Max.Y < box.Min.Y || b.Min.Y > box.Max.Y {
+			return
+		}
+		for _, v := range n.points {
+			if box.Contains(v) {
+				result = append(result, v)
+			}
+		}
+		if n.children != nil {
+			for i := range n.children {
+				visit(&n.children[i])
+			}
+		}
+	}
+	visit(q.root)
+	return result
+}
+
+// boxDist2 returns the squared distance from p to the nearest point of b.
+func boxDist2(b BoundingBox, p Point) float64 {
+	dx := math.Max(0, math.Max(b.Min.X-p.X, p.X-b.Max.X))
+	dy := math.Max(0, math.Max(b.Min.Y-p.Y, p.Y-b.Max.Y))
+	return dx*dx + dy*dy
+}
+
+// quadItem is a node, or a point if node is nil, queued by
+// KNearestNeighbors.
+type quadItem struct {
+	node  *quadNode
+	point Point
+	dist2 float64
+}
+
+// quadQueue is a min-heap of quadItems ordered by distance.
+type quadQueue []quadItem
+
+func (h quadQueue) Len() int            { return len(h) }
+func (h quadQueue) Less(i, j int) bool  { return h[i].dist2 < h[j].dist2 }
+func (h quadQueue) Swap(i, j int)       { h[i], h[j] = h[j], h[i] }
+func (h *quadQueue) Push(x interface{}) { *h = append(*h, x.(quadItem)) }
+func (h *quadQueue) Pop() interface{} {
+	old := *h
+	it := old[len(old)-1]
+	*h = old[:len(old)-1]
+	return it
+}
@@ -0,0 +1,109 @@
+package bowyer_watson
+
+import (
+	"math"
+	"math/rand"
+	"sort"
+	"testing"
+)
+
+func TestQuadtree(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	bounds := BoundingBox{Min: Point{0, 0}, Max: Point{1, 1}}
+	q := NewQuadtree(bounds, 4)
+	var points []Point
+	for i := 0; i < 2000; i++ {
+		// Clustered around a few centers, with some points outside the
+		// initial bounds and some repeated.
+		c := Point{float64(i%3) * 0.4, float64(i%5) * 0.3}
+		p := Point{c.X + r.NormFloat64()*0.02, c.Y + r.NormFloat64()*0.02}
+		if i%97 == 0 {
+			p = Point{r.Float64()*10 - 5, r.Float64()*10 - 5}
+		}
+		if i%31 == 0 && len(points) > 0 {
+			p = points[r.Intn(len(points))]
+		}
+		points = append(points, p)
+		q.Insert(p)
+	}
+	if got := q.Len(); got != len(points) {
+		t.Errorf("Len: got %v, want %v", got, len(points))
+	}
+
+	for i := 0; i < 200; i++ {
+		p := Point{r.Float64()*3 - 1, r.Float64()*3 - 1}
+		k := 1 + r.Intn(20)
+		want := make([]float64, len(points))
+		for j, v := range points {
+			want[j] = dist2(v, p)
+		}
+		sort.Float64s(want)
+
+		got := q.KNearestNeighbors(p, k)
+		if len(got) != k {
+			t.Fatalf("KNearestNeighbors(%v, %v): got %d points, want %d", p, k, len(got), k)
+		}
+		for j, v := range got {
+			if d := dist2(v, p); d != want[j] {
+				t.Fatalf("KNearestNeighbors(%v, %v): point %d %v at distance² %v, want %v", p, k, j, v, d, want[j])
+			}
+		}
+		if nn := q.NearestNeighbor(p); dist2(nn, p) != want[0] {
+			t.Errorf("NearestNeighbor(%v): got %v", p, nn)
+		}
+
+		x0, y0 := r.Float64()*2-0.5, r.Float64()*2-0.5
+		box := BoundingBox{Min: Point{x0, y0}, Max: Point{x0 + r.Float64(), y0 + r.Float64()}}
+		var wantIn []Point
+		for _, v := range points {
+			if box.Contains(v) {
+				wantIn = append(wantIn, v)
+			}
+		}
+		gotIn := q.PointsInRect(box)
+		sortPoints(gotIn)
+		sortPoints(wantIn)
+		if len(gotIn) != len(wantIn) {
+			t.Fatalf("PointsInRect(%v): got %d points, want %d", box, len(gotIn), len(wantIn))
+		}
+		for j := range gotIn {
+			if gotIn[j] != wantIn[j] {
+				t.Fatalf("PointsInRect(%v): got %v, want %v", box, gotIn[j], wantIn[j])
+			}
+		}
+	}
+
+	if got := q.KNearestNeighbors(Point{}, 5000); len(got) != len(points) {
+		t.Errorf("k > Len: got %d points, want %d", len(got), len(points))
+	}
+}
+
+func sortPoints(points []Point) {
+	sort.Slice(points, func(i, j int) bool { return lexLess(points[i], points[j]) })
+}
+
+func TestQuadtreeEdgeCases(t *testing.T) {
+	q := NewQuadtree(BoundingBox{}, 0)
+	if got := q.KNearestNeighbors(Point{1, 1}, 3); got != nil {
+		t.Errorf("empty: got %v, want nil", got)
+	}
+	if got := q.NearestNeighbor(Point{1, 1}); got != (Point{}) {
+		t.Errorf("empty: got %v, want the zero Point", got)
+	}
+
+	// Many equal points cannot be separated by splitting.
+	for i := 0; i < 100; i++ {
+		q.Insert(Point{3, 4})
+	}
+	q.Insert(Point{math.NaN(), 0})
+	q.Insert(Point{0, math.Inf(1)})
+	if got := q.Len(); got != 100 {
+		t.Errorf("Len: got %v, want 100", got)
+	}
+	if got := q.PointsInRect(BoundingBox{Min: Point{3, 4}, Max: Point{3, 4}}); len(got) != 100 {
+		t.Errorf("PointsInRect: got %d points, want 100", len(got))
+	}
+	if got := q.NearestNeighbor(Point{-7, 2}); got != (Point{3, 4}) {
+		t.Errorf("NearestNeighbor: got %v, want {3 4}", got)
+	}
+}
@@ -0,0 +1,213 @@
+package bowyer_watson
+
+import (
+	"math"
+	"sort"
+)
+
+// FilterSlivers removes the triangles whose area is less than minArea from
+// a triangulation and returns the remaining triangles and the number
+// removed. The triangles must be counter-clockwise and form a conforming
+// mesh, as returned by DelaunayTriangulation.
+//
+// A sliver is removed by collapsing one of its edges, shortest first: one
+// endpoint, which must not be on the boundary of the mesh, is merged into
+// the other, and the two triangles sharing the edge disappear. An edge is
+// collapsed only if no other triangle would be turned over or flattened,
+// so the mesh still covers the same region without a hole. A sliver on the
+// boundary that cannot be collapsed is deleted, which uncovers less than
+// minArea. Any other sliver that cannot be collapsed is kept. The result
+// need not be Delaunay.
+func FilterSlivers(triangles []Triangle, minArea float64) (result []Triangle, removed int) {
+	m := newSliverMesh(triangles)
+	queue := make([]int, len(m.tris))
+	for i := range queue {
+		queue[i] = i
+	}
+	for len(queue) > 0 {
+		i := queue[0]
+		queue = queue[1:]
+		t := &m.tris[i]
+		if m.dead[i] || math.Abs(t.SignedArea()) >= minArea {
+			continue
+		}
+		changed, n := m.collapseSliver(i)
+		if n == 0 && m.onBoundary(i) {
+			m.remove(i)
+			n = 1
+		}
+		removed += n
+		queue = append(queue, changed...)
+	}
+
+	for i, t := range m.tris {
+		if !m.dead[i] {
+			result = append(result, t)
+		}
+	}
+	return result, removed
+}
+
+// sliverMesh is the mutable triangulation FilterSlivers works on.
+type sliverMesh struct {
+	tris     []Triangle
+	dead     []bool
+	incident map[Point][]int // may include dead triangles
+	edges    map[Edge]int    // the number of live triangles sharing each canonical edge
+}
+
+func newSliverMesh(triangles []Triangle) *sliverMesh {
+	m := &sliverMesh{
+		tris:     append([]Triangle(nil), triangles...),
+		dead:     make([]bool, len(triangles)),
+		incident: map[Point][]int{},
+		edges:    map[Edge]int{},
+	}
+	for i, t := range m.tris {
+		for _, v := range [3]Point{t.A, t.B, t.C} {
+			m.incident[v] = append(m.incident[v], i)
+		}
+		for _, e := range triangleEdges(t) {
+			m.edges[e]++
+		}
+	}
+	return m
+}
+
+func triangleEdges(t Triangle) [3]Edge {
+	return [3]Edge{Edge{t.A, t.B}.canonical(), Edge{t.B, t.C}.canonical(), Edge{t.C, t.A}.canonical()}
+}
+
+// remove deletes triangle i.
+func (m *sliverMesh) remove(i int) {
+	m.dead[i] = true
+	for _, e := range triangleEdges(m.tris[i]) {
+		m.edges[e]--
+	}
+}
+
+// onBoundary reports whether triangle i has an edge on the boundary.
+func (m *sliverMesh) onBoundary(i int) bool {
+	for _, e := range triangleEdges(m.tris[i]) {
+		if m.edges[e] == 1 {
+			return true
+		}
+	}
+	return false
+}
+
+// isBoundaryVertex reports whether v is an endpoint of a boundary edge.
+func (m *sliverMesh) isBoundaryVertex(v Point) bool {
+	for _, i := range m.incident[v] {
+		if m.dead[i] {
+			continue
+		}
+		t := m.tris[i]
+		for _, e := range triangleEdges(t) {
+			if (e.A == v || e.B == v) && m.edges[e] == 1 {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// neighbors returns the vertices joined to v by an edge.
+func (m *sliverMesh) neighbors(v Point) map[Point]bool {
+	ns := map[Point]bool{}
+	for _, i := range m.incident[v] {
+		if m.dead[i] {
+			continue
+		}
+		t := m.tris[i]
+		for _, u := range [3]Point{t.A, t.B, t.C} {
+			if u != v {
+				ns[u] = true
+			}
+		}
+	}
+	return ns
+}
+
+// collapseSliver collapses the shortest edge of triangle i that can be
+// collapsed, and returns the indices of the changed triangles and the
+// number removed. It returns zero removed if no edge can be collapsed.
+func (m *sliverMesh) collapseSliver(i int) (changed []int, removed int) {
+	t := m.tris[i]
+	es := [3]Edge{{t.A, t.B}, {t.B, t.C}, {t.C, t.A}}
+	sort.Slice(es[:], func(a, b int) bool { return dist2(es[a].A, es[a].B) < dist2(es[b].A, es[b].B) })
+	for _, e := range es {
+		for _, uv := range [2][2]Point{{e.A, e.B}, {e.B, e.A}} {
+			if m.canCollapse(uv[0], uv[1]) {
+				return m.collapse(uv[0], uv[1])
+			}
+		}
+	}
+	return nil, 0
+}
+
+// canCollapse reports whether v can be merged into u: v is not on the
+// boundary, u and v share exactly the two neighbors opposite their edge,
+// so the mesh stays a manifold, and every triangle that moves keeps its
+// orientation.
+func (m *sliverMesh) canCollapse(u, v Point) bool {
+	if m.isBoundaryVertex(v) {
+		return false
+	}
+	nu, common := m.neighbors(u), 0
+	for w := range m.neighbors(v) {
+		if nu[w] {
+			common++
+		}
+	}
+	if common != 2 {
+		return false
+	}
+	for _, j := range m.incident[v] {
+		t := m.tris[j]
+		if m.dead[j] || t.A == u || t.B == u || t.C == u {
+			continue
+		}
+		if t = replaceVertex(t, v, u); orient(t.A, t.B, t.C) <= 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// collapse merges v into u.
+func (m *sliverMesh) collapse(u, v Point) (changed []int, removed int) {
+	for _, j := range m.incident[v] {
+		if m.dead[j] {
+			continue
+		}
+		t := m.tris[j]
+		m.remove(j)
+		if t.A == u || t.B == u || t.C == u {
+			removed++
+			continue
+		}
+		t = replaceVertex(t, v, u)
+		m.tris[j], m.dead[j] = t, false
+		for _, e := range triangleEdges(t) {
+			m.edges[e]++
+		}
+		m.incident[u] = append(m.incident[u], j)
+		changed = append(changed, j)
+	}
+	delete(m.incident, v)
+	return changed, removed
+}
+
+// replaceVertex returns t with its vertex v replaced by u.
+func replaceVertex(t Triangle, v, u Point) Triangle {
+	switch v {
+	case t.A:
+		t.A = u
+	case t.B:
+		t.B = u
+	case t.C:
+		t.C = u
+	}
+	return t
+}
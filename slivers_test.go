@@ -0,0 +1,103 @@
+package bowyer_watson
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+// checkCover reports an error unless tris are counter-clockwise, share each
+// edge at most twice, satisfy Euler's formula and have a total area of
+// area.
+func checkCover(t *testing.T, tris []Triangle, area float64) {
+	t.Helper()
+	count := map[Edge]int{}
+	vertices := map[Point]bool{}
+	total := 0.0
+	for _, tri := range tris {
+		if orient(tri.A, tri.B, tri.C) <= 0 {
+			t.Errorf("triangle %v is not counter-clockwise", tri)
+		}
+		for _, e := range triangleEdges(tri) {
+			count[e]++
+		}
+		vertices[tri.A], vertices[tri.B], vertices[tri.C] = true, true, true
+		total += tri.SignedArea()
+	}
+	boundary := 0
+	for e, n := range count {
+		if n > 2 {
+			t.Errorf("edge %v is shared by %d triangles", e, n)
+		}
+		if n == 1 {
+			boundary++
+		}
+	}
+	if got, want := len(tris), 2*len(vertices)-2-boundary; got != want {
+		t.Errorf("#triangles: got %v, want %v for %d vertices and %d boundary edges", got, want, len(vertices), boundary)
+	}
+	if math.Abs(total-area) > 1e-9*area {
+		t.Errorf("area: got %v, want %v", total, area)
+	}
+}
+
+func TestFilterSlivers(t *testing.T) {
+	// Tight clusters scattered among random points make tiny triangles,
+	// including some at a corner of the hull.
+	r := rand.New(rand.NewSource(1))
+	points := []Point{{0, 0}, {10, 0}, {10, 10}, {0, 10}, {1e-7, 2e-7}, {3e-7, 1e-7}}
+	for i := 0; i < 100; i++ {
+		points = append(points, Point{1 + 8*r.Float64(), 1 + 8*r.Float64()})
+	}
+	for i := 0; i < 5; i++ {
+		x, y := 1+7*r.Float64(), 1+7*r.Float64()
+		for j := 0; j < 4; j++ {
+			points = append(points, Point{x + 1e-7*r.Float64(), y + 1e-7*r.Float64()})
+		}
+	}
+	triangles, err := DelaunayTriangulation(points, SuperTriangleFor(points, 10))
+	if err != nil {
+		t.Fatal(err)
+	}
+	const minArea = 1e-12
+	slivers := 0
+	for i := range triangles {
+		if math.Abs(triangles[i].SignedArea()) < minArea {
+			slivers++
+		}
+	}
+	if slivers == 0 {
+		t.Fatal("no slivers to remove")
+	}
+
+	got, removed := FilterSlivers(triangles, minArea)
+	if removed < slivers {
+		t.Errorf("removed %d triangles, want at least %d", removed, slivers)
+	}
+	if len(got) != len(triangles)-removed {
+		t.Errorf("#triangles: got %d, want %d", len(got), len(triangles)-removed)
+	}
+	for _, tri := range got {
+		if math.Abs(tri.SignedArea()) < minArea {
+			t.Errorf("sliver %v remains", tri)
+		}
+	}
+	checkCover(t, got, 100)
+
+	if got, removed := FilterSlivers(triangles, 0); removed != 0 || len(got) != len(triangles) {
+		t.Errorf("minArea 0: removed %d of %d triangles", removed, len(triangles))
+	}
+}
+
+func TestFilterSliversBoundary(t *testing.T) {
+	// The sliver along the bottom edge can be neither collapsed, since all
+	// its vertices are on the boundary, nor kept.
+	triangles := []Triangle{
+		{A: Point{0, 0}, B: Point{1, -1e-12}, C: Point{2, 0}},
+		{A: Point{0, 0}, B: Point{2, 0}, C: Point{1, 1}},
+	}
+	got, removed := FilterSlivers(triangles, 1e-6)
+	if removed != 1 || len(got) != 1 || got[0] != triangles[1] {
+		t.Errorf("got %v, removed %d, want %v, removed 1", got, removed, triangles[1:])
+	}
+}
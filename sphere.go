@@ -0,0 +1,286 @@
+package bowyer_watson
+
+import "math"
+
+// LatLon is a point on the globe given by its latitude and longitude in
+// degrees.
+type LatLon struct {
+	Lat float64 `json:"lat"`
+	Lon float64 `json:"lon"`
+}
+
+// SphericalTriangle is a triangle on the sphere whose sides are great circle
+// arcs. Its vertices are counter-clockwise when viewed from outside the
+// sphere.
+type SphericalTriangle struct {
+	A, B, C LatLon
+}
+
+// vec3 is a point or vector in three dimensions.
+type vec3 struct {
+	x, y, z float64
+}
+
+// unitVector returns the point on the unit sphere at ll.
+func (ll LatLon) unitVector() vec3 {
+	lat, lon := ll.Lat*math.Pi/180, ll.Lon*math.Pi/180
+	return vec3{math.Cos(lat) * math.Cos(lon), math.Cos(lat) * math.Sin(lon), math.Sin(lat)}
+}
+
+// normalize returns ll with its longitude in (-180, 180], or zero at the
+// poles, so that equal positions have equal coordinates. It reports false
+// if ll is not a valid position.
+func (ll LatLon) normalize() (LatLon, bool) {
+	if !(ll.Lat >= -90 && ll.Lat <= 90) || math.IsNaN(ll.Lon) || math.IsInf(ll.Lon, 0) {
+		return ll, false
+	}
+	if ll.Lat == 90 || ll.Lat == -90 {
+		return LatLon{ll.Lat, 0}, true
+	}
+	lon := math.Remainder(ll.Lon, 360)
+	if lon == -180 {
+		lon = 180
+	}
+	return LatLon{ll.Lat, lon}, true
+}
+
+// DelaunaySphere returns the Delaunay triangulation of points on the
+// sphere: no point lies strictly inside the circle on the sphere through
+// the vertices of any triangle. Unlike a planar triangulation of the
+// coordinates it is not distorted near the poles and has no seam at the
+// antimeridian. Points with an invalid latitude or longitude are ignored,
+// as are repeated positions, and nil is returned if fewer than four
+// positions remain or they all lie on one circle.
+//
+// If the points do not all fit in one open hemisphere the triangles cover
+// the whole sphere. Otherwise they cover the spherical convex hull of the
+// points, just as the planar triangulations cover the convex hull, and the
+// triangles that would close the surface across the empty side are
+// omitted.
+//
+// The points are projected onto the unit sphere, where a circle on the
+// sphere is the intersection with a plane, so the Delaunay triangles are
+// the faces of the convex hull of the projected points. The hull is
+// computed with the Quickhull algorithm of Barber, C. B., Dobkin, D. P. and
+// Huhdanpaa, H., "The Quickhull Algorithm for Convex Hulls", ACM
+// Transactions on Mathematical Software 22(4), 1996, using exact
+// orientation tests. Because the projection rounds, a point that is within
+// rounding error of the plane of a face, such as one of several nearly
+// coincident points, may be left out.
+func DelaunaySphere(points []LatLon) []SphericalTriangle {
+	var lls []LatLon
+	var vs []vec3
+	seen := map[LatLon]bool{}
+	for _, p := range points {
+		ll, ok := p.normalize()
+		if !ok || seen[ll] {
+			continue
+		}
+		seen[ll] = true
+		lls = append(lls, p)
+		vs = append(vs, ll.unitVector())
+	}
+
+	faces := convexHull3(vs)
+	origin := vec3{}
+	var result []SphericalTriangle
+	for _, f := range faces {
+		a, b, c := vs[f[0]], vs[f[1]], vs[f[2]]
+		// A face with the origin on or in front of it closes the hull
+		// across the side of the sphere with no points.
+		if orient3d(a, b, c, origin) <= 0 {
+			continue
+		}
+		result = append(result, SphericalTriangle{A: lls[f[0]], B: lls[f[1]], C: lls[f[2]]})
+	}
+	return result
+}
+
+// A hullFace is a triangular face of a convex hull in three dimensions. Its
+// vertices are counter-clockwise when viewed from outside.
+type hullFace struct {
+	v       [3]int       // vertex indices
+	adj     [3]*hullFace // adj[i] shares the edge from v[i] to v[i+1]
+	outside []int        // points in front of the face not yet added
+	visit   int          // the iteration that last tested the face
+	visible bool         // whether the point being added is in front
+	deleted bool
+}
+
+// convexHull3 returns the faces of the convex hull of pts as vertex index
+// triples, counter-clockwise when viewed from outside. The elements of pts
+// must be distinct. It returns nil if there are fewer than four points or
+// they are coplanar. Coplanar faces are split into triangles arbitrarily.
+func convexHull3(pts []vec3) [][3]int {
+	if len(pts) < 4 {
+		return nil
+	}
+	// Find a tetrahedron. No three distinct points on a sphere are
+	// collinear, but points off the sphere might be.
+	i2 := -1
+	for i := 2; i < len(pts); i++ {
+		if !collinear3(pts[0], pts[1], pts[i]) {
+			i2 = i
+			break
+		}
+	}
+	if i2 < 0 {
+		return nil
+	}
+	i3 := -1
+	for i := 2; i < len(pts); i++ {
+		if i != i2 && orient3d(pts[0], pts[1], pts[i2], pts[i]) != 0 {
+			i3 = i
+			break
+		}
+	}
+	if i3 < 0 {
+		return nil
+	}
+	tet := [4]int{0, 1, i2, i3}
+	if orient3d(pts[0], pts[1], pts[i2], pts[i3]) < 0 {
+		tet[0], tet[1] = tet[1], tet[0]
+	}
+	// Now pts[tet[3]] is behind face tet[0:3], so these faces are all
+	// counter-clockwise from outside.
+	a, b, c, d := tet[0], tet[1], tet[2], tet[3]
+	faces := []*hullFace{
+		{v: [3]int{a, b, c}},
+		{v: [3]int{a, d, b}},
+		{v: [3]int{b, d, c}},
+		{v: [3]int{c, d, a}},
+	}
+	link := func(f *hullFace, i int, g *hullFace) {
+		u, w := f.v[i], f.v[(i+1)%3]
+		for j := range g.v {
+			if g.v[j] == w && g.v[(j+1)%3] == u {
+				f.adj[i], g.adj[j] = g, f
+				return
+			}
+		}
+	}
+	for _, f := range faces {
+		for i := range f.v {
+			if f.adj[i] != nil {
+				continue
+			}
+			for _, g := range faces {
+				if g != f {
+					link(f, i, g)
+				}
+			}
+		}
+	}
+
+	front := func(f *hullFace, p int) bool {
+		return orient3d(pts[f.v[0]], pts[f.v[1]], pts[f.v[2]], pts[p]) < 0
+	}
+	assign := func(candidates []*hullFace, p int) {
+		for _, f := range candidates {
+			if front(f, p) {
+				f.outside = append(f.outside, p)
+				return
+			}
+		}
+	}
+	for p := range pts {
+		if p != a && p != b && p != c && p != d {
+			assign(faces, p)
+		}
+	}
+
+	pending := append([]*hullFace(nil), faces...)
+	for iter := 1; len(pending) > 0; iter++ {
+		f := pending[len(pending)-1]
+		pending = pending[:len(pending)-1]
+		if f.deleted || len(f.outside) == 0 {
+			continue
+		}
+
+		// Add the point furthest in front of f.
+		p, best := -1, 0.0
+		for _, q := range f.outside {
+			dist := -orient3d(pts[f.v[0]], pts[f.v[1]], pts[f.v[2]], pts[q])
+			if p < 0 || dist > best {
+				p, best = q, dist
+			}
+		}
+
+		// The faces p is in front of form a connected region bounded by the
+		// horizon, whose edges are joined to p to form the new faces.
+		type horizonEdge struct {
+			f *hullFace // the visible face
+			i int       // the edge of f on the horizon
+		}
+		var visible []*hullFace
+		var horizon []horizonEdge
+		f.visit, f.visible = iter, true
+		stack := []*hullFace{f}
+		for len(stack) > 0 {
+			g := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			visible = append(visible, g)
+			for i, n := range g.adj {
+				if n.visit != iter {
+					n.visit, n.visible = iter, front(n, p)
+					if n.visible {
+						stack = append(stack, n)
+					}
+				}
+				if !n.visible {
+					horizon = append(horizon, horizonEdge{g, i})
+				}
+			}
+		}
+
+		newFaces := make([]*hullFace, 0, len(horizon))
+		byStart := make(map[int]*hullFace, len(horizon))
+		for _, h := range horizon {
+			u, w := h.f.v[h.i], h.f.v[(h.i+1)%3]
+			n := h.f.adj[h.i]
+			nf := &hullFace{v: [3]int{u, w, p}}
+			nf.adj[0] = n
+			for j := range n.v {
+				if n.v[j] == w {
+					n.adj[j] = nf
+				}
+			}
+			newFaces = append(newFaces, nf)
+			byStart[u] = nf
+		}
+		for _, nf := range newFaces {
+			// The edge from w to p is shared with the new face starting at w.
+			g := byStart[nf.v[1]]
+			nf.adj[1], g.adj[2] = g, nf
+		}
+
+		for _, g := range visible {
+			g.deleted = true
+			for _, q := range g.outside {
+				if q != p {
+					assign(newFaces, q)
+				}
+			}
+			g.outside = nil
+		}
+		faces = append(faces, newFaces...)
+		pending = append(pending, newFaces...)
+	}
+
+	var result [][3]int
+	for _, f := range faces {
+		if !f.deleted {
+			result = append(result, f.v)
+		}
+	}
+	return result
+}
+
+// collinear3 reports whether a, b and c lie on one line.
+func collinear3(a, b, c vec3) bool {
+	// The points are collinear exactly when their projections onto each
+	// coordinate plane are.
+	return orient(Point{a.x, a.y}, Point{b.x, b.y}, Point{c.x, c.y}) == 0 &&
+		orient(Point{a.y, a.z}, Point{b.y, b.z}, Point{c.y, c.z}) == 0 &&
+		orient(Point{a.z, a.x}, Point{b.z, b.x}, Point{c.z, c.x}) == 0
+}
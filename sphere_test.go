@@ -0,0 +1,144 @@
+package bowyer_watson
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+// checkSphere reports an error if tris is not a Delaunay triangulation on
+// the sphere with want triangles and every element of points as a vertex.
+func checkSphere(t *testing.T, points []LatLon, tris []SphericalTriangle, want int) {
+	t.Helper()
+	if got := len(tris); got != want {
+		t.Errorf("#triangles: got %v, want %v", got, want)
+	}
+	vertices := map[LatLon]bool{}
+	edges := map[[2]LatLon]int{}
+	for _, tri := range tris {
+		vs := [3]LatLon{tri.A, tri.B, tri.C}
+		for i, v := range vs {
+			vertices[v] = true
+			edges[[2]LatLon{v, vs[(i+1)%3]}]++
+		}
+		a, b, c := tri.A.unitVector(), tri.B.unitVector(), tri.C.unitVector()
+		if orient3d(a, b, c, vec3{}) <= 0 {
+			t.Errorf("triangle %v: not counter-clockwise from outside", tri)
+		}
+		for _, p := range points {
+			if p == tri.A || p == tri.B || p == tri.C {
+				continue
+			}
+			if orient3d(a, b, c, p.unitVector()) < 0 {
+				t.Errorf("triangle %v: circle contains %v", tri, p)
+				break
+			}
+		}
+	}
+	for e, n := range edges {
+		if n > 1 {
+			t.Errorf("edge %v is used by %d triangles", e, n)
+		}
+	}
+	for _, p := range points {
+		if !vertices[p] {
+			t.Errorf("point %v is not a vertex", p)
+		}
+	}
+}
+
+func TestDelaunaySphere(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	points := make([]LatLon, 500)
+	for i := range points {
+		// Uniform on the sphere.
+		points[i] = LatLon{
+			Lat: math.Asin(2*r.Float64()-1) * 180 / math.Pi,
+			Lon: r.Float64()*360 - 180,
+		}
+	}
+	checkSphere(t, points, DelaunaySphere(points), 2*len(points)-4)
+}
+
+func TestDelaunaySphereOctahedron(t *testing.T) {
+	points := []LatLon{{90, 0}, {-90, 0}, {0, 0}, {0, 90}, {0, 180}, {0, -90}}
+	checkSphere(t, points, DelaunaySphere(points), 8)
+}
+
+func TestDelaunaySphereGrid(t *testing.T) {
+	// Every latitude is a circle of cocircular points.
+	points := []LatLon{{90, 0}, {-90, 0}}
+	for lat := -75.0; lat <= 75; lat += 15 {
+		for lon := -180.0; lon < 180; lon += 15 {
+			points = append(points, LatLon{lat, lon})
+		}
+	}
+	tris := DelaunaySphere(points)
+	checkSphere(t, points, tris, 2*len(points)-4)
+
+	// Longitudes that name the same position, and the poles at any
+	// longitude, are repeats.
+	dup := append([]LatLon(nil), points...)
+	dup = append(dup, LatLon{0, 180 + 360}, LatLon{15, -360}, LatLon{90, 45}, LatLon{-90, -10})
+	if got, want := len(DelaunaySphere(dup)), len(tris); got != want {
+		t.Errorf("with repeats: #triangles: got %v, want %v", got, want)
+	}
+}
+
+func TestDelaunaySphereAntimeridian(t *testing.T) {
+	// A cluster around the antimeridian, which fits in a hemisphere.
+	r := rand.New(rand.NewSource(1))
+	points := make([]LatLon, 100)
+	for i := range points {
+		lon := 175 + r.Float64()*10
+		if lon > 180 {
+			lon -= 360
+		}
+		points[i] = LatLon{Lat: r.Float64()*10 - 5, Lon: lon}
+	}
+	tris := DelaunaySphere(points)
+	if len(tris) == 0 {
+		t.Fatal("no triangles")
+	}
+	checkSphere(t, points, tris, len(tris))
+
+	// No triangle wraps around the globe.
+	for _, tri := range tris {
+		a, b, c := tri.A.unitVector(), tri.B.unitVector(), tri.C.unitVector()
+		for _, e := range [3][2]vec3{{a, b}, {b, c}, {c, a}} {
+			d := math.Sqrt(sqr(e[0].x-e[1].x) + sqr(e[0].y-e[1].y) + sqr(e[0].z-e[1].z))
+			if d > 0.5 {
+				t.Errorf("triangle %v: edge is %v long", tri, d)
+			}
+		}
+	}
+
+	// The triangles in a cap triangulate its hull: 2n - 2 - h of them,
+	// where h is the number of hull vertices, which the planar
+	// triangulation of a projection of the cap also has.
+	planar := make([]Point, len(points))
+	for i, p := range points {
+		// Gnomonic projection from the center of the cap, which maps
+		// great circles to lines.
+		v := p.unitVector()
+		planar[i] = Point{v.y / -v.x, v.z / -v.x}
+	}
+	if got, want := len(tris), len(SweepHullTriangulation(planar)); got != want {
+		t.Errorf("#triangles: got %v, want %v", got, want)
+	}
+}
+
+func TestDelaunaySphereDegenerate(t *testing.T) {
+	for _, points := range [][]LatLon{
+		nil,
+		{{0, 0}, {10, 10}, {20, 0}},
+		// A circle of latitude.
+		{{45, 0}, {45, 90}, {45, 180}, {45, -90}, {45, 10}},
+		// Repeats and invalid positions.
+		{{0, 0}, {0, 360}, {0, 0}, {91, 0}, {math.NaN(), 0}, {0, math.Inf(1)}},
+	} {
+		if got := DelaunaySphere(points); got != nil {
+			t.Errorf("%v: got %v, want nil", points, got)
+		}
+	}
+}
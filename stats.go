@@ -0,0 +1,144 @@
+package bowyer_watson
+
+import (
+	"fmt"
+	"math"
+	"strings"
+)
+
+// MeshStats summarizes a set of triangles. Angles are in degrees. The mean
+// angle is not given, since it is always 60 degrees.
+type MeshStats struct {
+	Count    int // number of triangles
+	Vertices int // number of distinct vertices
+	Edges    int // number of distinct edges
+
+	TotalArea float64
+
+	MinCircumradius, MaxCircumradius, MeanCircumradius float64
+
+	MinAngle, MaxAngle float64
+
+	// The aspect ratios are those of Triangle.AspectRatio. They are +Inf
+	// if any triangle is degenerate.
+	MinAspectRatio, MaxAspectRatio, MeanAspectRatio float64
+
+	Slivers int // number of triangles with an aspect ratio above the threshold
+}
+
+// Stats is like ComputeMeshStats but counts no slivers.
+func Stats(triangles []Triangle) MeshStats {
+	return ComputeMeshStats(triangles, math.Inf(1))
+}
+
+// ComputeMeshStats returns statistics describing triangles, in a single
+// pass, counting as slivers the triangles whose AspectRatio exceeds
+// sliverRatio. It returns the zero MeshStats if triangles is empty.
+func ComputeMeshStats(triangles []Triangle, sliverRatio float64) MeshStats {
+	if len(triangles) == 0 {
+		return MeshStats{}
+	}
+
+	s := MeshStats{
+		Count:           len(triangles),
+		MinCircumradius: math.Inf(1),
+		MinAngle:        math.Inf(1),
+		MinAspectRatio:  math.Inf(1),
+	}
+	vertices := map[Point]bool{}
+	edges := map[Edge]bool{}
+	sum, sumRatio := 0.0, 0.0
+	for _, t := range triangles {
+		for _, v := range [3]Point{t.A, t.B, t.C} {
+			vertices[v] = true
+		}
+		for _, e := range [3]Edge{{t.A, t.B}, {t.B, t.C}, {t.C, t.A}} {
+			edges[e.canonical()] = true
+		}
+
+		s.TotalArea += math.Abs(cross(t.A, t.B, t.C)) / 2
+
+		c, _ := t.CalcCircumCircle()
+		s.MinCircumradius = math.Min(s.MinCircumradius, c.Radius)
+		s.MaxCircumradius = math.Max(s.MaxCircumradius, c.Radius)
+		sum += c.Radius
+
+		for _, a := range t.Angles() {
+			s.MinAngle = math.Min(s.MinAngle, a)
+			s.MaxAngle = math.Max(s.MaxAngle, a)
+		}
+
+		r := t.AspectRatio()
+		s.MinAspectRatio = math.Min(s.MinAspectRatio, r)
+		s.MaxAspectRatio = math.Max(s.MaxAspectRatio, r)
+		sumRatio += r
+		if r > sliverRatio {
+			s.Slivers++
+		}
+	}
+	s.Vertices = len(vertices)
+	s.Edges = len(edges)
+	s.MeanCircumradius = sum / float64(len(triangles))
+	s.MeanAspectRatio = sumRatio / float64(len(triangles))
+	return s
+}
+
+// String returns a human-readable report of s, one statistic per line.
+func (s MeshStats) String() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "triangles:    %d\n", s.Count)
+	fmt.Fprintf(&b, "vertices:     %d\n", s.Vertices)
+	fmt.Fprintf(&b, "edges:        %d\n", s.Edges)
+	fmt.Fprintf(&b, "area:         %g\n", s.TotalArea)
+	fmt.Fprintf(&b, "circumradius: min %g, max %g, mean %g\n", s.MinCircumradius, s.MaxCircumradius, s.MeanCircumradius)
+	fmt.Fprintf(&b, "angle:        min %g, max %g\n", s.MinAngle, s.MaxAngle)
+	fmt.Fprintf(&b, "aspect ratio: min %g, max %g, mean %g\n", s.MinAspectRatio, s.MaxAspectRatio, s.MeanAspectRatio)
+	fmt.Fprintf(&b, "slivers:      %d\n", s.Slivers)
+	return b.String()
+}
+
+// Angles returns the interior angles of t at A, B and C in degrees.
+func (t *Triangle) Angles() [3]float64 {
+	return [3]float64{angle(t.A, t.B, t.C), angle(t.B, t.C, t.A), angle(t.C, t.A, t.B)}
+}
+
+// angle returns the angle at a between the rays to b and c in degrees.
+func angle(a, b, c Point) float64 {
+	ux, uy := b.X-a.X, b.Y-a.Y
+	vx, vy := c.X-a.X, c.Y-a.Y
+	return math.Atan2(math.Abs(ux*vy-uy*vx), ux*vx+uy*vy) * 180 / math.Pi
+}
+
+// AspectRatio returns the ratio of t's circumradius to the length of its
+// shortest edge, a common measure of triangle quality. It is 1/√3, about
+// 0.577, for an equilateral triangle, which is the smallest possible value,
+// and grows without bound as t approaches a sliver. By the law of sines it
+// equals 1/(2 sin θ), where θ is t's smallest angle. It is +Inf if t is
+// degenerate. The circumradius is computed from the edge lengths and area
+// rather than by CalcCircumCircle.
+func (t *Triangle) AspectRatio() float64 {
+	a := math.Hypot(t.B.X-t.C.X, t.B.Y-t.C.Y)
+	b := math.Hypot(t.C.X-t.A.X, t.C.Y-t.A.Y)
+	c := math.Hypot(t.A.X-t.B.X, t.A.Y-t.B.Y)
+	area := math.Abs(cross(t.A, t.B, t.C)) / 2
+	if area == 0 {
+		return math.Inf(1)
+	}
+	// R = abc / 4K, and R / min(a, b, c) is the product of the two longer
+	// edges over 4K.
+	min := math.Min(a, math.Min(b, c))
+	return a * b * c / min / (4 * area)
+}
+
+// FilterByQuality returns the elements of triangles whose AspectRatio is at
+// most maxRatio, in their original order. A maxRatio of 1 keeps the
+// triangles whose smallest angle is at least 30 degrees.
+func FilterByQuality(triangles []Triangle, maxRatio float64) []Triangle {
+	var result []Triangle
+	for i := range triangles {
+		if triangles[i].AspectRatio() <= maxRatio {
+			result = append(result, triangles[i])
+		}
+	}
+	return result
+}
@@ -0,0 +1,138 @@
+package bowyer_watson
+
+import (
+	"math"
+	"testing"
+)
+
+func TestStats(t *testing.T) {
+	square := []Triangle{
+		{A: Point{0, 0}, B: Point{2, 0}, C: Point{2, 2}},
+		{A: Point{0, 0}, B: Point{2, 2}, C: Point{0, 2}},
+	}
+	got := Stats(square)
+	want := MeshStats{
+		Count:            2,
+		Vertices:         4,
+		Edges:            5,
+		TotalArea:        4,
+		MinCircumradius:  math.Sqrt2,
+		MaxCircumradius:  math.Sqrt2,
+		MeanCircumradius: math.Sqrt2,
+		MinAngle:         45,
+		MaxAngle:         90,
+		MinAspectRatio:   math.Sqrt2 / 2,
+		MaxAspectRatio:   math.Sqrt2 / 2,
+		MeanAspectRatio:  math.Sqrt2 / 2,
+	}
+	if got != want {
+		t.Errorf("got %+v, want %+v", got, want)
+	}
+
+	if got := Stats(nil); got != (MeshStats{}) {
+		t.Errorf("nil: got %+v, want zero", got)
+	}
+}
+
+func TestComputeMeshStats(t *testing.T) {
+	triangles := []Triangle{
+		{A: Point{0, 0}, B: Point{1, 0}, C: Point{0.5, math.Sqrt(3) / 2}},
+		{A: Point{0, 0}, B: Point{2, 0}, C: Point{1, 0.1}},
+	}
+	s := ComputeMeshStats(triangles, 1)
+	if s.Slivers != 1 {
+		t.Errorf("slivers: got %v, want 1", s.Slivers)
+	}
+	if want := 1 / math.Sqrt(3); math.Abs(s.MinAspectRatio-want) > 1e-12 {
+		t.Errorf("min aspect ratio: got %v, want %v", s.MinAspectRatio, want)
+	}
+	if want := triangles[1].AspectRatio(); s.MaxAspectRatio != want {
+		t.Errorf("max aspect ratio: got %v, want %v", s.MaxAspectRatio, want)
+	}
+	if want := (triangles[0].AspectRatio() + triangles[1].AspectRatio()) / 2; math.Abs(s.MeanAspectRatio-want) > 1e-12 {
+		t.Errorf("mean aspect ratio: got %v, want %v", s.MeanAspectRatio, want)
+	}
+
+	want := `triangles:    2
+vertices:     4
+edges:        5
+area:         4
+circumradius: min 1.4142135623730951, max 1.4142135623730951, mean 1.4142135623730951
+angle:        min 45, max 90
+aspect ratio: min 0.7071067811865476, max 0.7071067811865476, mean 0.7071067811865476
+slivers:      0
+`
+	square := []Triangle{
+		{A: Point{0, 0}, B: Point{2, 0}, C: Point{2, 2}},
+		{A: Point{0, 0}, B: Point{2, 2}, C: Point{0, 2}},
+	}
+	if got := ComputeMeshStats(square, 1).String(); got != want {
+		t.Errorf("String: got\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestAngles(t *testing.T) {
+	points := make([]Point, 100)
+	for i := range points {
+		x, y := getRandomPointInCircle(5)
+		points[i] = Point{x, y}
+	}
+	for _, tri := range SweepHullTriangulation(points) {
+		a := tri.Angles()
+		if sum := a[0] + a[1] + a[2]; math.Abs(sum-180) > 1e-9 {
+			t.Errorf("triangle %v: angles %v sum to %v, want 180", tri, a, sum)
+		}
+	}
+
+	equilateral := Triangle{A: Point{0, 0}, B: Point{1, 0}, C: Point{0.5, math.Sqrt(3) / 2}}
+	for i, a := range equilateral.Angles() {
+		if math.Abs(a-60) > 1e-9 {
+			t.Errorf("angle %d: got %v, want 60", i, a)
+		}
+	}
+}
+
+func TestAspectRatio(t *testing.T) {
+	tests := []struct {
+		tri  Triangle
+		want float64
+	}{
+		{Triangle{A: Point{0, 0}, B: Point{1, 0}, C: Point{0.5, math.Sqrt(3) / 2}}, 1 / math.Sqrt(3)},
+		{Triangle{A: Point{0, 0}, B: Point{1, 0}, C: Point{0, 1}}, math.Sqrt2 / 2},
+		{Triangle{A: Point{0, 0}, B: Point{4, 0}, C: Point{2, 1e-3}}, 500 * math.Sqrt(4+1e-6)},
+		{Triangle{A: Point{0, 0}, B: Point{1, 1}, C: Point{2, 2}}, math.Inf(1)},
+		{Triangle{A: Point{1, 1}, B: Point{1, 1}, C: Point{1, 1}}, math.Inf(1)},
+	}
+	for _, tt := range tests {
+		got := tt.tri.AspectRatio()
+		if math.IsInf(tt.want, 1) != math.IsInf(got, 1) || !math.IsInf(got, 1) && math.Abs(got-tt.want) > 1e-9*tt.want {
+			t.Errorf("%v: got %v, want %v", tt.tri, got, tt.want)
+		}
+	}
+
+	// The ratio is 1/(2 sin θ) for the smallest angle θ.
+	for _, tri := range SweepHullTriangulation([]Point{{0, 0}, {3, 1}, {1, 4}, {5, 5}, {2, 2.5}}) {
+		a := tri.Angles()
+		theta := math.Min(a[0], math.Min(a[1], a[2])) * math.Pi / 180
+		if got, want := tri.AspectRatio(), 1/(2*math.Sin(theta)); math.Abs(got-want) > 1e-9*want {
+			t.Errorf("%v: got %v, want %v", tri, got, want)
+		}
+	}
+}
+
+func TestFilterByQuality(t *testing.T) {
+	good := Triangle{A: Point{0, 0}, B: Point{1, 0}, C: Point{0.5, 1}}
+	sliver := Triangle{A: Point{0, 0}, B: Point{1, 0}, C: Point{0.5, 0.01}}
+	flat := Triangle{A: Point{0, 0}, B: Point{1, 0}, C: Point{2, 0}}
+	triangles := []Triangle{sliver, good, flat, good}
+	got := FilterByQuality(triangles, 1)
+	if len(got) != 2 || got[0] != good || got[1] != good {
+		t.Errorf("got %v, want two copies of %v", got, good)
+	}
+	if triangles[0] != sliver {
+		t.Errorf("input modified")
+	}
+	if got := FilterByQuality(triangles, 100); len(got) != 3 {
+		t.Errorf("100: got %d triangles, want 3", len(got))
+	}
+}
@@ -0,0 +1,87 @@
+package bowyer_watson
+
+import (
+	"bufio"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"math"
+)
+
+// WriteSTL writes triangles to w in binary STL format with every vertex at
+// z = 0.
+func WriteSTL(w io.Writer, triangles []Triangle) error {
+	return WriteSTLHeight(w, triangles, nil)
+}
+
+// WriteSTLHeight writes triangles to w in binary STL format, using height to
+// compute the z coordinate of each vertex. A nil height places every vertex
+// at z = 0.
+func WriteSTLHeight(w io.Writer, triangles []Triangle, height func(Point) float64) error {
+	if uint64(len(triangles)) > math.MaxUint32 {
+		return fmt.Errorf("bowyer_watson: %d triangles exceed the STL limit", len(triangles))
+	}
+
+	bw := bufio.NewWriter(w)
+	var header [80]byte
+	copy(header[:], "binary STL written by bowyer_watson")
+	bw.Write(header[:])
+
+	var buf [50]byte
+	binary.LittleEndian.PutUint32(buf[:4], uint32(len(triangles)))
+	bw.Write(buf[:4])
+
+	for _, t := range triangles {
+		vs := stlFacet(t, height)
+		for i, f := range vs {
+			binary.LittleEndian.PutUint32(buf[4*i:], math.Float32bits(f))
+		}
+		// The attribute byte count is left zero.
+		buf[48], buf[49] = 0, 0
+		bw.Write(buf[:])
+	}
+	return bw.Flush()
+}
+
+// WriteSTLASCII writes triangles to w in ASCII STL format with every vertex
+// at z = 0.
+func WriteSTLASCII(w io.Writer, triangles []Triangle) error {
+	bw := bufio.NewWriter(w)
+	fmt.Fprint(bw, "solid bowyer_watson\n")
+	for _, t := range triangles {
+		vs := stlFacet(t, nil)
+		fmt.Fprintf(bw, "facet normal %g %g %g\n", vs[0], vs[1], vs[2])
+		fmt.Fprint(bw, "  outer loop\n")
+		for i := 3; i < 12; i += 3 {
+			fmt.Fprintf(bw, "    vertex %g %g %g\n", vs[i], vs[i+1], vs[i+2])
+		}
+		fmt.Fprint(bw, "  endloop\n")
+		fmt.Fprint(bw, "endfacet\n")
+	}
+	fmt.Fprint(bw, "endsolid bowyer_watson\n")
+	return bw.Flush()
+}
+
+// stlFacet returns the unit normal of t followed by its three vertices, as
+// float32 values in the order they appear in an STL facet.
+func stlFacet(t Triangle, height func(Point) float64) [12]float32 {
+	var za, zb, zc float64
+	if height != nil {
+		za, zb, zc = height(t.A), height(t.B), height(t.C)
+	}
+
+	// The normal is the cross product of B-A and C-A.
+	ux, uy, uz := t.B.X-t.A.X, t.B.Y-t.A.Y, zb-za
+	vx, vy, vz := t.C.X-t.A.X, t.C.Y-t.A.Y, zc-za
+	nx, ny, nz := uy*vz-uz*vy, uz*vx-ux*vz, ux*vy-uy*vx
+	if l := math.Sqrt(nx*nx + ny*ny + nz*nz); l > 0 {
+		nx, ny, nz = nx/l, ny/l, nz/l
+	}
+
+	return [12]float32{
+		float32(nx), float32(ny), float32(nz),
+		float32(t.A.X), float32(t.A.Y), float32(za),
+		float32(t.B.X), float32(t.B.Y), float32(zb),
+		float32(t.C.X), float32(t.C.Y), float32(zc),
+	}
+}
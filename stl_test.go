@@ -0,0 +1,157 @@
+package bowyer_watson
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"encoding/hex"
+	"io"
+	"math"
+	"strings"
+	"testing"
+)
+
+// readSTL is a minimal binary STL reader used to check WriteSTL's output
+// independently of the writer.
+func readSTL(r io.Reader) (normals [][3]float32, facets [][9]float32, err error) {
+	var header [80]byte
+	if _, err := io.ReadFull(r, header[:]); err != nil {
+		return nil, nil, err
+	}
+	var n uint32
+	if err := binary.Read(r, binary.LittleEndian, &n); err != nil {
+		return nil, nil, err
+	}
+	for i := uint32(0); i < n; i++ {
+		var rec struct {
+			Normal [3]float32
+			Facet  [9]float32
+			Attr   uint16
+		}
+		if err := binary.Read(r, binary.LittleEndian, &rec); err != nil {
+			return nil, nil, err
+		}
+		normals = append(normals, rec.Normal)
+		facets = append(facets, rec.Facet)
+	}
+	return normals, facets, nil
+}
+
+var stlTriangles = []Triangle{
+	{A: Point{0, 0}, B: Point{1, 0}, C: Point{1, 1}},
+	{A: Point{0, 0}, B: Point{0, 1}, C: Point{1, 1}},
+}
+
+func TestWriteSTL(t *testing.T) {
+	var buf bytes.Buffer
+	if err := WriteSTL(&buf, stlTriangles); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := buf.Len(), 84+50*len(stlTriangles); got != want {
+		t.Errorf("size: got %v, want %v", got, want)
+	}
+
+	normals, facets, err := readSTL(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	wantNormals := [][3]float32{{0, 0, 1}, {0, 0, -1}}
+	for i, tri := range stlTriangles {
+		if normals[i] != wantNormals[i] {
+			t.Errorf("normal %d: got %v, want %v", i, normals[i], wantNormals[i])
+		}
+		want := [9]float32{
+			float32(tri.A.X), float32(tri.A.Y), 0,
+			float32(tri.B.X), float32(tri.B.Y), 0,
+			float32(tri.C.X), float32(tri.C.Y), 0,
+		}
+		if facets[i] != want {
+			t.Errorf("facet %d: got %v, want %v", i, facets[i], want)
+		}
+	}
+}
+
+func TestWriteSTLReference(t *testing.T) {
+	// The facets of stlTriangles laid out by hand from the STL
+	// specification: a little-endian uint32 count, then per facet a normal
+	// and three vertices as little-endian float32s and a zero uint16
+	// attribute byte count. 0000803f is 1 and 000080bf is -1.
+	want := "02000000" +
+		"00000000" + "00000000" + "0000803f" +
+		"00000000" + "00000000" + "00000000" +
+		"0000803f" + "00000000" + "00000000" +
+		"0000803f" + "0000803f" + "00000000" +
+		"0000" +
+		"00000000" + "00000000" + "000080bf" +
+		"00000000" + "00000000" + "00000000" +
+		"00000000" + "0000803f" + "00000000" +
+		"0000803f" + "0000803f" + "00000000" +
+		"0000"
+	var buf bytes.Buffer
+	if err := WriteSTL(&buf, stlTriangles); err != nil {
+		t.Fatal(err)
+	}
+	if buf.Len() < 80 {
+		t.Fatalf("size: got %v, want at least the 80-byte header", buf.Len())
+	}
+	if got := hex.EncodeToString(buf.Bytes()[80:]); got != want {
+		t.Errorf("got  %s\nwant %s", got, want)
+	}
+}
+
+func TestWriteSTLHeight(t *testing.T) {
+	tri := []Triangle{{A: Point{0, 0}, B: Point{1, 0}, C: Point{0, 1}}}
+	var buf bytes.Buffer
+	err := WriteSTLHeight(&buf, tri, func(p Point) float64 { return p.X })
+	if err != nil {
+		t.Fatal(err)
+	}
+	normals, facets, err := readSTL(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The plane z = x has normal (-1, 0, 1)/sqrt(2).
+	s := float32(1 / math.Sqrt2)
+	if got, want := normals[0], [3]float32{-s, 0, s}; got != want {
+		t.Errorf("normal: got %v, want %v", got, want)
+	}
+	if got, want := facets[0], [9]float32{0, 0, 0, 1, 0, 1, 0, 1, 0}; got != want {
+		t.Errorf("facet: got %v, want %v", got, want)
+	}
+}
+
+func TestWriteSTLASCII(t *testing.T) {
+	var buf bytes.Buffer
+	if err := WriteSTLASCII(&buf, stlTriangles); err != nil {
+		t.Fatal(err)
+	}
+
+	out := buf.String()
+	if !strings.HasPrefix(out, "solid ") || !strings.Contains(out, "\nendsolid ") {
+		t.Errorf("missing solid/endsolid in:\n%s", out)
+	}
+	if !strings.Contains(out, "facet normal 0 0 -1") {
+		t.Errorf("missing downward normal in:\n%s", out)
+	}
+
+	var vertices []string
+	facets := 0
+	sc := bufio.NewScanner(&buf)
+	for sc.Scan() {
+		line := strings.TrimSpace(sc.Text())
+		switch {
+		case strings.HasPrefix(line, "facet normal "):
+			facets++
+		case strings.HasPrefix(line, "vertex "):
+			vertices = append(vertices, strings.TrimPrefix(line, "vertex "))
+		}
+	}
+	if facets != len(stlTriangles) {
+		t.Errorf("#facets: got %v, want %v", facets, len(stlTriangles))
+	}
+	want := []string{"0 0 0", "1 0 0", "1 1 0", "0 0 0", "0 1 0", "1 1 0"}
+	if strings.Join(vertices, "|") != strings.Join(want, "|") {
+		t.Errorf("vertices: got %q, want %q", vertices, want)
+	}
+}
@@ -0,0 +1,52 @@
+package bowyer_watson
+
+// Subdivide splits t into four triangles similar to it by joining the
+// midpoints of its edges: one at each vertex and one in the middle. Each has
+// the same orientation as t. Since a midpoint does not depend on the
+// direction of its edge, subdividing every triangle of a conforming mesh
+// gives a conforming mesh.
+func (t *Triangle) Subdivide() [4]Triangle {
+	ab, bc, ca := midpoint(t.A, t.B), midpoint(t.B, t.C), midpoint(t.C, t.A)
+	return [4]Triangle{
+		{A: t.A, B: ab, C: ca},
+		{A: ab, B: t.B, C: bc},
+		{A: ca, B: bc, C: t.C},
+		{A: ab, B: bc, C: ca},
+	}
+}
+
+// SubdivideN applies Subdivide n times, returning the 4ⁿ triangles of the
+// last level. It returns t alone if n is not positive.
+func (t *Triangle) SubdivideN(n int) []Triangle {
+	result := []Triangle{*t}
+	for i := 0; i < n; i++ {
+		next := make([]Triangle, 0, 4*len(result))
+		for j := range result {
+			s := result[j].Subdivide()
+			next = append(next, s[:]...)
+		}
+		result = next
+	}
+	return result
+}
+
+// BisectLongest splits t at the midpoint of its longest edge, the first of
+// AB, BC and CA if several are equally long, and returns the midpoint and
+// the two triangles joining it to the opposite vertex. Each has the same
+// orientation as t. A triangle that shares the edge must be split at the
+// same point for the mesh to stay conforming.
+func (t *Triangle) BisectLongest() (Point, [2]Triangle) {
+	p, q, r := t.A, t.B, t.C
+	if dist2(t.B, t.C) > dist2(p, q) {
+		p, q, r = t.B, t.C, t.A
+	}
+	if dist2(t.C, t.A) > dist2(p, q) {
+		p, q, r = t.C, t.A, t.B
+	}
+	m := midpoint(p, q)
+	return m, [2]Triangle{{A: p, B: m, C: r}, {A: m, B: q, C: r}}
+}
+
+func midpoint(a, b Point) Point {
+	return Point{(a.X + b.X) / 2, (a.Y + b.Y) / 2}
+}
@@ -0,0 +1,109 @@
+package bowyer_watson
+
+import (
+	"math"
+	"testing"
+)
+
+func TestSubdivide(t *testing.T) {
+	tri := Triangle{A: Point{0, 0}, B: Point{4, 0}, C: Point{0, 4}}
+	got := tri.Subdivide()
+	want := [4]Triangle{
+		{A: Point{0, 0}, B: Point{2, 0}, C: Point{0, 2}},
+		{A: Point{2, 0}, B: Point{4, 0}, C: Point{2, 2}},
+		{A: Point{0, 2}, B: Point{2, 2}, C: Point{0, 4}},
+		{A: Point{2, 0}, B: Point{2, 2}, C: Point{0, 2}},
+	}
+	if got != want {
+		t.Errorf("got %v, want %v", got, want)
+	}
+
+	// A clockwise triangle gives clockwise sub-triangles.
+	cw := Triangle{A: Point{1, 1}, B: Point{2, 5}, C: Point{6, 2}}
+	for _, s := range cw.Subdivide() {
+		if a := s.SignedArea(); a >= 0 {
+			t.Errorf("%v: got area %v, want negative", s, a)
+		}
+	}
+}
+
+func TestSubdivideN(t *testing.T) {
+	tri := Triangle{A: Point{1, 1}, B: Point{7, 2}, C: Point{3, 6}}
+	if got := tri.SubdivideN(0); len(got) != 1 || got[0] != tri {
+		t.Errorf("0: got %v, want %v", got, tri)
+	}
+	got := tri.SubdivideN(3)
+	if len(got) != 64 {
+		t.Fatalf("#triangles: got %v, want 64", len(got))
+	}
+	area := tri.SignedArea()
+	for _, s := range got {
+		if a := s.SignedArea(); math.Abs(a-area/64) > 1e-12 {
+			t.Errorf("%v: got area %v, want %v", s, a, area/64)
+		}
+	}
+
+	// The sub-triangles form a conforming mesh: every interior edge is
+	// shared by exactly two of them.
+	edges := map[Edge]int{}
+	for _, s := range got {
+		for _, e := range triangleEdges(s) {
+			edges[e]++
+		}
+	}
+	boundary := 0
+	for e, n := range edges {
+		switch n {
+		case 1:
+			boundary++
+		case 2:
+		default:
+			t.Errorf("edge %v: shared by %d triangles", e, n)
+		}
+	}
+	if boundary != 3*8 {
+		t.Errorf("boundary edges: got %v, want %v", boundary, 3*8)
+	}
+}
+
+func TestBisectLongest(t *testing.T) {
+	tests := []struct {
+		tri  Triangle
+		m    Point
+		want [2]Triangle
+	}{
+		{
+			Triangle{A: Point{0, 0}, B: Point{4, 0}, C: Point{1, 1}},
+			Point{2, 0},
+			[2]Triangle{{A: Point{0, 0}, B: Point{2, 0}, C: Point{1, 1}}, {A: Point{2, 0}, B: Point{4, 0}, C: Point{1, 1}}},
+		},
+		{
+			Triangle{A: Point{1, 1}, B: Point{0, 0}, C: Point{4, 0}},
+			Point{2, 0},
+			[2]Triangle{{A: Point{0, 0}, B: Point{2, 0}, C: Point{1, 1}}, {A: Point{2, 0}, B: Point{4, 0}, C: Point{1, 1}}},
+		},
+		{
+			Triangle{A: Point{0, 4}, B: Point{1, 1}, C: Point{0, 0}},
+			Point{0, 2},
+			[2]Triangle{{A: Point{0, 0}, B: Point{0, 2}, C: Point{1, 1}}, {A: Point{0, 2}, B: Point{0, 4}, C: Point{1, 1}}},
+		},
+		// A right isosceles triangle splits on its hypotenuse.
+		{
+			Triangle{A: Point{0, 0}, B: Point{2, 0}, C: Point{0, 2}},
+			Point{1, 1},
+			[2]Triangle{{A: Point{2, 0}, B: Point{1, 1}, C: Point{0, 0}}, {A: Point{1, 1}, B: Point{0, 2}, C: Point{0, 0}}},
+		},
+	}
+	for _, tt := range tests {
+		m, got := tt.tri.BisectLongest()
+		if m != tt.m || got != tt.want {
+			t.Errorf("%v: got %v, %v, want %v, %v", tt.tri, m, got, tt.m, tt.want)
+		}
+		area := tt.tri.SignedArea()
+		for _, c := range got {
+			if a := c.SignedArea(); a != area/2 {
+				t.Errorf("%v: child %v has area %v, want %v", tt.tri, c, a, area/2)
+			}
+		}
+	}
+}
@@ -0,0 +1,166 @@
+package bowyer_watson
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"math"
+)
+
+// SVGOptions controls the output of WriteSVG. The zero value selects the
+// defaults noted on each field.
+type SVGOptions struct {
+	// Width and Height are the dimensions of the image in pixels. The
+	// default is 800x800.
+	Width, Height float64
+
+	// Margin is the space left around the triangulation in pixels. The
+	// default is 10.
+	Margin float64
+
+	// StrokeWidth is the width of triangle edges in pixels. The default
+	// is 1. Boundary edges are drawn twice as wide.
+	StrokeWidth float64
+
+	// EdgeColor is the color of edges shared by two triangles, the default
+	// is "black". BoundaryColor is the color of edges that belong to a
+	// single triangle, the default is "red". FillColor is the fill of the
+	// triangles, the default is "none".
+	EdgeColor, BoundaryColor, FillColor string
+
+	// Circumcircles enables drawing each triangle's circumcircle in
+	// CircleColor, which defaults to "gray".
+	Circumcircles bool
+	CircleColor   string
+
+	// LabelVertices enables labeling each vertex with its coordinates.
+	LabelVertices bool
+}
+
+func (o *SVGOptions) setDefaults() {
+	if o.Width <= 0 {
+		o.Width = 800
+	}
+	if o.Height <= 0 {
+		o.Height = 800
+	}
+	if o.Margin <= 0 {
+		o.Margin = 10
+	}
+	if o.StrokeWidth <= 0 {
+		o.StrokeWidth = 1
+	}
+	if o.EdgeColor == "" {
+		o.EdgeColor = "black"
+	}
+	if o.BoundaryColor == "" {
+		o.BoundaryColor = "red"
+	}
+	if o.FillColor == "" {
+		o.FillColor = "none"
+	}
+	if o.CircleColor == "" {
+		o.CircleColor = "gray"
+	}
+}
+
+// WriteSVG writes an SVG image of triangles to w. The triangulation is
+// scaled to fit the image dimensions given in opts, preserving its aspect
+// ratio.
+func WriteSVG(w io.Writer, triangles []Triangle, opts SVGOptions) error {
+	opts.setDefaults()
+
+	min := Point{math.Inf(1), math.Inf(1)}
+	max := Point{math.Inf(-1), math.Inf(-1)}
+	edges := map[Edge]int{}
+	var order []Edge
+	for _, t := range triangles {
+		for _, p := range [3]Point{t.A, t.B, t.C} {
+			min.X, min.Y = math.Min(min.X, p.X), math.Min(min.Y, p.Y)
+			max.X, max.Y = math.Max(max.X, p.X), math.Max(max.Y, p.Y)
+		}
+		for _, e := range [3]Edge{{t.A, t.B}, {t.B, t.C}, {t.C, t.A}} {
+			e = e.canonical()
+			if edges[e] == 0 {
+				order = append(order, e)
+			}
+			edges[e]++
+		}
+	}
+
+	scale := 1.0
+	if dx, dy := max.X-min.X, max.Y-min.Y; dx > 0 || dy > 0 {
+		scale = math.Min((opts.Width-2*opts.Margin)/dx, (opts.Height-2*opts.Margin)/dy)
+	}
+	// SVG's y axis points down, so flip the image vertically.
+	tx := func(p Point) (float64, float64) {
+		return opts.Margin + (p.X-min.X)*scale, opts.Height - opts.Margin - (p.Y-min.Y)*scale
+	}
+
+	bw := bufio.NewWriter(w)
+	fmt.Fprintf(bw, "<svg xmlns=\"http://www.w3.org/2000/svg\" width=\"%g\" height=\"%g\" viewBox=\"0 0 %g %g\">\n",
+		opts.Width, opts.Height, opts.Width, opts.Height)
+
+	fmt.Fprintf(bw, "<g fill=\"%s\" stroke=\"none\">\n", opts.FillColor)
+	for _, t := range triangles {
+		ax, ay := tx(t.A)
+		bx, by := tx(t.B)
+		cx, cy := tx(t.C)
+		fmt.Fprintf(bw, "<polygon points=\"%g,%g %g,%g %g,%g\"/>\n", ax, ay, bx, by, cx, cy)
+	}
+	fmt.Fprint(bw, "</g>\n")
+
+	fmt.Fprintf(bw, "<g stroke=\"%s\" stroke-width=\"%g\">\n", opts.EdgeColor, opts.StrokeWidth)
+	for _, e := range order {
+		if edges[e] > 1 {
+			writeSVGLine(bw, e, tx)
+		}
+	}
+	fmt.Fprint(bw, "</g>\n")
+
+	fmt.Fprintf(bw, "<g stroke=\"%s\" stroke-width=\"%g\">\n", opts.BoundaryColor, 2*opts.StrokeWidth)
+	for _, e := range order {
+		if edges[e] == 1 {
+			writeSVGLine(bw, e, tx)
+		}
+	}
+	fmt.Fprint(bw, "</g>\n")
+
+	if opts.Circumcircles {
+		fmt.Fprintf(bw, "<g fill=\"none\" stroke=\"%s\" stroke-width=\"%g\">\n", opts.CircleColor, opts.StrokeWidth/2)
+		for _, t := range triangles {
+			c, err := t.CalcCircumCircle()
+			if err != nil {
+				continue
+			}
+			cx, cy := tx(c.Center)
+			fmt.Fprintf(bw, "<circle cx=\"%g\" cy=\"%g\" r=\"%g\"/>\n", cx, cy, c.Radius*scale)
+		}
+		fmt.Fprint(bw, "</g>\n")
+	}
+
+	if opts.LabelVertices {
+		fmt.Fprint(bw, "<g font-family=\"sans-serif\" font-size=\"10\">\n")
+		seen := map[Point]bool{}
+		for _, t := range triangles {
+			for _, p := range [3]Point{t.A, t.B, t.C} {
+				if seen[p] {
+					continue
+				}
+				seen[p] = true
+				x, y := tx(p)
+				fmt.Fprintf(bw, "<text x=\"%g\" y=\"%g\">%g,%g</text>\n", x+2, y-2, p.X, p.Y)
+			}
+		}
+		fmt.Fprint(bw, "</g>\n")
+	}
+
+	fmt.Fprint(bw, "</svg>\n")
+	return bw.Flush()
+}
+
+func writeSVGLine(w io.Writer, e Edge, tx func(Point) (float64, float64)) {
+	x1, y1 := tx(e.A)
+	x2, y2 := tx(e.B)
+	fmt.Fprintf(w, "<line x1=\"%g\" y1=\"%g\" x2=\"%g\" y2=\"%g\"/>\n", x1, y1, x2, y2)
+}
@@ -0,0 +1,78 @@
+package bowyer_watson
+
+import (
+	"bytes"
+	"encoding/xml"
+	"io"
+	"testing"
+)
+
+func TestWriteSVG(t *testing.T) {
+	square := []Triangle{
+		{A: Point{0, 0}, B: Point{1, 0}, C: Point{1, 1}},
+		{A: Point{0, 0}, B: Point{1, 1}, C: Point{0, 1}},
+	}
+
+	var buf bytes.Buffer
+	err := WriteSVG(&buf, square, SVGOptions{Circumcircles: true, LabelVertices: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Count the elements in each group to check that shared and boundary
+	// edges were separated.
+	var groups []map[string]int
+	dec := xml.NewDecoder(&buf)
+	for {
+		tok, err := dec.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatalf("invalid XML: %v", err)
+		}
+		se, ok := tok.(xml.StartElement)
+		if !ok {
+			continue
+		}
+		if se.Name.Local == "g" {
+			groups = append(groups, map[string]int{})
+		} else if len(groups) > 0 {
+			groups[len(groups)-1][se.Name.Local]++
+		}
+	}
+
+	want := []map[string]int{
+		{"polygon": 2},
+		{"line": 1},
+		{"line": 4},
+		{"circle": 2},
+		{"text": 4},
+	}
+	if got := len(groups); got != len(want) {
+		t.Fatalf("#groups: got %v, want %v", got, len(want))
+	}
+	for i := range want {
+		for k, v := range want[i] {
+			if got := groups[i][k]; got != v {
+				t.Errorf("group %d #%s: got %v, want %v", i, k, got, v)
+			}
+		}
+	}
+}
+
+func TestWriteSVGFit(t *testing.T) {
+	tri := []Triangle{{A: Point{-5, -5}, B: Point{5, -5}, C: Point{0, 15}}}
+
+	var buf bytes.Buffer
+	if err := WriteSVG(&buf, tri, SVGOptions{Width: 200, Height: 100, Margin: 10}); err != nil {
+		t.Fatal(err)
+	}
+
+	// The triangle is twice as tall as it is wide so the height limits the
+	// scale to 80/20 = 4 pixels per unit.
+	want := `<polygon points="10,90 50,90 30,10"/>`
+	if !bytes.Contains(buf.Bytes(), []byte(want)) {
+		t.Errorf("output does not contain %s:\n%s", want, buf.Bytes())
+	}
+}
@@ -0,0 +1,333 @@
+package bowyer_watson
+
+import (
+	"math"
+	"sort"
+)
+
+// SweepHullTriangulation returns the triangles in the Delaunay triangulation
+// of points, computed with the sweep-hull (s-hull) algorithm. Unlike
+// DelaunayTriangulation it needs no super triangle and its result covers the
+// convex hull of points. Duplicate points are ignored and nil is returned if
+// any point has a NaN or infinite coordinate, there are fewer than three
+// distinct points or they are all collinear. The returned triangles are
+// counter-clockwise.
+//
+// The points are sorted by their distance from a seed triangle near the
+// center of the set and added one at a time, each connected to the part of
+// the current convex hull visible from it. Edges are flipped as triangles
+// are added to maintain the Delaunay property. Source for algorithm:
+// s-hull.org/paper/s_hull.pdf
+func SweepHullTriangulation(points []Point) []Triangle {
+	s := newSweepHull(points)
+	if s == nil {
+		return nil
+	}
+	s.run()
+
+	result := make([]Triangle, 0, len(s.tri)/3)
+	for i := 0; i < len(s.tri); i += 3 {
+		result = append(result, Triangle{A: points[s.tri[i]], B: points[s.tri[i+1]], C: points[s.tri[i+2]]})
+	}
+	return result
+}
+
+// sweepHull holds the state of a sweep-hull triangulation. Triangles are
+// stored as consecutive triples of point indexes in tri, in
+// counter-clockwise order. Half-edge h runs from tri[h] to the next vertex of
+// its triangle and half[h] is the oppositely directed half-edge of the
+// adjacent triangle, or -1 on the convex hull.
+type sweepHull struct {
+	pts       []Point
+	tri, half []int
+	order     []int
+	center    Point
+	hullNext  []int // next vertex counter-clockwise on the hull, -1 if not on the hull
+	hullPrev  []int // previous vertex on the hull
+	hullTri   []int // half-edge from each hull vertex to its hullNext
+	hullHash  []int // hull vertices bucketed by angle around center
+	hullStart int   // a vertex known to be on the hull
+	edgeStack []int
+	seeds     [3]int
+}
+
+func newSweepHull(pts []Point) *sweepHull {
+	n := len(pts)
+	if n < 3 || checkFinite(pts) != nil {
+		return nil
+	}
+
+	min := Point{math.Inf(1), math.Inf(1)}
+	max := Point{math.Inf(-1), math.Inf(-1)}
+	for _, p := range pts {
+		min.X, min.Y = math.Min(min.X, p.X), math.Min(min.Y, p.Y)
+		max.X, max.Y = math.Max(max.X, p.X), math.Max(max.Y, p.Y)
+	}
+	mid := Point{(min.X + max.X) / 2, (min.Y + max.Y) / 2}
+
+	// Choose a seed triangle near the middle with a small circumcircle.
+	i0 := closest(pts, mid, -1)
+	i1 := closest(pts, pts[i0], i0)
+	if i1 < 0 {
+		return nil
+	}
+	i2, minRadius := -1, math.Inf(1)
+	for i, p := range pts {
+		if i == i0 || i == i1 || orient(pts[i0], pts[i1], p) == 0 {
+			continue
+		}
+		t := Triangle{A: pts[i0], B: pts[i1], C: p}
+		if c, _ := calcCircumcircle(&t); c.radius2 < minRadius {
+			i2, minRadius = i, c.radius2
+		}
+	}
+	if i2 < 0 {
+		return nil
+	}
+	if orient(pts[i0], pts[i1], pts[i2]) < 0 {
+		i1, i2 = i2, i1
+	}
+	seed := Triangle{A: pts[i0], B: pts[i1], C: pts[i2]}
+	c, _ := seed.CalcCircumCircle()
+
+	s := &sweepHull{
+		pts:      pts,
+		tri:      make([]int, 0, 3*(2*n-5)),
+		half:     make([]int, 0, 3*(2*n-5)),
+		center:   c.Center,
+		hullNext: make([]int, n),
+		hullPrev: make([]int, n),
+		hullTri:  make([]int, n),
+		hullHash: make([]int, int(math.Ceil(math.Sqrt(float64(n))))),
+		seeds:    [3]int{i0, i1, i2},
+	}
+	for i := range s.hullNext {
+		s.hullNext[i] = -1
+	}
+	for i := range s.hullHash {
+		s.hullHash[i] = -1
+	}
+
+	s.addTriangle(i0, i1, i2, -1, -1, -1)
+	s.hullNext[i0], s.hullNext[i1], s.hullNext[i2] = i1, i2, i0
+	s.hullPrev[i0], s.hullPrev[i1], s.hullPrev[i2] = i2, i0, i1
+	s.hullTri[i0], s.hullTri[i1], s.hullTri[i2] = 0, 1, 2
+	s.hullStart = i0
+	for _, i := range s.seeds {
+		s.hullHash[s.hashKey(pts[i])] = i
+	}
+
+	s.order = make([]int, 0, n-3)
+	for i := range pts {
+		if i != i0 && i != i1 && i != i2 {
+			s.order = append(s.order, i)
+		}
+	}
+	dist := make([]float64, n)
+	for i, p := range pts {
+		dist[i] = sqr(p.X-s.center.X) + sqr(p.Y-s.center.Y)
+	}
+	// Break ties by coordinates so that duplicate points are adjacent.
+	sort.Slice(s.order, func(i, j int) bool {
+		a, b := s.order[i], s.order[j]
+		if dist[a] != dist[b] {
+			return dist[a] < dist[b]
+		}
+		if pts[a].X != pts[b].X {
+			return pts[a].X < pts[b].X
+		}
+		return pts[a].Y < pts[b].Y
+	})
+
+	return s
+}
+
+// closest returns the index of the point in pts nearest to p that is not
+// equal to p and does not have index skip, or -1 if there is none.
+func closest(pts []Point, p Point, skip int) int {
+	best, bestDist := -1, math.Inf(1)
+	for i, q := range pts {
+		if i == skip || q == p && skip >= 0 {
+			continue
+		}
+		if d := sqr(q.X-p.X) + sqr(q.Y-p.Y); d < bestDist {
+			best, bestDist = i, d
+		}
+	}
+	return best
+}
+
+func (s *sweepHull) run() {
+	pts := s.pts
+	prev := -1
+	for _, i := range s.order {
+		p := pts[i]
+		if prev >= 0 && pts[prev] == p || p == pts[s.seeds[0]] || p == pts[s.seeds[1]] || p == pts[s.seeds[2]] {
+			continue
+		}
+		prev = i
+
+		// Find an edge of the hull visible from p, starting near p's angle
+		// around the center.
+		start := s.hullStart
+		key := s.hashKey(p)
+		for j := 0; j < len(s.hullHash); j++ {
+			if h := s.hullHash[(key+j)%len(s.hullHash)]; h >= 0 && s.hullNext[h] >= 0 {
+				start = h
+				break
+			}
+		}
+		start = s.hullPrev[start]
+		e := start
+		for !s.visible(e, s.hullNext[e], p) {
+			e = s.hullNext[e]
+			if e == start {
+				e = -1
+				break
+			}
+		}
+		if e < 0 {
+			// p is not outside the hull, which only happens for points
+			// that coincide with a hull vertex.
+			continue
+		}
+
+		// The visible edges form a chain, extend it in both directions.
+		for q := s.hullPrev[e]; s.visible(q, e, p); q = s.hullPrev[e] {
+			e = q
+		}
+		first := e
+
+		// Add a triangle joining p to every visible edge. The first new
+		// triangle's edge from first to p and the last one's edge from p
+		// back to the hull become hull edges.
+		lastT := -1
+		for {
+			w := s.hullNext[e]
+			t := s.addTriangle(e, i, w, lastT, -1, s.hullTri[e])
+			if lastT < 0 {
+				s.hullTri[first] = t
+			}
+			lastT = t + 1
+			s.edgeStack = append(s.edgeStack, t+2)
+			if e != first {
+				s.hullNext[e] = -1
+			}
+			e = w
+			if !s.visible(e, s.hullNext[e], p) {
+				break
+			}
+		}
+		last := e
+		s.hullTri[i] = lastT
+
+		s.hullNext[first], s.hullPrev[i] = i, first
+		s.hullNext[i], s.hullPrev[last] = last, i
+		s.hullHash[s.hashKey(p)] = i
+		s.hullHash[s.hashKey(pts[first])] = first
+		s.hullStart = i
+
+		s.legalize()
+	}
+}
+
+// addTriangle appends the triangle (a, b, c) and links its half-edges to
+// ab, bc and ca, and returns the index of its first half-edge.
+func (s *sweepHull) addTriangle(a, b, c, ab, bc, ca int) int {
+	t := len(s.tri)
+	s.tri = append(s.tri, a, b, c)
+	s.half = append(s.half, ab, bc, ca)
+	for k, h := range [3]int{ab, bc, ca} {
+		if h >= 0 {
+			s.half[h] = t + k
+		}
+	}
+	return t
+}
+
+// legalize flips edges on the edge stack, and edges exposed by those flips,
+// until every triangle satisfies the Delaunay condition.
+func (s *sweepHull) legalize() {
+	for len(s.edgeStack) > 0 {
+		a := s.edgeStack[len(s.edgeStack)-1]
+		s.edgeStack = s.edgeStack[:len(s.edgeStack)-1]
+
+		b := s.half[a]
+		if b < 0 {
+			continue
+		}
+
+		//           pa                    pa
+		//          /  \                  /||\
+		//         /    \                / || \
+		//        /  a   \              /  ||  \
+		//      v0 ------ v1   flip   v0 a ||  b v1
+		//        \  b   /      =>      \  ||  /
+		//         \    /                \ || /
+		//          \  /                  \||/
+		//           pb                    pb
+		an, ap := nextHalfEdge(a), prevHalfEdge(a)
+		bn, bp := nextHalfEdge(b), prevHalfEdge(b)
+		v0, v1, pa, pb := s.tri[a], s.tri[an], s.tri[ap], s.tri[bp]
+		if !inCirclePerturbed(s.pts[v0], s.pts[v1], s.pts[pa], s.pts[pb]) {
+			continue
+		}
+
+		// Triangle a becomes (pa, v0, pb) and triangle b (pb, v1, pa). The
+		// outer edges v1-pa and v0-pb trade places.
+		s.tri[an], s.tri[bn] = pb, pa
+		h1, h2 := s.half[an], s.half[bn]
+		s.link(a, h2)
+		s.link(b, h1)
+		s.link(an, bn)
+		if h1 < 0 {
+			s.hullTri[v1] = b
+		}
+		if h2 < 0 {
+			s.hullTri[v0] = a
+		}
+
+		s.edgeStack = append(s.edgeStack, a, bp)
+	}
+}
+
+func (s *sweepHull) link(a, b int) {
+	s.half[a] = b
+	if b >= 0 {
+		s.half[b] = a
+	}
+}
+
+// visible reports whether p is strictly to the right of the hull edge from
+// a to b, and so can see it from outside the hull.
+func (s *sweepHull) visible(a, b int, p Point) bool {
+	return orient(s.pts[a], s.pts[b], p) < 0
+}
+
+func (s *sweepHull) hashKey(p Point) int {
+	return int(pseudoAngle(p.X-s.center.X, p.Y-s.center.Y)*float64(len(s.hullHash))) % len(s.hullHash)
+}
+
+// pseudoAngle returns a value in [0, 1) that increases monotonically with
+// the angle of the vector (dx, dy).
+func pseudoAngle(dx, dy float64) float64 {
+	p := dx / (math.Abs(dx) + math.Abs(dy))
+	if dy > 0 {
+		return (3 - p) / 4
+	}
+	return (1 + p) / 4
+}
+
+func nextHalfEdge(h int) int {
+	if h%3 == 2 {
+		return h - 2
+	}
+	return h + 1
+}
+
+func prevHalfEdge(h int) int {
+	if h%3 == 0 {
+		return h + 2
+	}
+	return h - 1
+}
@@ -0,0 +1,180 @@
+package bowyer_watson
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"sort"
+	"testing"
+)
+
+// convexHullArea returns the area of the convex hull of points.
+func convexHullArea(points []Point) float64 {
+	hull := convexHull(points)
+	area := 0.0
+	for i := range hull {
+		area += cross(Point{}, hull[i], hull[(i+1)%len(hull)])
+	}
+	return area / 2
+}
+
+// checkDelaunay reports an error if tris is not a counter-clockwise
+// triangulation of the convex hull of points with empty circumcircles.
+func checkDelaunay(t *testing.T, points []Point, tris []Triangle) {
+	t.Helper()
+	area := 0.0
+	for _, tri := range tris {
+		if orient(tri.A, tri.B, tri.C) <= 0 {
+			t.Errorf("triangle %v is not counter-clockwise", tri)
+		}
+		area += cross(tri.A, tri.B, tri.C) / 2
+		for _, p := range points {
+			if tri.HasVertex(p) {
+				continue
+			}
+			if inCircle(tri.A, tri.B, tri.C, p) > 0 {
+				t.Errorf("point %v is inside the circumcircle of %v", p, tri)
+				return
+			}
+		}
+	}
+	if hull := convexHullArea(points); math.Abs(area-hull) > 1e-9*hull {
+		t.Errorf("area: got %v, want convex hull area %v", area, hull)
+	}
+}
+
+func TestSweepHullTriangulation(t *testing.T) {
+	for _, n := range []int{3, 4, 10, 100, 1000} {
+		t.Run(fmt.Sprint(n), func(t *testing.T) {
+			points := make([]Point, n)
+			for i := range points {
+				x, y := getRandomPointInCircle(5)
+				points[i] = Point{x, y}
+			}
+
+			u := SweepHullTriangulation(points)
+			checkDelaunay(t, points, u)
+
+			// Euler's formula for a triangulated point set.
+			hull := 0
+			count := map[Edge]int{}
+			for _, tri := range u {
+				for _, e := range []Edge{{tri.A, tri.B}, {tri.B, tri.C}, {tri.C, tri.A}} {
+					count[e.canonical()]++
+				}
+			}
+			for _, c := range count {
+				if c == 1 {
+					hull++
+				}
+			}
+			if got, want := len(u), 2*n-2-hull; got != want {
+				t.Errorf("#triangles: got %v, want %v", got, want)
+			}
+
+			// Every triangle found by DelaunayTriangulation is Delaunay, so
+			// it must also be in the sweep-hull result.
+			super := Triangle{
+				A: Point{0, 50},
+				B: Point{50, -50},
+				C: Point{-50, -50},
+			}
+			found := map[[3]Point]bool{}
+			for _, tri := range u {
+				found[sortedVertices(tri)] = true
+			}
+			dt, err := DelaunayTriangulation(points, super)
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, tri := range dt {
+				if !found[sortedVertices(tri)] {
+					t.Errorf("missing triangle %v", tri)
+				}
+			}
+		})
+	}
+}
+
+func TestSweepHullTriangulationDegenerate(t *testing.T) {
+	grid := make([]Point, 0, 100)
+	for i := 0; i < 10; i++ {
+		for j := 0; j < 10; j++ {
+			grid = append(grid, Point{float64(i), float64(j)})
+		}
+	}
+
+	tests := []struct {
+		name   string
+		points []Point
+		want   int
+	}{
+		{"empty", nil, 0},
+		{"two", []Point{{0, 0}, {1, 1}}, 0},
+		{"collinear", []Point{{0, 0}, {1, 1}, {2, 2}, {3, 3}}, 0},
+		{"same", []Point{{1, 1}, {1, 1}, {1, 1}}, 0},
+		{"duplicates", []Point{{0, 0}, {1, 0}, {0, 1}, {1, 0}, {0, 0}, {0, 1}}, 1},
+		{"square", []Point{{0, 0}, {1, 0}, {0, 1}, {1, 1}}, 2},
+		{"collinear hull", []Point{{0, 0}, {1, 0}, {2, 0}, {3, 0}, {1.5, 1}}, 3},
+		{"grid", grid, 162},
+		{"NaN", []Point{{0, 0}, {1, 0}, {0, 1}, {1, 1}, {math.NaN(), 0.5}, {0.3, 0.7}}, 0},
+		{"infinite", []Point{{0, 0}, {1, 0}, {0, 1}, {math.Inf(-1), 1}}, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			u := SweepHullTriangulation(tt.points)
+			if got := len(u); got != tt.want {
+				t.Errorf("#triangles: got %v, want %v", got, tt.want)
+			}
+			if len(u) > 0 {
+				checkDelaunay(t, tt.points, u)
+			}
+		})
+	}
+}
+
+func sortedVertices(t Triangle) [3]Point {
+	vs := [3]Point{t.A, t.B, t.C}
+	sort.Slice(vs[:], func(i, j int) bool {
+		return vs[i].X < vs[j].X || vs[i].X == vs[j].X && vs[i].Y < vs[j].Y
+	})
+	return vs
+}
+
+func benchmarkPoints(n int) []Point {
+	r := rand.New(rand.NewSource(1))
+	points := make([]Point, n)
+	for i := range points {
+		points[i] = Point{r.Float64() * 100, r.Float64() * 100}
+	}
+	return points
+}
+
+func BenchmarkSweepHullTriangulation1e5(b *testing.B) {
+	points := benchmarkPoints(1e5)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		SweepHullTriangulation(points)
+	}
+}
+
+func BenchmarkSweepHullTriangulation1e6(b *testing.B) {
+	points := benchmarkPoints(1e6)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		SweepHullTriangulation(points)
+	}
+}
+
+func BenchmarkDelaunayTriangulation1e5(b *testing.B) {
+	points := benchmarkPoints(1e5)
+	super := Triangle{
+		A: Point{-1e4, -1e4},
+		B: Point{1e4, -1e4},
+		C: Point{0, 1e4},
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		DelaunayTriangulation(points, super)
+	}
+}
@@ -0,0 +1,258 @@
+package bowyer_watson
+
+import (
+	"errors"
+	"math"
+	"sort"
+)
+
+// Point3D represents a basic x,y,z coordinate.
+type Point3D struct {
+	X float64 `json:"x"`
+	Y float64 `json:"y"`
+	Z float64 `json:"z"`
+}
+
+func (p Point3D) vec() vec3 { return vec3{p.X, p.Y, p.Z} }
+
+// point3DEqual reports whether a and b differ by no more than eps in each
+// coordinate.
+func point3DEqual(a, b Point3D, eps float64) bool {
+	return a == b || math.Abs(a.X-b.X) <= eps && math.Abs(a.Y-b.Y) <= eps && math.Abs(a.Z-b.Z) <= eps
+}
+
+type points3DByX []Point3D
+
+func (s points3DByX) Len() int      { return len(s) }
+func (s points3DByX) Swap(i, j int) { s[i], s[j] = s[j], s[i] }
+func (s points3DByX) Less(i, j int) bool {
+	a, b := s[i], s[j]
+	return a.X < b.X || a.X == b.X && (a.Y < b.Y || a.Y == b.Y && a.Z < b.Z)
+}
+
+// Tetrahedron contains four points that form a tetrahedron.
+type Tetrahedron struct {
+	A, B, C, D      Point3D
+	center          Point3D
+	radius, radius2 float64
+}
+
+// ErrDegenerateTetrahedron is returned by CalcCircumSphere when a
+// tetrahedron has no circumsphere.
+var ErrDegenerateTetrahedron = errors.New("bowyer_watson: degenerate tetrahedron")
+
+// CalcCircumSphere calculates t's circumsphere and caches the results in t.
+// It must be called before using CircumsphereContains. If t's vertices are
+// coplanar, or so nearly coplanar that the circumsphere cannot be
+// represented, t is marked degenerate and ErrDegenerateTetrahedron is
+// returned. A degenerate tetrahedron has a finite center at its centroid,
+// an infinite radius, and contains no points.
+func (t *Tetrahedron) CalcCircumSphere() error {
+	if orient3d(t.A.vec(), t.B.vec(), t.C.vec(), t.D.vec()) == 0 {
+		t.setDegenerate()
+		return ErrDegenerateTetrahedron
+	}
+
+	// Solve for the center relative to A.
+	bx, by, bz := t.B.X-t.A.X, t.B.Y-t.A.Y, t.B.Z-t.A.Z
+	cx, cy, cz := t.C.X-t.A.X, t.C.Y-t.A.Y, t.C.Z-t.A.Z
+	dx, dy, dz := t.D.X-t.A.X, t.D.Y-t.A.Y, t.D.Z-t.A.Z
+	b2, c2, d2 := bx*bx+by*by+bz*bz, cx*cx+cy*cy+cz*cz, dx*dx+dy*dy+dz*dz
+
+	cdx, cdy, cdz := cy*dz-cz*dy, cz*dx-cx*dz, cx*dy-cy*dx
+	dbx, dby, dbz := dy*bz-dz*by, dz*bx-dx*bz, dx*by-dy*bx
+	bcx, bcy, bcz := by*cz-bz*cy, bz*cx-bx*cz, bx*cy-by*cx
+	den := 2 * (bx*cdx + by*cdy + bz*cdz)
+
+	ox := (b2*cdx + c2*dbx + d2*bcx) / den
+	oy := (b2*cdy + c2*dby + d2*bcy) / den
+	oz := (b2*cdz + c2*dbz + d2*bcz) / den
+	t.center = Point3D{t.A.X + ox, t.A.Y + oy, t.A.Z + oz}
+	t.radius2 = ox*ox + oy*oy + oz*oz
+	t.radius = math.Sqrt(t.radius2)
+
+	// Nearly coplanar vertices can still overflow or divide by zero.
+	if math.IsNaN(t.radius2) || math.IsInf(t.radius2, 0) {
+		t.setDegenerate()
+		return ErrDegenerateTetrahedron
+	}
+	return nil
+}
+
+// setDegenerate marks t as having no circumsphere. The center is set to
+// the centroid so that it remains finite.
+func (t *Tetrahedron) setDegenerate() {
+	t.center = Point3D{
+		(t.A.X + t.B.X + t.C.X + t.D.X) / 4,
+		(t.A.Y + t.B.Y + t.C.Y + t.D.Y) / 4,
+		(t.A.Z + t.B.Z + t.C.Z + t.D.Z) / 4,
+	}
+	t.radius, t.radius2 = math.Inf(1), math.Inf(1)
+}
+
+// IsDegenerate reports whether CalcCircumSphere found that t's vertices are
+// coplanar, so that t has no circumsphere.
+func (t *Tetrahedron) IsDegenerate() bool {
+	return math.IsInf(t.radius2, 1)
+}
+
+// HasVertex determine if p is one of t's vertices, within Tolerance.
+func (t *Tetrahedron) HasVertex(p Point3D) bool {
+	return point3DEqual(t.A, p, Tolerance) || point3DEqual(t.B, p, Tolerance) ||
+		point3DEqual(t.C, p, Tolerance) || point3DEqual(t.D, p, Tolerance)
+}
+
+// CircumsphereContains determines if p is contained within the circumsphere
+// of t. A degenerate tetrahedron contains no points.
+func (t *Tetrahedron) CircumsphereContains(p Point3D) bool {
+	if t.IsDegenerate() {
+		return false
+	}
+	dist2 := sqr(p.X-t.center.X) + sqr(p.Y-t.center.Y) + sqr(p.Z-t.center.Z)
+	return dist2 <= t.radius2
+}
+
+// SignedVolume returns the volume of t, positive if A, B and C appear
+// counter-clockwise when viewed from D and negative if they appear
+// clockwise. The sign is exact, so it is zero only if the vertices are
+// coplanar.
+func (t *Tetrahedron) SignedVolume() float64 {
+	return -orient3d(t.A.vec(), t.B.vec(), t.C.vec(), t.D.vec()) / 6
+}
+
+// inSphere reports whether p lies strictly inside the circumsphere of t,
+// which must have positive volume. The test is exact.
+func (t *Tetrahedron) inSphere(p Point3D) bool {
+	// orient3d(B, A, C, D) is positive when t's volume is.
+	return inSphere(t.B.vec(), t.A.vec(), t.C.vec(), t.D.vec(), p.vec()) > 0
+}
+
+// face is a triangle on the boundary of a tetrahedron.
+type face struct {
+	A, B, C Point3D
+}
+
+// canonical returns f with its vertices sorted, so that equivalent faces
+// compare equal with == and may be used as map keys.
+func (f face) canonical() face {
+	vs := points3DByX{f.A, f.B, f.C}
+	sort.Sort(vs)
+	return face{vs[0], vs[1], vs[2]}
+}
+
+// DelaunayTetrahedralization returns the tetrahedra in the Delaunay
+// tetrahedralization of points, with positive volume. It is the three
+// dimensional counterpart of DelaunayTriangulation: each point is inserted
+// by removing the tetrahedra whose circumspheres contain it and joining it
+// to the faces of the cavity they leave. Points that do not lie strictly
+// inside super, and points equal within Tolerance to an earlier one, are
+// ignored. It returns nil if super is degenerate or fewer than four of the
+// points are left, or if they are all coplanar.
+//
+// As in two dimensions, the tetrahedra with a vertex of super are removed
+// at the end, so if super is not much larger than the points a few
+// tetrahedra near the convex hull may be missing.
+func DelaunayTetrahedralization(points []Point3D, super Tetrahedron) []Tetrahedron {
+	if super.CalcCircumSphere() != nil {
+		return nil
+	}
+	if super.SignedVolume() < 0 {
+		super.B, super.C = super.C, super.B
+	}
+	sa, sb, sc, sd := super.A.vec(), super.B.vec(), super.C.vec(), super.D.vec()
+
+	pts := make([]Point3D, 0, len(points))
+	for _, p := range points {
+		v := p.vec()
+		// Each face of super, oriented so that the inside is below it.
+		if orient3d(sa, sc, sb, v) > 0 && orient3d(sa, sb, sd, v) > 0 &&
+			orient3d(sa, sd, sc, v) > 0 && orient3d(sb, sc, sd, v) > 0 {
+			pts = append(pts, p)
+		}
+	}
+	sort.Sort(points3DByX(pts))
+	n := 0
+	for k, p := range pts {
+		if k > 0 && point3DEqual(p, pts[n-1], Tolerance) {
+			continue
+		}
+		pts[n] = p
+		n++
+	}
+	pts = pts[:n]
+
+	ts := []Tetrahedron{super}
+	var result []Tetrahedron
+	var faces []face
+	counts := map[face]int{}
+	for _, p := range pts {
+		faces = faces[:0]
+		for f := range counts {
+			delete(counts, f)
+		}
+
+		for i := 0; i < len(ts); {
+			t := &ts[i]
+			if p.X-t.center.X > t.radius+retireSlack*(t.radius+math.Abs(t.center.X)) {
+				result = append(result, *t)
+				n := len(ts) - 1
+				ts[i] = ts[n]
+				ts = ts[:n]
+			} else if !t.IsDegenerate() && t.inSphere(p) {
+				for _, f := range [...]face{
+					{t.A, t.B, t.C},
+					{t.A, t.B, t.D},
+					{t.A, t.C, t.D},
+					{t.B, t.C, t.D},
+				} {
+					f = f.canonical()
+					if counts[f]++; counts[f] == 1 {
+						faces = append(faces, f)
+					}
+				}
+				n := len(ts) - 1
+				ts[i] = ts[n]
+				ts = ts[:n]
+			} else {
+				i++
+			}
+		}
+
+		// Faces shared by two removed tetrahedra are inside the cavity.
+		for _, f := range faces {
+			if counts[f] == 1 {
+				ts = append(ts, newTetrahedron(f, p))
+			}
+		}
+	}
+	result = append(result, ts...)
+
+	//Remove any tetrahedra using the Points of the super, and any
+	//degenerate tetrahedra
+	for i := 0; i < len(result); {
+		t := &result[i]
+		if !t.IsDegenerate() && !t.HasVertex(super.A) && !t.HasVertex(super.B) &&
+			!t.HasVertex(super.C) && !t.HasVertex(super.D) {
+			i++
+			continue
+		}
+		n := len(result) - 1
+		result[i] = result[n]
+		result = result[:n]
+	}
+	if len(result) == 0 {
+		return nil
+	}
+	return result
+}
+
+// newTetrahedron returns the tetrahedron with positive volume joining f to
+// p, with its circumsphere calculated.
+func newTetrahedron(f face, p Point3D) Tetrahedron {
+	t := Tetrahedron{A: f.A, B: f.B, C: f.C, D: p}
+	if t.SignedVolume() < 0 {
+		t.A, t.B = t.B, t.A
+	}
+	t.CalcCircumSphere()
+	return t
+}
@@ -0,0 +1,139 @@
+package bowyer_watson
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+var bigTetrahedron = Tetrahedron{
+	A: Point3D{-1000, -1000, -1000},
+	B: Point3D{1000, -1000, -1000},
+	C: Point3D{0, 1000, -1000},
+	D: Point3D{0, 0, 1000},
+}
+
+// checkTetrahedralization reports an error if tets is not a Delaunay
+// tetrahedralization with every element of points as a vertex and total
+// volume wantVolume.
+func checkTetrahedralization(t *testing.T, points []Point3D, tets []Tetrahedron, wantVolume float64) {
+	t.Helper()
+	vertices := map[Point3D]bool{}
+	faces := map[face]int{}
+	volume := 0.0
+	for _, tet := range tets {
+		vs := [4]Point3D{tet.A, tet.B, tet.C, tet.D}
+		for _, v := range vs {
+			vertices[v] = true
+		}
+		for _, f := range [...]face{{tet.A, tet.B, tet.C}, {tet.A, tet.B, tet.D}, {tet.A, tet.C, tet.D}, {tet.B, tet.C, tet.D}} {
+			faces[f.canonical()]++
+		}
+		v := tet.SignedVolume()
+		if v <= 0 {
+			t.Errorf("tetrahedron %v: volume %v", vs, v)
+		}
+		volume += v
+		for _, p := range points {
+			if tet.inSphere(p) {
+				t.Errorf("tetrahedron %v: circumsphere contains %v", vs, p)
+				break
+			}
+		}
+	}
+	for f, n := range faces {
+		if n > 2 {
+			t.Errorf("face %v is used by %d tetrahedra", f, n)
+		}
+	}
+	for _, p := range points {
+		if !vertices[p] {
+			t.Errorf("point %v is not a vertex", p)
+		}
+	}
+	if math.Abs(volume-wantVolume) > 1e-9*wantVolume {
+		t.Errorf("volume: got %v, want %v", volume, wantVolume)
+	}
+}
+
+func TestCalcCircumSphere(t *testing.T) {
+	tet := Tetrahedron{A: Point3D{1, 0, 0}, B: Point3D{0, 1, 0}, C: Point3D{0, 0, 1}, D: Point3D{0, 0, 0}}
+	if err := tet.CalcCircumSphere(); err != nil {
+		t.Fatal(err)
+	}
+	if want := (Point3D{0.5, 0.5, 0.5}); tet.center != want {
+		t.Errorf("center: got %v, want %v", tet.center, want)
+	}
+	if want := math.Sqrt(0.75); math.Abs(tet.radius-want) > 1e-15 {
+		t.Errorf("radius: got %v, want %v", tet.radius, want)
+	}
+	if !tet.CircumsphereContains(Point3D{1, 1, 0}) {
+		t.Errorf("does not contain a point on the sphere")
+	}
+	if tet.CircumsphereContains(Point3D{1, 1, 1.1}) {
+		t.Errorf("contains a point outside the sphere")
+	}
+	if got, want := tet.SignedVolume(), -1.0/6; got != want {
+		t.Errorf("volume: got %v, want %v", got, want)
+	}
+
+	flat := Tetrahedron{A: Point3D{0, 0, 0}, B: Point3D{1, 0, 0}, C: Point3D{0, 1, 0}, D: Point3D{1, 1, 0}}
+	if err := flat.CalcCircumSphere(); err != ErrDegenerateTetrahedron {
+		t.Errorf("coplanar: got %v, want %v", err, ErrDegenerateTetrahedron)
+	}
+	if !flat.IsDegenerate() || flat.CircumsphereContains(Point3D{0.5, 0.5, 0}) {
+		t.Errorf("coplanar: not degenerate")
+	}
+}
+
+func TestDelaunayTetrahedralization(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	points := make([]Point3D, 200)
+	for i := range points {
+		points[i] = Point3D{r.Float64(), r.Float64(), r.Float64()}
+	}
+	// Add the corners so that the convex hull is the unit cube.
+	for i := 0; i < 8; i++ {
+		points = append(points, Point3D{float64(i & 1), float64(i >> 1 & 1), float64(i >> 2)})
+	}
+	tets := DelaunayTetrahedralization(points, bigTetrahedron)
+	checkTetrahedralization(t, points, tets, 1)
+}
+
+func TestDelaunayTetrahedralizationLattice(t *testing.T) {
+	// Every cube of the lattice is cospherical.
+	var points []Point3D
+	for x := 0; x < 4; x++ {
+		for y := 0; y < 4; y++ {
+			for z := 0; z < 4; z++ {
+				points = append(points, Point3D{float64(x), float64(y), float64(z)})
+			}
+		}
+	}
+	tets := DelaunayTetrahedralization(points, bigTetrahedron)
+	checkTetrahedralization(t, points, tets, 27)
+}
+
+func TestDelaunayTetrahedralizationIgnored(t *testing.T) {
+	points := []Point3D{
+		{0, 0, 0}, {1, 0, 0}, {0, 1, 0}, {0, 0, 1},
+		{0, 0, 0},          // duplicate
+		{5000, 0, 0},       // outside super
+		{0, 0, 1000},       // a vertex of super
+		{math.NaN(), 0, 0}, // not inside anything
+	}
+	tets := DelaunayTetrahedralization(points, bigTetrahedron)
+	if len(tets) != 1 {
+		t.Fatalf("got %v, want one tetrahedron", tets)
+	}
+	checkTetrahedralization(t, points[:4], tets, 1.0/6)
+
+	coplanar := []Point3D{{0, 0, 0}, {1, 0, 0}, {0, 1, 0}, {1, 1, 0}}
+	if got := DelaunayTetrahedralization(coplanar, bigTetrahedron); got != nil {
+		t.Errorf("coplanar: got %v, want nil", got)
+	}
+	flat := Tetrahedron{D: Point3D{1, 1, 0}, B: Point3D{1, 0, 0}, C: Point3D{0, 1, 0}}
+	if got := DelaunayTetrahedralization(points, flat); got != nil {
+		t.Errorf("degenerate super: got %v, want nil", got)
+	}
+}
@@ -0,0 +1,25 @@
+package bowyer_watson
+
+import "context"
+
+// A Triangulator computes Delaunay triangulations like DelaunayTriangulation
+// but keeps its working storage between calls, so that triangulating many
+// point sets in a loop does not allocate afresh each time. The zero value is
+// ready to use. A Triangulator must not be used by more than one goroutine
+// at a time.
+type Triangulator struct {
+	pts      []Point
+	ts       []cachedTriangle
+	edges    []Edge
+	result   []cachedTriangle
+	interior []Triangle
+	ghosts   []Triangle
+}
+
+// Triangulate is like DelaunayTriangulation. The returned slice shares
+// storage with tr and is only valid until the next call to Triangulate;
+// copy it to keep it longer.
+func (tr *Triangulator) Triangulate(points []Point, super Triangle, opts ...Option) ([]Triangle, error) {
+	interior, _, err := triangulate(context.Background(), points, super, opts, tr)
+	return interior, err
+}
@@ -0,0 +1,79 @@
+package bowyer_watson
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestTriangulator(t *testing.T) {
+	super := Triangle{
+		A: Point{0, 50},
+		B: Point{50, -50},
+		C: Point{-50, -50},
+	}
+	var tr Triangulator
+	for _, n := range []int{100, 10, 50} {
+		points := make([]Point, n)
+		for i := range points {
+			x, y := getRandomPointInCircle(5)
+			points[i] = Point{x, y}
+		}
+		got, err := tr.Triangulate(points, super, WithSortedOutput())
+		if err != nil {
+			t.Fatal(err)
+		}
+		want, err := DelaunayTriangulation(points, super, WithSortedOutput())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(got) != len(want) {
+			t.Fatalf("%d points: #triangles: got %v, want %v", n, len(got), len(want))
+		}
+		for i := range want {
+			if got[i] != want[i] {
+				t.Errorf("%d points: triangle %d: got %v, want %v", n, i, got[i], want[i])
+			}
+		}
+	}
+
+	if _, err := tr.Triangulate(nil, super); err != ErrEmptyInput {
+		t.Errorf("got error %v, want %v", err, ErrEmptyInput)
+	}
+}
+
+func smallPointSets() [][]Point {
+	r := rand.New(rand.NewSource(1))
+	sets := make([][]Point, 100)
+	for i := range sets {
+		sets[i] = make([]Point, 20)
+		for j := range sets[i] {
+			sets[i][j] = Point{r.Float64()*10 - 5, r.Float64()*10 - 5}
+		}
+	}
+	return sets
+}
+
+var benchSuper = Triangle{
+	A: Point{0, 50},
+	B: Point{50, -50},
+	C: Point{-50, -50},
+}
+
+func BenchmarkTriangulatorSmall(b *testing.B) {
+	sets := smallPointSets()
+	var tr Triangulator
+	b.ReportAllocs()
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		tr.Triangulate(sets[n%len(sets)], benchSuper)
+	}
+}
+
+func BenchmarkDelaunayTriangulationSmall(b *testing.B) {
+	sets := smallPointSets()
+	b.ReportAllocs()
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		DelaunayTriangulation(sets[n%len(sets)], benchSuper)
+	}
+}
@@ -0,0 +1,123 @@
+package bowyer_watson
+
+import "fmt"
+
+// IsDelaunay reports whether triangles satisfy the empty circumcircle
+// property with respect to points: no element of points lies strictly inside
+// the circumcircle of any triangle. It takes time proportional to
+// len(triangles) * len(points) and is intended for tests.
+func IsDelaunay(triangles []Triangle, points []Point) bool {
+	for _, t := range triangles {
+		if !isEmptyCircle(t, points) {
+			return false
+		}
+	}
+	return true
+}
+
+// ValidateDelaunay returns the triangles whose circumcircle strictly
+// contains an element of points, in their original order. It returns nil if
+// triangles satisfy the empty circumcircle property. Like IsDelaunay it takes
+// time proportional to len(triangles) * len(points) and is intended for
+// tests and debugging.
+func ValidateDelaunay(points []Point, triangles []Triangle) []Triangle {
+	var bad []Triangle
+	for _, t := range triangles {
+		if !isEmptyCircle(t, points) {
+			bad = append(bad, t)
+		}
+	}
+	return bad
+}
+
+// isEmptyCircle reports whether no element of points other than t's own
+// vertices lies strictly inside the circumcircle of t, regardless of t's
+// winding.
+func isEmptyCircle(t Triangle, points []Point) bool {
+	a, b, c := t.A, t.B, t.C
+	if orient(a, b, c) < 0 {
+		b, c = c, b
+	}
+	for _, p := range points {
+		if p == a || p == b || p == c {
+			continue
+		}
+		if inCircle(a, b, c, p) > 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// Validate checks that triangles form the Delaunay triangulation of points.
+// Every triangle must be counter-clockwise with vertices drawn from points,
+// every distinct element of points must be a vertex, the triangles must tile
+// the convex hull of points without gaps or overlaps, and no element of
+// points may lie strictly inside the circumcircle of any triangle. It
+// returns an error describing the first problem found. Like IsDelaunay it is
+// intended for tests.
+func Validate(points []Point, triangles []Triangle) error {
+	inputs := make(map[Point]bool, len(points))
+	for _, p := range points {
+		inputs[p] = true
+	}
+
+	vertices := map[Point]bool{}
+	edges := map[Edge]int{}
+	for i, t := range triangles {
+		if orient(t.A, t.B, t.C) <= 0 {
+			return fmt.Errorf("bowyer_watson: triangle %d %v is not counter-clockwise", i, [3]Point{t.A, t.B, t.C})
+		}
+		for _, v := range [3]Point{t.A, t.B, t.C} {
+			if !inputs[v] {
+				return fmt.Errorf("bowyer_watson: triangle %d vertex %v is not an input point", i, v)
+			}
+			vertices[v] = true
+		}
+		for _, e := range [3]Edge{{t.A, t.B}, {t.B, t.C}, {t.C, t.A}} {
+			// Adjacent counter-clockwise triangles traverse their shared
+			// edge in opposite directions.
+			edges[e]++
+			if edges[e] > 1 {
+				return fmt.Errorf("bowyer_watson: edge %v belongs to overlapping triangles", e)
+			}
+		}
+	}
+	for p := range inputs {
+		if !vertices[p] {
+			return fmt.Errorf("bowyer_watson: point %v is not a vertex", p)
+		}
+	}
+
+	// A boundary edge has no reversed twin. With every triangle
+	// counter-clockwise, the triangles tile the convex hull exactly if all
+	// the boundary edges are hull edges, with no point to their right, and
+	// Euler's formula holds.
+	boundary := 0
+	for _, t := range triangles {
+		for _, e := range [3]Edge{{t.A, t.B}, {t.B, t.C}, {t.C, t.A}} {
+			if edges[Edge{e.B, e.A}] > 0 {
+				continue
+			}
+			boundary++
+			for _, p := range points {
+				if orient(e.A, e.B, p) < 0 {
+					return fmt.Errorf("bowyer_watson: boundary edge %v is not on the convex hull", e)
+				}
+			}
+		}
+	}
+	if want := 2*len(vertices) - 2 - boundary; len(triangles) != want {
+		return fmt.Errorf("bowyer_watson: got %d triangles, want %d for %d vertices and %d boundary edges",
+			len(triangles), want, len(vertices), boundary)
+	}
+
+	for i, t := range triangles {
+		for _, p := range points {
+			if p != t.A && p != t.B && p != t.C && inCircle(t.A, t.B, t.C, p) > 0 {
+				return fmt.Errorf("bowyer_watson: point %v is inside the circumcircle of triangle %d %v", p, i, [3]Point{t.A, t.B, t.C})
+			}
+		}
+	}
+	return nil
+}
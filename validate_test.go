@@ -0,0 +1,218 @@
+package bowyer_watson
+
+import (
+	"bytes"
+	"math/rand"
+	"testing"
+)
+
+func TestIsDelaunay(t *testing.T) {
+	points := make([]Point, 200)
+	for i := range points {
+		x, y := getRandomPointInCircle(5)
+		points[i] = Point{x, y}
+	}
+
+	super := Triangle{
+		A: Point{0, 50},
+		B: Point{50, -50},
+		C: Point{-50, -50},
+	}
+
+	u, err := DelaunayTriangulation(points, super)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !IsDelaunay(u, points) {
+		t.Error("DelaunayTriangulation: got false, want true")
+	}
+	if !IsDelaunay(SweepHullTriangulation(points), points) {
+		t.Error("SweepHullTriangulation: got false, want true")
+	}
+	if !IsDelaunay(DivideAndConquer(points), points) {
+		t.Error("DivideAndConquer: got false, want true")
+	}
+}
+
+func TestIsDelaunayBroken(t *testing.T) {
+	// A thin quadrilateral split along its long diagonal; the Delaunay
+	// triangulation uses the short one.
+	points := []Point{{-3, 0}, {3, 0}, {0, 1}, {0, -1}}
+	broken := []Triangle{
+		{A: Point{-3, 0}, B: Point{3, 0}, C: Point{0, 1}},
+		{A: Point{-3, 0}, B: Point{0, -1}, C: Point{3, 0}},
+	}
+	if IsDelaunay(broken, points) {
+		t.Error("long diagonal: got true, want false")
+	}
+
+	// Winding must not matter.
+	broken[0].B, broken[0].C = broken[0].C, broken[0].B
+	if IsDelaunay(broken, points) {
+		t.Error("clockwise long diagonal: got true, want false")
+	}
+
+	fixed := []Triangle{
+		{A: Point{-3, 0}, B: Point{0, -1}, C: Point{0, 1}},
+		{A: Point{3, 0}, B: Point{0, 1}, C: Point{0, -1}},
+	}
+	if !IsDelaunay(fixed, points) {
+		t.Error("short diagonal: got false, want true")
+	}
+}
+
+func TestValidateDelaunay(t *testing.T) {
+	points := []Point{{-3, 0}, {3, 0}, {0, 1}, {0, -1}, {0, -5}}
+	tris := []Triangle{
+		{A: Point{-3, 0}, B: Point{3, 0}, C: Point{0, 1}},
+		{A: Point{-3, 0}, B: Point{0, -1}, C: Point{3, 0}},
+		{A: Point{-3, 0}, B: Point{0, -5}, C: Point{0, -1}},
+	}
+	// Both halves of the long diagonal split contain the opposite vertex;
+	// the third triangle is fine.
+	got := ValidateDelaunay(points, tris)
+	if len(got) != 2 || got[0] != tris[0] || got[1] != tris[1] {
+		t.Errorf("got %v, want %v", got, tris[:2])
+	}
+
+	u, err := DelaunayTriangulation(points, Triangle{A: Point{0, 50}, B: Point{50, -50}, C: Point{-50, -50}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := ValidateDelaunay(points, u); got != nil {
+		t.Errorf("DelaunayTriangulation: got %v, want nil", got)
+	}
+}
+
+func TestValidate(t *testing.T) {
+	points := []Point{{-3, 0}, {3, 0}, {0, 1}, {0, -1}}
+	good := []Triangle{
+		{A: Point{-3, 0}, B: Point{0, -1}, C: Point{0, 1}},
+		{A: Point{3, 0}, B: Point{0, 1}, C: Point{0, -1}},
+	}
+	if err := Validate(points, good); err != nil {
+		t.Errorf("good: %v", err)
+	}
+
+	tests := []struct {
+		name      string
+		points    []Point
+		triangles []Triangle
+		want      string
+	}{
+		{
+			"clockwise",
+			points,
+			[]Triangle{good[0], {A: Point{3, 0}, B: Point{0, -1}, C: Point{0, 1}}},
+			"bowyer_watson: triangle 1 [{3 0} {0 -1} {0 1}] is not counter-clockwise",
+		},
+		{
+			"foreign vertex",
+			points,
+			[]Triangle{good[0], {A: Point{4, 0}, B: Point{0, 1}, C: Point{0, -1}}},
+			"bowyer_watson: triangle 1 vertex {4 0} is not an input point",
+		},
+		{
+			"overlap",
+			points,
+			[]Triangle{good[0], good[1], good[1]},
+			"bowyer_watson: edge {{3 0} {0 1}} belongs to overlapping triangles",
+		},
+		{
+			"missing vertex",
+			points,
+			good[:1],
+			"bowyer_watson: point {3 0} is not a vertex",
+		},
+		{
+			"concave",
+			[]Point{{0, 0}, {4, 0}, {2, 1}, {2, 4}},
+			[]Triangle{
+				{A: Point{0, 0}, B: Point{2, 1}, C: Point{2, 4}},
+				{A: Point{2, 1}, B: Point{4, 0}, C: Point{2, 4}},
+			},
+			"bowyer_watson: boundary edge {{0 0} {2 1}} is not on the convex hull",
+		},
+		{
+			"not Delaunay",
+			points,
+			[]Triangle{
+				{A: Point{-3, 0}, B: Point{3, 0}, C: Point{0, 1}},
+				{A: Point{-3, 0}, B: Point{0, -1}, C: Point{3, 0}},
+			},
+			"bowyer_watson: point {0 -1} is inside the circumcircle of triangle 0 [{-3 0} {3 0} {0 1}]",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := Validate(tt.points, tt.triangles)
+			if err == nil || err.Error() != tt.want {
+				t.Errorf("got %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidateGrid(t *testing.T) {
+	var grid []Point
+	for i := 0; i < 50; i++ {
+		for j := 0; j < 50; j++ {
+			grid = append(grid, Point{float64(i), float64(j)})
+		}
+	}
+	super := Triangle{
+		A: Point{-1000, -1000},
+		B: Point{1000, -1000},
+		C: Point{0, 1000},
+	}
+
+	u, err := DelaunayTriangulation(grid, super)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := Validate(grid, u); err != nil {
+		t.Fatal(err)
+	}
+	var want bytes.Buffer
+	if err := WriteTriangles(&want, u); err != nil {
+		t.Fatal(err)
+	}
+
+	// The tie-breaking rule depends only on coordinates, so the input order
+	// does not matter and the other algorithms find the same triangles.
+	shuffled := append([]Point(nil), grid...)
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 3; i++ {
+		r.Shuffle(len(shuffled), func(i, j int) { shuffled[i], shuffled[j] = shuffled[j], shuffled[i] })
+		v, err := DelaunayTriangulation(shuffled, super)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var got bytes.Buffer
+		if err := WriteTriangles(&got, v); err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(got.Bytes(), want.Bytes()) {
+			t.Errorf("run %d: output differs", i)
+		}
+	}
+
+	found := map[[3]Point]bool{}
+	for _, tri := range u {
+		found[sortedVertices(tri)] = true
+	}
+	for name, tris := range map[string][]Triangle{
+		"SweepHullTriangulation": SweepHullTriangulation(shuffled),
+		"DivideAndConquer":       DivideAndConquer(shuffled),
+	} {
+		if err := Validate(grid, tris); err != nil {
+			t.Errorf("%s: %v", name, err)
+		}
+		for _, tri := range tris {
+			if !found[sortedVertices(tri)] {
+				t.Errorf("%s: unexpected triangle %v", name, tri)
+				break
+			}
+		}
+	}
+}
@@ -0,0 +1,153 @@
+package bowyer_watson
+
+import "fmt"
+
+// VerifyError is returned by TriangulateVerified when a triangulation fails
+// verification.
+type VerifyError struct {
+	Stage    string       // the triangulation that failed: "fast" or "fallback"
+	Check    string       // the property violated: "orientation", "vertex", "overlap", "coverage" or "delaunay"
+	Triangle Triangle     // the offending triangle
+	Point    Point        // the offending point, if the check involves one
+	Previous *VerifyError // for the fallback, how the fast triangulation failed
+}
+
+func (e *VerifyError) Error() string {
+	s := fmt.Sprintf("bowyer_watson: %s triangulation failed %s check: triangle %v, point %v",
+		e.Stage, e.Check, [3]Point{e.Triangle.A, e.Triangle.B, e.Triangle.C}, e.Point)
+	if e.Previous != nil {
+		s += " (after " + e.Previous.Error() + ")"
+	}
+	return s
+}
+
+// TriangulateVerified is like Triangulate with WithHullCompletion, but
+// checks the result before returning it: every triangle is
+// counter-clockwise, the distinct points are exactly the vertices, no two
+// triangles overlap, they cover the convex hull of points, and every edge
+// is locally Delaunay, which for such a mesh implies that no point lies
+// inside any circumcircle. The checks take time proportional to the number
+// of triangles, plus sorting the points. If they fail, the points are
+// triangulated again with DivideAndConquer, a different algorithm, and that
+// result is checked and returned instead. A VerifyError is returned if both
+// fail; errors for invalid input are returned as by Triangulate.
+func TriangulateVerified(points []Point) ([]Triangle, error) {
+	triangles, err := Triangulate(points, WithHullCompletion())
+	if err != nil {
+		return nil, err
+	}
+	pts, _ := sortDedup(append([]Point(nil), points...), false)
+	fast := verifyTriangulation(pts, triangles)
+	if fast == nil {
+		return triangles, nil
+	}
+	fast.Stage = "fast"
+
+	triangles = DivideAndConquer(pts)
+	fallback := verifyTriangulation(pts, triangles)
+	if fallback == nil {
+		return triangles, nil
+	}
+	fallback.Stage, fallback.Previous = "fallback", fast
+	return nil, fallback
+}
+
+// verifyTriangulation returns the first failure of the checks described for
+// TriangulateVerified, or nil. pts must be sorted and distinct.
+func verifyTriangulation(pts []Point, triangles []Triangle) *VerifyError {
+	inputs := make(map[Point]bool, len(pts))
+	for _, p := range pts {
+		inputs[p] = true
+	}
+	// owner records the triangle traversing each directed edge
+	// counter-clockwise; adjacent triangles traverse their shared edge in
+	// opposite directions.
+	owner := make(map[Edge]int, 3*len(triangles))
+	vertices := make(map[Point]bool, len(pts))
+	for i, t := range triangles {
+		if orient(t.A, t.B, t.C) <= 0 {
+			return &VerifyError{Check: "orientation", Triangle: t}
+		}
+		for _, v := range [3]Point{t.A, t.B, t.C} {
+			if !inputs[v] {
+				return &VerifyError{Check: "vertex", Triangle: t, Point: v}
+			}
+			vertices[v] = true
+		}
+		for _, e := range [3]Edge{{t.A, t.B}, {t.B, t.C}, {t.C, t.A}} {
+			if _, ok := owner[e]; ok {
+				return &VerifyError{Check: "overlap", Triangle: t, Point: e.A}
+			}
+			owner[e] = i
+		}
+	}
+	for _, p := range pts {
+		if !vertices[p] {
+			return &VerifyError{Check: "vertex", Point: p}
+		}
+	}
+
+	// The boundary edges, those without a reversed twin, must form a
+	// single loop that turns left only at the vertices of the convex hull.
+	// Together with Euler's formula this makes the triangles tile the hull.
+	next := map[Point]Point{}
+	var start Point
+	for _, t := range triangles {
+		for _, e := range [3]Edge{{t.A, t.B}, {t.B, t.C}, {t.C, t.A}} {
+			if _, ok := owner[Edge{e.B, e.A}]; ok {
+				continue
+			}
+			if _, ok := next[e.A]; ok {
+				return &VerifyError{Check: "coverage", Triangle: t, Point: e.A}
+			}
+			if len(next) == 0 {
+				start = e.A
+			}
+			next[e.A] = e.B
+		}
+	}
+	corners := map[Point]bool{}
+	for _, p := range convexHull(pts) {
+		corners[p] = true
+	}
+	n, prev := 0, start
+	for v := next[start]; n < len(next); v = next[v] {
+		w, ok := next[v]
+		if !ok {
+			return &VerifyError{Check: "coverage", Triangle: triangles[owner[Edge{prev, v}]], Point: v}
+		}
+		if o := orient(prev, v, w); o < 0 || o > 0 != corners[v] {
+			return &VerifyError{Check: "coverage", Triangle: triangles[owner[Edge{prev, v}]], Point: v}
+		}
+		n++
+		if v == start {
+			break
+		}
+		prev = v
+	}
+	if n != len(next) || len(triangles) != 2*len(vertices)-2-len(next) {
+		return &VerifyError{Check: "coverage", Point: start}
+	}
+
+	// Check each interior edge against the far vertex of its neighbor.
+	for i, t := range triangles {
+		for _, e := range [3]Edge{{t.A, t.B}, {t.B, t.C}, {t.C, t.A}} {
+			j, ok := owner[Edge{e.B, e.A}]
+			if !ok || j < i {
+				continue
+			}
+			u := triangles[j]
+			far := u.A
+			if far == e.A || far == e.B {
+				far = u.B
+				if far == e.A || far == e.B {
+					far = u.C
+				}
+			}
+			if inCircle(t.A, t.B, t.C, far) > 0 {
+				return &VerifyError{Check: "delaunay", Triangle: t, Point: far}
+			}
+		}
+	}
+	return nil
+}
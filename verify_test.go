@@ -0,0 +1,87 @@
+package bowyer_watson
+
+import (
+	"math"
+	"strings"
+	"testing"
+)
+
+func TestTriangulateVerified(t *testing.T) {
+	var points []Point
+	for i := 0; i < 200; i++ {
+		x, y := getRandomPointInCircle(10)
+		points = append(points, Point{x, y})
+	}
+	// Cocircular points and points along the hull.
+	for i := 0; i < 5; i++ {
+		points = append(points, Point{float64(i), 20}, Point{20, float64(i)})
+	}
+	got, err := TriangulateVerified(points)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := Validate(points, got); err != nil {
+		t.Error(err)
+	}
+
+	// A hull vertex a few ulps off the line through its neighbours.
+	nearlyCollinear := []Point{{0.1, 0.1}, {5.5825, math.Nextafter(5.5825, 0)}, {12.3, 12.3}, {0, 24}}
+	got, err = TriangulateVerified(nearlyCollinear)
+	if err != nil {
+		t.Fatalf("nearly collinear: %v", err)
+	}
+	if err := Validate(nearlyCollinear, got); err != nil {
+		t.Errorf("nearly collinear: %v", err)
+	}
+
+	if _, err := TriangulateVerified(nil); err != ErrEmptyInput {
+		t.Errorf("empty: got error %v, want %v", err, ErrEmptyInput)
+	}
+}
+
+func TestVerifyTriangulation(t *testing.T) {
+	points := []Point{{0, 0}, {4, 0}, {4, 4}, {0, 4}, {1, 2}}
+	pts, _ := sortDedup(append([]Point(nil), points...), false)
+	good := DivideAndConquer(pts)
+	if err := verifyTriangulation(pts, good); err != nil {
+		t.Fatalf("good: %v", err)
+	}
+
+	clone := func() []Triangle { return append([]Triangle(nil), good...) }
+	flipped := clone()
+	flipped[0].B, flipped[0].C = flipped[0].C, flipped[0].B
+	extra := append(clone(), Triangle{A: Point{0, 0}, B: Point{4, 0}, C: Point{9, 9}})
+	overlap := append(clone(), good[0])
+	missing := clone()[1:]
+
+	// Triangles (0,0) (4,0) (1,2) and (1,2) (4,0) (4,4) share an edge; the
+	// other diagonal of their quadrilateral is not Delaunay.
+	nonDelaunay := []Triangle{
+		{A: Point{0, 0}, B: Point{4, 0}, C: Point{4, 4}},
+		{A: Point{0, 0}, B: Point{4, 4}, C: Point{1, 2}},
+		{A: Point{1, 2}, B: Point{4, 4}, C: Point{0, 4}},
+		{A: Point{0, 0}, B: Point{1, 2}, C: Point{0, 4}},
+	}
+
+	for _, tt := range []struct {
+		name      string
+		triangles []Triangle
+		want      string
+	}{
+		{"flipped", flipped, "orientation"},
+		{"extra", extra, "vertex"},
+		{"overlap", overlap, "overlap"},
+		{"missing", missing, "coverage"},
+		{"non-Delaunay", nonDelaunay, "delaunay"},
+	} {
+		err := verifyTriangulation(pts, tt.triangles)
+		if err == nil || err.Check != tt.want {
+			t.Errorf("%s: got %v, want %s failure", tt.name, err, tt.want)
+		}
+	}
+
+	err := &VerifyError{Stage: "fallback", Check: "delaunay", Previous: &VerifyError{Stage: "fast", Check: "coverage"}}
+	if s := err.Error(); !strings.Contains(s, "fallback triangulation failed delaunay") || !strings.Contains(s, "fast triangulation failed coverage") {
+		t.Errorf("Error: got %q", s)
+	}
+}
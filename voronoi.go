@@ -0,0 +1,203 @@
+package bowyer_watson
+
+import "math"
+
+// VoronoiCellAreas returns the area of the Voronoi cell of each element of
+// points, in the same order: the region nearer to it than to any other
+// point, whose area is the inverse of the local point density. The cells of
+// points on the convex hull are unbounded and have area +Inf. Equal points
+// share a cell, and a point merged within Tolerance with another has area
+// NaN. All elements of points must lie inside super, as for
+// DelaunayTriangulation; nil is returned if they cannot be triangulated.
+func VoronoiCellAreas(points []Point, super Triangle) []float64 {
+	return voronoiCellAreas(points, super, nil)
+}
+
+// VoronoiCellAreasClipped is like VoronoiCellAreas but clips every cell to
+// bounds, so that the cells of points on the convex hull have finite areas.
+func VoronoiCellAreasClipped(points []Point, super Triangle, bounds BoundingBox) []float64 {
+	clip := []Point{bounds.Min, {bounds.Max.X, bounds.Min.Y}, bounds.Max, {bounds.Min.X, bounds.Max.Y}}
+	return voronoiCellAreas(points, super, clip)
+}
+
+// voronoiCellAreas implements VoronoiCellAreas, clipping the cells to the
+// convex polygon clip unless it is nil.
+func voronoiCellAreas(points []Point, super Triangle, clip []Point) []float64 {
+	triangles, err := DelaunayTriangulation(points, super, WithHullCompletion())
+	if err != nil {
+		return nil
+	}
+	v := newVoronoiCells(triangles)
+
+	// The rays bounding unbounded cells must reach beyond clip from every
+	// circumcenter.
+	b := PointsBounds(clip)
+	for _, c := range v.circles {
+		b.Min.X, b.Min.Y = math.Min(b.Min.X, c.Center.X), math.Min(b.Min.Y, c.Center.Y)
+		b.Max.X, b.Max.Y = math.Max(b.Max.X, c.Center.X), math.Max(b.Max.Y, c.Center.Y)
+	}
+	diameter := math.Hypot(b.Max.X-b.Min.X, b.Max.Y-b.Min.Y)
+
+	areas := make([]float64, len(points))
+	for i, p := range points {
+		switch {
+		case len(v.incident[p]) == 0:
+			areas[i] = math.NaN()
+		case v.open[p] && clip == nil:
+			areas[i] = math.Inf(1)
+		case clip == nil:
+			areas[i] = polygonArea(v.cell(p, diameter))
+		default:
+			areas[i] = polygonArea(clipPolygon(v.cell(p, diameter), clip))
+		}
+	}
+	return areas
+}
+
+// voronoiCells forms the Voronoi cells of the vertices of a triangulation
+// from the circumcenters of its triangles.
+type voronoiCells struct {
+	triangles []Triangle
+	circles   []Circle
+	incident  map[Point][]int // the triangles with each vertex
+	open      map[Point]bool  // the vertices on the boundary
+}
+
+func newVoronoiCells(triangles []Triangle) *voronoiCells {
+	v := &voronoiCells{
+		triangles: triangles,
+		circles:   Circumcircles(triangles),
+		incident:  map[Point][]int{},
+		open:      map[Point]bool{},
+	}
+	edges := map[Edge]int{}
+	for i, t := range triangles {
+		for _, p := range [3]Point{t.A, t.B, t.C} {
+			v.incident[p] = append(v.incident[p], i)
+		}
+		for _, e := range [3]Edge{{t.A, t.B}, {t.B, t.C}, {t.C, t.A}} {
+			edges[e.canonical()]++
+		}
+	}
+	// Vertices on the boundary of the triangulation do not have a closed
+	// fan of triangles around them.
+	for e, n := range edges {
+		if n == 1 {
+			v.open[e.A], v.open[e.B] = true, true
+		}
+	}
+	return v
+}
+
+// cell returns the counter-clockwise Voronoi cell of vertex p. The cell of
+// a vertex on the boundary is unbounded. Its edges with the boundary
+// neighbors are rays outward from the first and last circumcenters, which
+// are cut off at distance 2*diameter.
+func (v *voronoiCells) cell(p Point, diameter float64) []Point {
+	ts := fan(v.triangles, v.incident[p], p)
+	cell := make([]Point, 0, len(ts)+2)
+	for _, k := range ts {
+		cell = append(cell, v.circles[k].Center)
+	}
+	if v.open[p] {
+		first, _ := spanAround(v.triangles[ts[0]], p)
+		_, last := spanAround(v.triangles[ts[len(ts)-1]], p)
+		c0, c1 := cell[0], cell[len(cell)-1]
+		cell = append([]Point{ray(c0, first.Y-p.Y, p.X-first.X, diameter)}, cell...)
+		cell = append(cell, ray(c1, p.Y-last.Y, last.X-p.X, diameter))
+	}
+	return cell
+}
+
+// polygonArea returns the area of poly by the shoelace formula, positive if
+// its vertices are counter-clockwise.
+func polygonArea(poly []Point) float64 {
+	if len(poly) < 3 {
+		return 0
+	}
+	// Work relative to the first vertex to limit cancellation.
+	o := poly[0]
+	area := 0.0
+	for i, a := range poly {
+		b := poly[(i+1)%len(poly)]
+		area += (a.X-o.X)*(b.Y-o.Y) - (b.X-o.X)*(a.Y-o.Y)
+	}
+	return area / 2
+}
+
+// NaturalNeighborWeights returns the Sibson natural neighbor coordinates of
+// p with respect to points, keyed by index into points. Inserting p into
+// the Voronoi diagram of points gives it a cell made of the areas it takes
+// from the cells of its natural neighbors; the weight of each neighbor is
+// the fraction of p's cell taken from it. The weights are positive, sum to
+// one, and reproduce p as the weighted sum of the neighbors, so they
+// interpolate linear functions exactly. If p is one of points the only
+// weight is 1, for the first index at which it appears. p must lie strictly
+// inside the convex hull of points, where its cell is bounded; otherwise,
+// or if the points cannot be triangulated, nil is returned.
+func NaturalNeighborWeights(p Point, points []Point, super Triangle) map[int]float64 {
+	triangles, err := DelaunayTriangulation(points, super, WithHullCompletion())
+	if err != nil {
+		return nil
+	}
+	for i, q := range points {
+		if PointEqual(p, q, Tolerance) {
+			return map[int]float64{i: 1}
+		}
+	}
+	v := newVoronoiCells(triangles)
+	index := make(map[Point]int, len(points))
+	for i := len(points) - 1; i >= 0; i-- {
+		index[points[i]] = i
+	}
+
+	hull := convexHull(points)
+	for i, a := range hull {
+		if orient(a, hull[(i+1)%len(hull)], p) <= 0 {
+			return nil
+		}
+	}
+	triangles, err = DelaunayTriangulation(append(points[:len(points):len(points)], p), super, WithHullCompletion())
+	if err != nil {
+		return nil
+	}
+	w := newVoronoiCells(triangles)
+
+	// The rays bounding the unbounded cells of hull points must reach
+	// beyond p's cell.
+	b := PointsBounds(points)
+	for _, circles := range [2][]Circle{v.circles, w.circles} {
+		for _, c := range circles {
+			b.Min.X, b.Min.Y = math.Min(b.Min.X, c.Center.X), math.Min(b.Min.Y, c.Center.Y)
+			b.Max.X, b.Max.Y = math.Max(b.Max.X, c.Center.X), math.Max(b.Max.Y, c.Center.Y)
+		}
+	}
+	diameter := math.Hypot(b.Max.X-b.Min.X, b.Max.Y-b.Min.Y)
+	cell := w.cell(p, diameter)
+
+	weights := map[int]float64{}
+	total := 0.0
+	for _, k := range w.incident[p] {
+		t := w.triangles[k]
+		for _, q := range [3]Point{t.A, t.B, t.C} {
+			i, ok := index[q]
+			if q == p || !ok {
+				continue
+			}
+			if _, done := weights[i]; done {
+				continue
+			}
+			area := polygonArea(clipPolygon(v.cell(q, diameter), cell))
+			weights[i] = area
+			total += area
+		}
+	}
+	for i, area := range weights {
+		if area <= 0 {
+			delete(weights, i)
+		} else {
+			weights[i] = area / total
+		}
+	}
+	return weights
+}
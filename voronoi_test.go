@@ -0,0 +1,120 @@
+package bowyer_watson
+
+import (
+	"math"
+	"testing"
+)
+
+func TestVoronoiCellAreas(t *testing.T) {
+	// A 6×6 grid with spacing 2: the interior cells are 2×2 squares.
+	var points []Point
+	for i := 0; i < 6; i++ {
+		for j := 0; j < 6; j++ {
+			points = append(points, Point{float64(2 * i), float64(2 * j)})
+		}
+	}
+	points = append(points, points[7])
+	super := SuperTriangleFor(points, 10)
+
+	areas := VoronoiCellAreas(points, super)
+	if len(areas) != len(points) {
+		t.Fatalf("#areas: got %v, want %v", len(areas), len(points))
+	}
+	for i, p := range points {
+		interior := p.X > 0 && p.X < 10 && p.Y > 0 && p.Y < 10
+		if interior && math.Abs(areas[i]-4) > 1e-12 {
+			t.Errorf("%v: got area %v, want 4", p, areas[i])
+		}
+		if !interior && !math.IsInf(areas[i], 1) {
+			t.Errorf("%v: got area %v, want +Inf", p, areas[i])
+		}
+	}
+
+	// Clipped to a box 1 beyond the grid, every cell is a 2×2 square.
+	clipped := VoronoiCellAreasClipped(points, super, BoundingBox{Min: Point{-1, -1}, Max: Point{11, 11}})
+	for i, p := range points {
+		if math.Abs(clipped[i]-4) > 1e-9 {
+			t.Errorf("clipped: %v: got area %v, want 4", p, clipped[i])
+		}
+	}
+
+	defer func(tol float64) { Tolerance = tol }(Tolerance)
+	Tolerance = 0.1
+	merged := append([]Point{{4, 4.05}}, points...)
+	if areas := VoronoiCellAreas(merged, super); !math.IsNaN(areas[0]) || areas[1+2*6+2] != 4 {
+		t.Errorf("merged: got areas %v and %v, want NaN and 4", areas[0], areas[1+2*6+2])
+	}
+
+	if areas := VoronoiCellAreas(nil, super); areas != nil {
+		t.Errorf("empty: got %v, want nil", areas)
+	}
+}
+
+func TestPolygonArea(t *testing.T) {
+	square := []Point{{1, 1}, {3, 1}, {3, 3}, {1, 3}}
+	if got := polygonArea(square); got != 4 {
+		t.Errorf("ccw: got %v, want 4", got)
+	}
+	cw := []Point{{1, 1}, {1, 3}, {3, 3}, {3, 1}}
+	if got := polygonArea(cw); got != -4 {
+		t.Errorf("cw: got %v, want -4", got)
+	}
+	if got := polygonArea(square[:2]); got != 0 {
+		t.Errorf("segment: got %v, want 0", got)
+	}
+}
+
+func TestNaturalNeighborWeights(t *testing.T) {
+	points := make([]Point, 100)
+	for i := range points {
+		x, y := getRandomPointInCircle(10)
+		points[i] = Point{x, y}
+	}
+	super := SuperTriangleFor(points, 10)
+
+	for _, p := range []Point{{0, 0}, {1.5, -2.25}, {-3, 4}, {0.1, 0.2}} {
+		w := NaturalNeighborWeights(p, points, super)
+		if len(w) < 3 {
+			t.Fatalf("%v: got weights %v, want at least 3", p, w)
+		}
+		sum := 0.0
+		var q Point
+		for i, wi := range w {
+			if wi <= 0 {
+				t.Errorf("%v: weight %d is %v", p, i, wi)
+			}
+			sum += wi
+			q.X += wi * points[i].X
+			q.Y += wi * points[i].Y
+		}
+		if math.Abs(sum-1) > 1e-12 {
+			t.Errorf("%v: weights sum to %v", p, sum)
+		}
+		// The weights reproduce linear functions, including the
+		// coordinates themselves.
+		if math.Abs(q.X-p.X) > 1e-9 || math.Abs(q.Y-p.Y) > 1e-9 {
+			t.Errorf("%v: weighted sum of neighbors is %v", p, q)
+		}
+	}
+
+	// At a data site all the weight is on it, and near one almost all. The
+	// site nearest the origin is well inside the hull.
+	k := 0
+	for i, p := range points {
+		if math.Hypot(p.X, p.Y) < math.Hypot(points[k].X, points[k].Y) {
+			k = i
+		}
+	}
+	points = append(points, points[k])
+	if w := NaturalNeighborWeights(points[k], points, super); len(w) != 1 || w[k] != 1 {
+		t.Errorf("data site: got %v, want only %d with weight 1", w, k)
+	}
+	near := Point{points[k].X + 1e-6, points[k].Y}
+	if w := NaturalNeighborWeights(near, points, super); w[k] < 0.99 {
+		t.Errorf("near a data site: got weight %v, want nearly 1", w[k])
+	}
+
+	if w := NaturalNeighborWeights(Point{20, 20}, points, super); w != nil {
+		t.Errorf("outside the hull: got %v, want nil", w)
+	}
+}
@@ -0,0 +1,259 @@
+package bowyer_watson
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// TriangleToWKT returns t as a Well-Known Text polygon, with its ring closed
+// by repeating A: POLYGON ((ax ay, bx by, cx cy, ax ay)). The vertices keep
+// their order in t.
+func TriangleToWKT(t Triangle) string {
+	var b strings.Builder
+	b.WriteString("POLYGON ")
+	writeWKTRing(&b, t)
+	return b.String()
+}
+
+// TrianglesToWKT returns triangles as a Well-Known Text GEOMETRYCOLLECTION
+// of polygons formatted as by TriangleToWKT, or GEOMETRYCOLLECTION EMPTY
+// if there are none.
+func TrianglesToWKT(triangles []Triangle) string {
+	if len(triangles) == 0 {
+		return "GEOMETRYCOLLECTION EMPTY"
+	}
+	var b strings.Builder
+	b.WriteString("GEOMETRYCOLLECTION (")
+	for i, t := range triangles {
+		if i > 0 {
+			b.WriteString(", ")
+		}
+		b.WriteString("POLYGON ")
+		writeWKTRing(&b, t)
+	}
+	b.WriteString(")")
+	return b.String()
+}
+
+func writeWKTRing(b *strings.Builder, t Triangle) {
+	b.WriteString("((")
+	for i, p := range [4]Point{t.A, t.B, t.C, t.A} {
+		if i > 0 {
+			b.WriteString(", ")
+		}
+		b.WriteString(formatFloat(p.X))
+		b.WriteString(" ")
+		b.WriteString(formatFloat(p.Y))
+	}
+	b.WriteString("))")
+}
+
+// ParsePointsWKT parses a Well-Known Text MULTIPOINT or POINT. Keywords are
+// case-insensitive, and the points of a MULTIPOINT may be given with or
+// without their own parentheses: MULTIPOINT ((1 2), (3 4)) and
+// MULTIPOINT (1 2, 3 4) are equivalent. An EMPTY geometry has no points.
+// Points with Z or M coordinates are not supported.
+func ParsePointsWKT(wkt string) ([]Point, error) {
+	s := strings.TrimSpace(wkt)
+	i := strings.IndexAny(s, " (")
+	if i < 0 {
+		i = len(s)
+	}
+	kind := strings.ToUpper(s[:i])
+	body := strings.TrimSpace(s[i:])
+	if kind != "MULTIPOINT" && kind != "POINT" {
+		return nil, fmt.Errorf("bowyer_watson: wkt: unsupported geometry %q", s[:i])
+	}
+	if strings.EqualFold(body, "EMPTY") {
+		return nil, nil
+	}
+	if len(body) < 2 || body[0] != '(' || body[len(body)-1] != ')' {
+		return nil, errors.New("bowyer_watson: wkt: missing parentheses")
+	}
+	body = body[1 : len(body)-1]
+
+	var points []Point
+	for _, f := range strings.Split(body, ",") {
+		f = strings.TrimSpace(f)
+		if kind == "MULTIPOINT" && strings.HasPrefix(f, "(") && strings.HasSuffix(f, ")") {
+			f = strings.TrimSpace(f[1 : len(f)-1])
+		}
+		if strings.EqualFold(f, "EMPTY") {
+			continue
+		}
+		p, err := parseWKTPoint(f)
+		if err != nil {
+			return nil, err
+		}
+		points = append(points, p)
+	}
+	if kind == "POINT" && len(points) != 1 {
+		return nil, errors.New("bowyer_watson: wkt: POINT must have one point")
+	}
+	return points, nil
+}
+
+func parseWKTPoint(s string) (Point, error) {
+	fields := strings.Fields(s)
+	if len(fields) != 2 {
+		return Point{}, fmt.Errorf("bowyer_watson: wkt: point %q must have two coordinates", s)
+	}
+	x, err := strconv.ParseFloat(fields[0], 64)
+	if err != nil {
+		return Point{}, fmt.Errorf("bowyer_watson: wkt: %v", err)
+	}
+	y, err := strconv.ParseFloat(fields[1], 64)
+	if err != nil {
+		return Point{}, fmt.Errorf("bowyer_watson: wkt: %v", err)
+	}
+	return Point{x, y}, nil
+}
+
+// Well-Known Binary geometry types.
+const (
+	wkbPoint              = 1
+	wkbPolygon            = 3
+	wkbMultiPoint         = 4
+	wkbGeometryCollection = 7
+)
+
+// TriangleToWKB returns t as a Well-Known Binary polygon, like
+// TriangleToWKT, in the byte order given by order, which must be
+// binary.LittleEndian or binary.BigEndian.
+func TriangleToWKB(t Triangle, order binary.ByteOrder) []byte {
+	return appendWKBPolygon(make([]byte, 0, wkbPolygonSize), t, order)
+}
+
+// TrianglesToWKB returns triangles as a Well-Known Binary geometry
+// collection of polygons, like TrianglesToWKT, in the byte order given by
+// order, which must be binary.LittleEndian or binary.BigEndian.
+func TrianglesToWKB(triangles []Triangle, order binary.ByteOrder) []byte {
+	b := make([]byte, 0, 9+len(triangles)*wkbPolygonSize)
+	b = appendWKBHeader(b, wkbGeometryCollection, order)
+	b = appendUint32(b, order, uint32(len(triangles)))
+	for _, t := range triangles {
+		b = appendWKBPolygon(b, t, order)
+	}
+	return b
+}
+
+// wkbPolygonSize is the length of a triangle encoded by appendWKBPolygon:
+// a header, the ring and point counts, and four points.
+const wkbPolygonSize = 5 + 4 + 4 + 4*16
+
+func appendWKBPolygon(b []byte, t Triangle, order binary.ByteOrder) []byte {
+	b = appendWKBHeader(b, wkbPolygon, order)
+	b = appendUint32(b, order, 1)
+	b = appendUint32(b, order, 4)
+	for _, p := range [4]Point{t.A, t.B, t.C, t.A} {
+		b = appendFloat64(b, order, p.X)
+		b = appendFloat64(b, order, p.Y)
+	}
+	return b
+}
+
+// appendWKBHeader appends the byte order marker, 0 for big-endian and 1 for
+// little-endian, and the geometry type.
+func appendWKBHeader(b []byte, typ uint32, order binary.ByteOrder) []byte {
+	if order == binary.BigEndian {
+		b = append(b, 0)
+	} else {
+		b = append(b, 1)
+	}
+	return appendUint32(b, order, typ)
+}
+
+func appendUint32(b []byte, order binary.ByteOrder, v uint32) []byte {
+	var buf [4]byte
+	order.PutUint32(buf[:], v)
+	return append(b, buf[:]...)
+}
+
+func appendFloat64(b []byte, order binary.ByteOrder, f float64) []byte {
+	var buf [8]byte
+	order.PutUint64(buf[:], math.Float64bits(f))
+	return append(b, buf[:]...)
+}
+
+// ParsePointsWKB parses a Well-Known Binary MultiPoint or Point, in either
+// byte order. Each point of a MultiPoint carries its own byte order marker,
+// which is respected. Points with Z or M coordinates are not supported.
+func ParsePointsWKB(data []byte) ([]Point, error) {
+	typ, order, data, err := readWKBHeader(data)
+	if err != nil {
+		return nil, err
+	}
+	switch typ {
+	case wkbPoint:
+		p, rest, err := readWKBPoint(data, order)
+		if err != nil {
+			return nil, err
+		}
+		if len(rest) != 0 {
+			return nil, errors.New("bowyer_watson: wkb: trailing data")
+		}
+		return []Point{p}, nil
+	case wkbMultiPoint:
+	default:
+		return nil, fmt.Errorf("bowyer_watson: wkb: unsupported geometry type %d", typ)
+	}
+
+	if len(data) < 4 {
+		return nil, errors.New("bowyer_watson: wkb: truncated")
+	}
+	n := order.Uint32(data)
+	data = data[4:]
+	// Each point takes 21 bytes, so a count the data cannot hold is
+	// rejected before it is used to allocate.
+	if uint64(n)*21 > uint64(len(data)) {
+		return nil, errors.New("bowyer_watson: wkb: truncated")
+	}
+	points := make([]Point, 0, n)
+	for i := uint32(0); i < n; i++ {
+		var typ uint32
+		var order binary.ByteOrder
+		typ, order, data, err = readWKBHeader(data)
+		if err != nil {
+			return nil, err
+		}
+		if typ != wkbPoint {
+			return nil, fmt.Errorf("bowyer_watson: wkb: MultiPoint holds geometry type %d", typ)
+		}
+		var p Point
+		if p, data, err = readWKBPoint(data, order); err != nil {
+			return nil, err
+		}
+		points = append(points, p)
+	}
+	if len(data) != 0 {
+		return nil, errors.New("bowyer_watson: wkb: trailing data")
+	}
+	return points, nil
+}
+
+func readWKBHeader(data []byte) (typ uint32, order binary.ByteOrder, rest []byte, err error) {
+	if len(data) < 5 {
+		return 0, nil, nil, errors.New("bowyer_watson: wkb: truncated")
+	}
+	switch data[0] {
+	case 0:
+		order = binary.BigEndian
+	case 1:
+		order = binary.LittleEndian
+	default:
+		return 0, nil, nil, fmt.Errorf("bowyer_watson: wkb: invalid byte order %d", data[0])
+	}
+	return order.Uint32(data[1:]), order, data[5:], nil
+}
+
+func readWKBPoint(data []byte, order binary.ByteOrder) (Point, []byte, error) {
+	if len(data) < 16 {
+		return Point{}, nil, errors.New("bowyer_watson: wkb: truncated")
+	}
+	p := Point{math.Float64frombits(order.Uint64(data)), math.Float64frombits(order.Uint64(data[8:]))}
+	return p, data[16:], nil
+}
@@ -0,0 +1,123 @@
+package bowyer_watson
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/hex"
+	"reflect"
+	"testing"
+)
+
+func TestTriangleToWKT(t *testing.T) {
+	tri := Triangle{A: Point{0, 0}, B: Point{1.5, 0}, C: Point{0, -2e-7}}
+	want := "POLYGON ((0 0, 1.5 0, 0 -2e-07, 0 0))"
+	if got := TriangleToWKT(tri); got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+
+	other := Triangle{A: Point{1, 1}, B: Point{2, 1}, C: Point{1, 2}}
+	want = "GEOMETRYCOLLECTION (POLYGON ((0 0, 1.5 0, 0 -2e-07, 0 0)), POLYGON ((1 1, 2 1, 1 2, 1 1)))"
+	if got := TrianglesToWKT([]Triangle{tri, other}); got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+	if got, want := TrianglesToWKT(nil), "GEOMETRYCOLLECTION EMPTY"; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
+
+func TestParsePointsWKT(t *testing.T) {
+	want := []Point{{1, 2}, {-3.5, 4e10}}
+	for _, s := range []string{
+		"MULTIPOINT ((1 2), (-3.5 4e10))",
+		"MULTIPOINT (1 2, -3.5 4e10)",
+		"  multipoint((1 2),(-3.5   4e10))  ",
+	} {
+		got, err := ParsePointsWKT(s)
+		if err != nil {
+			t.Errorf("%q: %v", s, err)
+			continue
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("%q: got %v, want %v", s, got, want)
+		}
+	}
+
+	if got, err := ParsePointsWKT("POINT (5 6)"); err != nil || !reflect.DeepEqual(got, []Point{{5, 6}}) {
+		t.Errorf("POINT: got %v, %v", got, err)
+	}
+	if got, err := ParsePointsWKT("MULTIPOINT EMPTY"); err != nil || len(got) != 0 {
+		t.Errorf("EMPTY: got %v, %v", got, err)
+	}
+
+	for _, s := range []string{
+		"",
+		"POLYGON ((0 0, 1 0, 0 1, 0 0))",
+		"MULTIPOINT (1 2",
+		"MULTIPOINT ((1 2 3))",
+		"MULTIPOINT Z ((1 2 3))",
+		"MULTIPOINT ((1 x))",
+		"POINT (1 2, 3 4)",
+	} {
+		if _, err := ParsePointsWKT(s); err == nil {
+			t.Errorf("%q: got no error", s)
+		}
+	}
+}
+
+func TestTriangleToWKB(t *testing.T) {
+	tri := Triangle{A: Point{0, 0}, B: Point{1, 0}, C: Point{0, 1}}
+	want := "01" + "03000000" + "01000000" + "04000000" +
+		"0000000000000000" + "0000000000000000" +
+		"000000000000f03f" + "0000000000000000" +
+		"0000000000000000" + "000000000000f03f" +
+		"0000000000000000" + "0000000000000000"
+	if got := hex.EncodeToString(TriangleToWKB(tri, binary.LittleEndian)); got != want {
+		t.Errorf("little-endian: got %s, want %s", got, want)
+	}
+	want = "00" + "00000003" + "00000001" + "00000004" +
+		"0000000000000000" + "0000000000000000" +
+		"3ff0000000000000" + "0000000000000000" +
+		"0000000000000000" + "3ff0000000000000" +
+		"0000000000000000" + "0000000000000000"
+	if got := hex.EncodeToString(TriangleToWKB(tri, binary.BigEndian)); got != want {
+		t.Errorf("big-endian: got %s, want %s", got, want)
+	}
+
+	got := TrianglesToWKB([]Triangle{tri, tri}, binary.BigEndian)
+	head := []byte{0, 0, 0, 0, 7, 0, 0, 0, 2}
+	if !bytes.HasPrefix(got, head) || !bytes.Equal(got[len(head):], append(TriangleToWKB(tri, binary.BigEndian), TriangleToWKB(tri, binary.BigEndian)...)) {
+		t.Errorf("collection: got %x", got)
+	}
+}
+
+func TestParsePointsWKB(t *testing.T) {
+	// POINT (1 2), little-endian.
+	point, _ := hex.DecodeString("0101000000000000000000f03f0000000000000040")
+	got, err := ParsePointsWKB(point)
+	if err != nil || !reflect.DeepEqual(got, []Point{{1, 2}}) {
+		t.Errorf("point: got %v, %v", got, err)
+	}
+
+	// A big-endian MultiPoint holding a little-endian and a big-endian
+	// point.
+	multi, _ := hex.DecodeString("00" + "00000004" + "00000002" +
+		"0101000000000000000000f03f0000000000000040" +
+		"000000000140080000000000004010000000000000")
+	got, err = ParsePointsWKB(multi)
+	if want := []Point{{1, 2}, {3, 4}}; err != nil || !reflect.DeepEqual(got, want) {
+		t.Errorf("multipoint: got %v, %v, want %v", got, err, want)
+	}
+
+	for name, data := range map[string][]byte{
+		"empty":      nil,
+		"truncated":  multi[:len(multi)-1],
+		"trailing":   append(append([]byte(nil), point...), 0),
+		"byte order": append([]byte{2}, point[1:]...),
+		"polygon":    TriangleToWKB(Triangle{}, binary.LittleEndian),
+		"huge count": {1, 4, 0, 0, 0, 0xff, 0xff, 0xff, 0xff},
+	} {
+		if _, err := ParsePointsWKB(data); err == nil {
+			t.Errorf("%s: got no error", name)
+		}
+	}
+}